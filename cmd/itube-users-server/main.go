@@ -2,15 +2,24 @@ package main
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"itube/users/internal/db"
+	"itube/users/internal/oidc"
 	"itube/users/internal/rpc"
 	"itube/users/internal/sender"
 	"itube/users/pkg/api"
 	"itube/users/pkg/email"
+	"itube/users/pkg/keyring"
 	"itube/users/pkg/openid"
+	"itube/users/pkg/openid/openidtest"
+	"itube/users/pkg/passhash"
+	"itube/users/pkg/passpolicy"
+	"itube/users/pkg/session"
 	"itube/users/pkg/tools"
 	"net"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/namsral/flag"
@@ -20,8 +29,11 @@ import (
 var (
 	// DefaultPort задает порт по умолчанию для gRPC сервера.
 	DefaultPort = 50051
+	// DefaultHTTPPort задает порт по умолчанию для HTTP сервера авторизации
+	// OpenID Connect.
+	DefaultHTTPPort = 8080
 	// SMTPSleep определяет время ожидания между проверками новых токенов для
-	// отправки.
+	// отправки на случай пропущенных уведомлений о них.
 	SMTPSleep = time.Minute * 5
 )
 
@@ -42,6 +54,69 @@ func main() {
 		smtp           = flag.String("smtp", "", "smtp server url")
 		tmpltsPath     = flag.String("templates", "../templates/emails.yaml",
 			"file with email templates")
+		emailTokenTTL = flag.Duration("email_token_ttl", time.Hour*24*3,
+			"email verification token lifetime")
+		passwordTokenTTL = flag.Duration("password_token_ttl", time.Hour,
+			"password reset token lifetime")
+		jwtKey = flag.String("jwt_key", "",
+			"PEM file with private key for signing access tokens")
+		jwtIssuer      = flag.String("jwt_issuer", session.DefaultIssuer, "access token issuer")
+		accessTokenTTL = flag.Duration("access_token_ttl", session.DefaultTTL,
+			"access token lifetime")
+		sessionTTL = flag.Duration("session_ttl", db.DefaultSessionTTL,
+			"refresh token lifetime")
+		loginFailures = flag.Int("login_failures", db.DefaultThrottle.EmailFailures,
+			"failed login attempts per email before lockout")
+		ipLoginFailures = flag.Int("ip_login_failures", db.DefaultThrottle.IPFailures,
+			"failed login attempts per client IP before lockout")
+		lockout = flag.Duration("lockout", db.DefaultThrottle.Lockout,
+			"initial login lockout duration")
+		maxLockout = flag.Duration("max_lockout", db.DefaultThrottle.MaxLockout,
+			"maximum login lockout duration")
+		lockoutWindow = flag.Duration("lockout_window", db.DefaultThrottle.Window,
+			"failed login attempts counting window")
+		emailAttempts = flag.Int("email_attempts", db.DefaultRetry.Attempts,
+			"email send attempts before giving up (0 for unlimited)")
+		emailRetryDelay = flag.Duration("email_retry_delay", db.DefaultRetry.Delay,
+			"initial delay before email send retry")
+		emailMaxRetryDelay = flag.Duration("email_max_retry_delay", db.DefaultRetry.MaxDelay,
+			"maximum delay before email send retry")
+		trustedProxies = flag.String("trusted_proxies", "",
+			"comma-separated list of trusted proxy IPs or CIDRs passing client address")
+		privateDomains = flag.String("private_domains", "",
+			"comma-separated list of domains hiding whether user is registered (* for all)")
+		passwordHash = flag.String("password_hash", "bcrypt",
+			"password hashing algorithm: bcrypt or argon2id")
+		bcryptCost = flag.Int("bcrypt_cost", passhash.DefaultBcryptCost,
+			"bcrypt password hashing cost")
+		argon2Time = flag.Uint("argon2_time", uint(passhash.DefaultArgon2id.Time),
+			"argon2id password hashing iterations")
+		argon2Memory = flag.Uint("argon2_memory", uint(passhash.DefaultArgon2id.Memory),
+			"argon2id password hashing memory in KiB")
+		argon2Threads = flag.Uint("argon2_threads", uint(passhash.DefaultArgon2id.Threads),
+			"argon2id password hashing parallelism")
+		passwordPolicy = flag.String("password_policy", "",
+			"file with password policies for domains")
+		breachedPasswords = flag.String("breached_passwords", "",
+			"file with breached passwords SHA-1 hashes ordered by hash")
+		openidStates = flag.String("openid_states", "postgres",
+			"openid login states storage: postgres or memory")
+		googleRedirectURIs = flag.String("google_redirect_uris", "",
+			"comma-separated list of allowed google redirect uris")
+		redirectURIs = flag.String("redirect_uris", "",
+			"file with allowed openid redirect uris for domains")
+		providersConfig = flag.String("providers", "",
+			"file with openid connect providers configuration")
+		tokenKeys = flag.String("token_keys", "",
+			"file with keys for encrypting stored provider tokens")
+		devOpenID = flag.String("dev_openid", "",
+			"name of local test openid connect provider for development")
+		devInsecure = flag.Bool("dev_insecure", false,
+			"allow insecure development mode required by dev_openid")
+		oidcIssuer = flag.String("oidc_issuer", "",
+			"public url of openid connect provider for client apps")
+		httpPort = flag.Int("http_port", DefaultHTTPPort,
+			"openid connect provider http port")
 	)
 	flag.Parse()
 	// устанавливаем уровень логирования
@@ -58,27 +133,197 @@ func main() {
 		log.WithError(err).Fatal("error connecting to PostgreSQL database")
 	}
 	defer pool.Close()
+	// контекст фоновых задач отменяется при завершении работы
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	// алгоритм хеширования паролей
+	hasher, err := passhash.New(*passwordHash)
+	if err != nil {
+		log.WithError(err).Fatal("password hasher initialization error")
+	}
+	switch h := hasher.(type) {
+	case passhash.Bcrypt:
+		h.Cost = *bcryptCost
+		hasher = h
+	case passhash.Argon2id:
+		h.Time = uint32(*argon2Time)
+		h.Memory = uint32(*argon2Memory)
+		h.Threads = uint8(*argon2Threads)
+		hasher = h
+	}
 	// прослойка для работы с базой данных
-	var adapter = &db.Adapter{Pool: pool}
-	// инициализируем провайдера авторизации Google
-	googleProvider, err := openid.NewGoogle(*googleClientID, *googleSecret)
+	var adapter = &db.Adapter{
+		Pool: pool,
+		TokenTTL: map[int32]time.Duration{
+			int32(api.EMAIL):    *emailTokenTTL,
+			int32(api.PASSWORD): *passwordTokenTTL,
+		},
+		SessionTTL: *sessionTTL,
+		Throttle: db.Throttle{
+			EmailFailures: *loginFailures,
+			IPFailures:    *ipLoginFailures,
+			Lockout:       *lockout,
+			MaxLockout:    *maxLockout,
+			Window:        *lockoutWindow,
+		},
+		Retry: db.Retry{
+			Attempts: *emailAttempts,
+			Delay:    *emailRetryDelay,
+			MaxDelay: *emailMaxRetryDelay,
+		},
+		Hasher: hasher,
+	}
+	// загружаем политики паролей и список скомпрометированных паролей
+	var passwords = new(rpc.Passwords)
+	if *passwordPolicy != "" {
+		passwords.Policies, err = passpolicy.Load(*passwordPolicy)
+		if err != nil {
+			log.WithError(err).Fatal("password policy loading error")
+		}
+	}
+	if *breachedPasswords != "" {
+		passwords.Breached, err = passpolicy.OpenBreached(*breachedPasswords)
+		if err != nil {
+			log.WithError(err).Fatal("breached passwords file error")
+		}
+		defer passwords.Breached.Close()
+	}
+	// загружаем ключ для подписи токенов доступа
+	var signingKey crypto.Signer
+	if *jwtKey != "" {
+		signingKey, err = session.LoadKey(*jwtKey)
+	} else {
+		log.Warn("access token signing key is not set, using temporary key")
+		signingKey, err = session.GenerateKey()
+	}
+	if err != nil {
+		log.WithError(err).Fatal("access token signing key error")
+	}
+	issuer, err := session.NewIssuer(*jwtIssuer, *accessTokenTTL, signingKey)
 	if err != nil {
-		log.WithError(err).Fatal("authorization provider initialization error")
+		log.WithError(err).Fatal("access token issuer initialization error")
+	}
+	// хранилище состояний авторизации через внешних провайдеров
+	var states openid.StateStore
+	switch *openidStates {
+	case "memory":
+		states = openid.NewMemoryStates()
+	case "postgres":
+		var pgStates = &db.OpenIDStates{Adapter: adapter}
+		states = pgStates
+		// периодически удаляем устаревшие состояния авторизации
+		go cleanup(ctx, "openid states", openid.StateTTL, pgStates.Cleanup)
+	default:
+		log.WithField("storage", *openidStates).Fatal("unsupported openid states storage")
+	}
+	// периодически удаляем устаревшие запросы на привязку провайдеров
+	go cleanup(ctx, "pending links", db.LinkTTL, adapter.LinkCleanup)
+	// периодически удаляем устаревшие запросы второго фактора
+	go cleanup(ctx, "second factor challenges", db.ChallengeTTL,
+		adapter.ChallengeCleanup)
+	// инициализируем провайдеров авторизации из файла конфигурации
+	var providers []openid.Authenticator
+	if *providersConfig != "" {
+		configs, err := openid.LoadConfig(*providersConfig)
+		if err != nil {
+			log.WithError(err).Fatal("providers config loading error")
+		}
+		for _, cfg := range configs {
+			cfg.States = states
+			provider, err := openid.NewAuthenticator(cfg)
+			if err != nil {
+				log.WithError(err).WithField("provider", cfg.Name).
+					Fatal("authorization provider initialization error")
+			}
+			providers = append(providers, provider)
+			log.WithFields(log.Fields{
+				"provider": provider.String(),
+				"clientID": cfg.СlientID,
+			}).Info("authorization provider initialized")
+		}
+	}
+	// инициализируем провайдера авторизации Google, если он задан параметрами
+	if *googleClientID != "" {
+		var googleRedirects openid.RedirectURIs
+		if *googleRedirectURIs != "" {
+			googleRedirects = strings.Split(*googleRedirectURIs, ",")
+		}
+		googleProvider, err := openid.NewGoogle(*googleClientID, *googleSecret,
+			googleRedirects, states)
+		if err != nil {
+			log.WithError(err).Fatal("authorization provider initialization error")
+		}
+		providers = append(providers, googleProvider)
+		log.WithFields(log.Fields{
+			"provider": googleProvider.String(),
+			"clientID": *googleClientID,
+		}).Info("authorization provider initialized")
+	}
+	// запускаем локальный тестовый провайдер OpenID Connect для разработки
+	if *devOpenID != "" {
+		// провайдер авторизует любого пользователя без пароля, поэтому
+		// запускается только в явно заданном режиме разработки
+		if !*devInsecure {
+			log.Fatal("development provider requires dev_insecure mode")
+		}
+		var devServer = openidtest.NewServer()
+		defer devServer.Close()
+		var cfg = devServer.Config(*devOpenID)
+		cfg.Title = "Development"
+		cfg.States = states
+		// email-адреса провайдера никогда не считаются подтвержденными, чтобы
+		// через него нельзя было войти в чужую учетную запись
+		cfg.TrustEmail = openid.TrustNever
+		devProvider, err := openid.New(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("development provider initialization error")
+		}
+		providers = append(providers, devProvider)
+		log.WithFields(log.Fields{
+			"provider": devProvider.String(),
+			"url":      devServer.URL,
+		}).Warn("development authorization provider initialized")
 	}
-	log.WithFields(log.Fields{
-		"provider": googleProvider.String(),
-		"clientID": *googleClientID,
-	}).Info("authorization provider initialized")
 	// инициализируем порт для grpc
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
 	if err != nil {
 		log.WithError(err).Fatal("init grpc listener port error")
 	}
 	defer listener.Close()
+	// адрес клиента из заголовков принимаем только от доверенных прокси
+	rpc.TrustedProxies, err = tools.ParseProxies(*trustedProxies)
+	if err != nil {
+		log.WithError(err).Fatal("trusted proxies parsing error")
+	}
 	// регистриуем grpc сервисы
 	var grpcServer = tools.InitGRPCServer(log.WithField("module", "grpc"))
-	api.RegisterIdentityServer(grpcServer, rpc.NewIdentity(adapter))
-	api.RegisterOpenIDServer(grpcServer, rpc.NewOpenID(adapter, googleProvider))
+	var sessions = rpc.NewSessions(adapter, issuer)
+	var identity = rpc.NewIdentity(adapter, sessions)
+	identity.Passwords = passwords
+	if *privateDomains != "" {
+		identity.Private = strings.Split(*privateDomains, ",")
+	}
+	api.RegisterIdentityServer(grpcServer, identity)
+	var openID = rpc.NewOpenID(adapter, sessions, providers...)
+	openID.Private = identity.Private
+	if *redirectURIs != "" {
+		openID.RedirectURIs, err = openid.LoadRedirectURIs(*redirectURIs)
+		if err != nil {
+			log.WithError(err).Fatal("redirect uris loading error")
+		}
+	}
+	if *tokenKeys != "" {
+		openID.Keyring, err = keyring.Load(*tokenKeys)
+		if err != nil {
+			log.WithError(err).Fatal("token keys loading error")
+		}
+	}
+	api.RegisterOpenIDServer(grpcServer, openID)
+	var tokens = rpc.NewTokens(adapter, sessions)
+	tokens.Passwords = passwords
+	api.RegisterTokensServer(grpcServer, tokens)
+	api.RegisterSessionsServer(grpcServer, sessions)
+	api.RegisterTwoFactorServer(grpcServer, rpc.NewTwoFactor(adapter, sessions))
 	go func() {
 		err := grpcServer.Serve(listener)
 		if err != nil {
@@ -86,6 +331,37 @@ func main() {
 		}
 	}()
 	log.WithField("port", *port).Infof("grpc server started")
+	// запускаем сервер авторизации OpenID Connect для приложений
+	var httpServer *http.Server
+	if *oidcIssuer != "" {
+		oidcTokens, err := session.NewIssuer(*oidcIssuer, *accessTokenTTL, signingKey)
+		if err != nil {
+			log.WithError(err).Fatal("openid connect issuer initialization error")
+		}
+		handler, err := oidc.NewServer(adapter, oidcTokens)
+		if err != nil {
+			log.WithError(err).Fatal("openid connect server initialization error")
+		}
+		handler.Proxies = rpc.TrustedProxies
+		httpServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", *httpPort),
+			Handler:           handler,
+			ReadHeaderTimeout: time.Second * 10,
+		}
+		go func() {
+			err := httpServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.WithError(err).Fatal("openid connect server error")
+			}
+		}()
+		// периодически удаляем устаревшие коды авторизации
+		go cleanup(ctx, "authorization codes", db.AuthCodeTTL,
+			adapter.AuthCodeCleanup)
+		log.WithFields(log.Fields{
+			"port":   *httpPort,
+			"issuer": *oidcIssuer,
+		}).Info("openid connect server started")
+	}
 
 	// инициализируем почтовые шаблоны
 	mailTemplates, err := email.Init(*smtp, *tmpltsPath)
@@ -95,28 +371,36 @@ func main() {
 	log.WithField("domains", mailTemplates.Domains()).Info("email templates initialized")
 	// запускаем обработчик для отправки почтовых сообщений с токенами
 	var sender = sender.New(adapter, mailTemplates)
-	var ctx, cancel = context.WithCancel(context.Background())
-	defer cancel()
-	go func() {
-		var timer = time.NewTimer(SMTPSleep)
-		defer timer.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-timer.C: // вызываем отправку токенов интрервалами
-				err := sender.Send(ctx)
-				if err != nil {
-					log.WithError(err).Error("error sending tokens")
-				}
-				timer.Reset(SMTPSleep) // повторить
-			}
-		}
-	}()
+	// письма отправляются по уведомлениям о новых токенах, а интервалами —
+	// только на случай пропущенных уведомлений
+	go sender.Run(ctx, SMTPSleep)
 
 	// завершение работы по сигналу прерывания
 	var sig = tools.WaitSignal() // ожидание сигнала о прерывании
 	log.WithField("signal", sig.String()).Infof("interrupt received")
+	cancel()                  // останавливаем фоновые задачи
 	grpcServer.GracefulStop() // останавливаем gRPC сервер
+	if httpServer != nil {    // останавливаем сервер OpenID Connect
+		_ = httpServer.Shutdown(context.Background())
+	}
 	log.Info("service finished its work")
 }
+
+// cleanup периодически, с интервалом interval, вызывает функцию удаления
+// устаревших данных, пока не будет отменен ctx. Ошибки удаления выводятся в
+// лог.
+func cleanup(ctx context.Context, name string, interval time.Duration,
+	fn func(context.Context) (int64, error)) {
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.WithError(err).WithField("data", name).Error("cleanup error")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
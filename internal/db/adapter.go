@@ -4,19 +4,15 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"itube/users/pkg/passhash"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
-	"golang.org/x/crypto/bcrypt"
 )
 
-// bcryptCost задает "стоимость" хеширования пароля пользователя.
-// Т.к. это внутренний сервис то, в принципе, стоимость можно и снизить, ведь
-// нет необходимости исскуственно замедлять этот процесс.
-const bcryptCost = bcrypt.MinCost
-
 // Adapter отвечает за работу с базой данных.
 //
 // Проверки пустых значений и правильности форматов входящих данных при вызове
@@ -28,12 +24,39 @@ const bcryptCost = bcrypt.MinCost
 // строковое представление слишком многословно.
 type Adapter struct {
 	*pgxpool.Pool // пулл подключений к postgres
+	// время жизни токенов в зависимости от их типа; если для типа токена
+	// время жизни не задано, то срок его действия не ограничен
+	TokenTTL map[int32]time.Duration
+	// время жизни токена обновления сессии; если не задано, то используется
+	// DefaultSessionTTL
+	SessionTTL time.Duration
+	// ограничения на количество неудачных попыток авторизации; нулевое
+	// значение отключает ограничения
+	Throttle Throttle
+	// параметры повторных попыток отправки писем; если не заданы, то
+	// используется DefaultRetry
+	Retry Retry
+	// алгоритм хеширования паролей; если не задан, то используется
+	// passhash.Default
+	Hasher passhash.Hasher
 }
 
-// Register регистрирует нового пользователя с логином и паролем. Если
-// пользователь с таким email уже зарегистрирован, но у него не задан пароль,
-// то ему автоматически устанавливается новый пароль. Если данные не изменяются,
-// то ошибка не генерится.
+// hasher возвращает алгоритм хеширования паролей.
+func (db *Adapter) hasher() passhash.Hasher {
+	if db.Hasher == nil {
+		return passhash.Default
+	}
+	return db.Hasher
+}
+
+// Register регистрирует нового пользователя с логином и паролем. Пароль
+// добавляется в историю паролей пользователя.
+//
+// Если пользователь с таким email уже зарегистрирован, то возвращается ошибка
+// ErrAlreadyRegisterd. Если же у него не задан пароль, то возвращается ошибка
+// ErrNoPassword: учетная запись могла быть создана через провайдера, не
+// подтвердившего адрес, поэтому пароль задается только через ResetPassword
+// после подтверждения почтового адреса.
 func (db *Adapter) Register(ctx context.Context,
 	email, password string) (*UserInfo, error) {
 	// т.к. email является ключевым идентификационным полем, то на всякий
@@ -42,16 +65,41 @@ func (db *Adapter) Register(ctx context.Context,
 		return nil, ErrEmptyEmail
 	}
 	// шифруем пароль пользователя перед сохранением
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	hashed, err := db.hasher().Hash(password)
 	if err != nil {
 		return nil, err
 	}
-	// регистрируем пользователя с паролем и разбираем данные о нем
-	user, err := scanUser(db.QueryRow(ctx, sqlInsertUser, email, hashed))
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	// регистрируем пользователя с паролем и разбираем данные о нем
+	user, err := scanUser(tx.QueryRow(ctx, sqlInsertUser, email, hashed))
+	if errors.Is(err, ErrNotFound) {
+		// пользователь уже зарегистрирован: проверяем, задан ли у него пароль
+		var hasPassword bool
+		_, err = scanUser(tx.QueryRow(ctx, sqlSelectUserHasPassword, email),
+			&hasPassword)
+		switch {
+		case hasPassword || errors.Is(err, ErrNotFound):
 			return nil, ErrAlreadyRegisterd
+		case err != nil:
+			return nil, err
 		}
+		return nil, ErrNoPassword
+	}
+	if err != nil {
+		return nil, err
+	}
+	// сохраняем пароль в истории паролей
+	_, err = tx.Exec(ctx, sqlInsertPasswordHistory, user.UID, hashed)
+	if err != nil {
+		return nil, err
+	}
+	// принимаем транзакцию
+	if err = tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 	return user, nil
@@ -62,6 +110,14 @@ func (db *Adapter) Register(ctx context.Context,
 // Если пользователь зарегистрирован через внешнего провайдера авторизации и
 // у него не задан пароль, то авторизация через этот метод не пройдет и будет
 // возвращена ошибка ErrNotFound.
+//
+// Если пользователь не найден или заблокирован, то пароль все равно
+// хешируется, чтобы по времени ответа нельзя было определить, зарегистрирован
+// ли пользователь.
+//
+// Если сохраненный хеш пароля создан другим алгоритмом или с более слабыми
+// параметрами, чем задано в Hasher, то после успешной проверки пароль
+// автоматически хешируется заново и сохраняется.
 func (db *Adapter) Authorize(ctx context.Context,
 	email, password string) (*UserInfo, error) {
 	// т.к. email является ключевым идентификационным полем, то на всякий
@@ -70,35 +126,71 @@ func (db *Adapter) Authorize(ctx context.Context,
 		return nil, ErrEmptyEmail
 	}
 	// получаем и разбираем данные о пользователе, а так же пароль
-	var hashed []byte
+	var (
+		hasher = db.hasher()
+		hashed string
+	)
 	user, err := scanUser(db.QueryRow(ctx, sqlSelectPassword, email), &hashed)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrBlocked) {
+			_, _ = hasher.Hash(password)
+		}
 		return nil, err
 	}
 	// проверяем, что пароль совпадает
-	err = bcrypt.CompareHashAndPassword(hashed, []byte(password))
+	err = passhash.Compare(hashed, password)
 	if err != nil {
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		if errors.Is(err, passhash.ErrMismatch) {
 			err = ErrInvalidPassword
 		}
 		return nil, err
 	}
+	// пересчитываем хеш пароля, если он устарел (ошибку игнорируем)
+	if hasher.NeedsRehash(hashed) {
+		if rehashed, err := hasher.Hash(password); err == nil {
+			_, _ = db.Exec(ctx, sqlRehashPassword, rehashed, user.UID, hashed)
+		}
+	}
 	// обновляем дату последней успешной авторизации (ошибку игнорируем)
 	_ = oneRow(db.Exec(ctx, sqlLogged, user.UID))
 	return user, nil
 }
 
 // SetPassword изменяет или задает пароль пользователя, если он до этого был
-// не задан.
+// не задан. Новый пароль добавляется в историю паролей пользователя, а все
+// сессии пользователя завершаются в той же транзакции.
+//
+// Если history больше 0, то пароль не должен совпадать ни с одним из
+// последних history паролей пользователя, иначе возвращается ошибка
+// *passpolicy.Error.
 func (db *Adapter) SetPassword(ctx context.Context,
-	uid, password string) error {
+	uid, password string, history int) error {
 	// шифруем пароль пользователя перед сохранением
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	hashed, err := db.hasher().Hash(password)
+	if err != nil {
+		return err
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	// проверяем, что пароль не использовался ранее
+	err = checkHistory(ctx, tx, uid, password, history)
 	if err != nil {
 		return err
 	}
 	// сохраняем новый пароль пользователя
-	return oneRow(db.Exec(ctx, sqlUpdatePassword, hashed, uid))
+	if err = savePassword(ctx, tx, uid, hashed); err != nil {
+		return err
+	}
+	// завершаем все сессии пользователя
+	if _, err = tx.Exec(ctx, sqlDeleteSessions, uid); err != nil {
+		return err
+	}
+	// принимаем транзакцию
+	return tx.Commit(ctx)
 }
 
 // Update обновляет информацию о пользователе. При изменении email может
@@ -137,10 +229,29 @@ func (db *Adapter) GetUser(ctx context.Context,
 }
 
 // BlockUser блокирует/разблокирует пользователя. Заблокированный пользователь
-// остается зарегистрированным, но не может авторизоваться.
+// остается зарегистрированным, но не может авторизоваться: при блокировке все
+// его сессии завершаются в той же транзакции.
 func (db *Adapter) BlockUser(ctx context.Context,
 	uid string, blocked bool) error {
-	return oneRow(db.Exec(ctx, sqlBlockUser, blocked, uid))
+	if !blocked {
+		return oneRow(db.Exec(ctx, sqlBlockUser, blocked, uid))
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	// блокируем пользователя
+	if err = oneRow(tx.Exec(ctx, sqlBlockUser, blocked, uid)); err != nil {
+		return err
+	}
+	// завершаем все сессии пользователя
+	if _, err = tx.Exec(ctx, sqlDeleteSessions, uid); err != nil {
+		return err
+	}
+	// принимаем транзакцию
+	return tx.Commit(ctx)
 }
 
 // Logged обновляет дату последней авторизации пользователя.
@@ -150,12 +261,35 @@ func (db *Adapter) Logged(ctx context.Context,
 }
 
 // OpenIDAuthorize авторизует пользователя по информации о внешней авторизации.
+//
+// claims задает последние данные профиля пользователя, полученные от
+// провайдера, которые сохраняются для этой привязки. properties задает
+// данные профиля в формате JSON, которыми дополняются и заменяются
+// расширенные свойства пользователя. Пустые значения не сохраняются.
 func (db *Adapter) OpenIDAuthorize(ctx context.Context,
-	provider, subject string) (*UserInfo, error) {
+	provider, subject, claims, properties string) (*UserInfo, error) {
 	user, err := scanUser(db.QueryRow(ctx, sqlSelectUserOpenID, provider, subject))
 	if err != nil {
 		return nil, err
 	}
+	// сохраняем последние данные профиля от провайдера
+	if claims != "" {
+		_, err = db.Exec(ctx, sqlUpdateOpenIDClaims, claims, provider, subject)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// обновляем расширенные свойства пользователя
+	if properties != "" {
+		updated, err := scanUser(db.QueryRow(ctx, sqlMergeProperties,
+			properties, user.UID, properties))
+		switch {
+		case err == nil:
+			user = updated
+		case !errors.Is(err, ErrNotFound): // свойства не изменились
+			return nil, err
+		}
+	}
 	// обновляем дату последней успешной авторизации (ошибку игнорируем)
 	_ = oneRow(db.Exec(ctx, sqlLogged, user.UID))
 	return user, nil
@@ -164,6 +298,11 @@ func (db *Adapter) OpenIDAuthorize(ctx context.Context,
 // OpenIDRegister регистрирует пользователя по информации о внешней авторизации.
 // Множественные регистрации одного и того же пользователя не приведут к ошибке,
 // а только изменят расширенные свойства пользователя.
+//
+// Если email не подтвержден провайдером, а пользователь с таким email уже
+// зарегистрирован, то привязка не выполняется и возвращается ошибка
+// ErrLinkRequired: владелец учетной записи должен подтвердить ее сам через
+// LinkRequest и LinkConfirm.
 func (db *Adapter) OpenIDRegister(ctx context.Context,
 	provider, subject, email string, verified bool, properties string) (*UserInfo, error) {
 	// т.к. email является ключевым идентификационным полем, то на всякий
@@ -184,14 +323,22 @@ func (db *Adapter) OpenIDRegister(ctx context.Context,
 			return nil, err
 		}
 	}
-	// регистрируем нового пользователя
-	user, err := scanUser(tx.QueryRow(ctx, sqlInsertUserOpenID, email,
-		null(properties)))
+	// регистрируем нового пользователя; к уже зарегистрированному
+	// пользователю с тем же email привязываем только при подтвержденном адресе
+	var query = sqlInsertUserOpenID
+	if !verified {
+		query = sqlInsertUserOpenIDNew
+	}
+	user, err := scanUser(tx.QueryRow(ctx, query, email, null(properties)))
+	if !verified && errors.Is(err, ErrNotFound) {
+		return nil, ErrLinkRequired
+	}
 	if err != nil && !errors.Is(err, ErrNotFound) {
 		return nil, err
 	}
 	// добавляем информацию об openid регистрации
-	_, err = tx.Exec(ctx, sqlInsertOpenID, provider, subject, user.UID)
+	_, err = tx.Exec(ctx, sqlInsertOpenID, provider, subject, user.UID,
+		null(properties))
 	if err != nil {
 		return nil, err
 	}
@@ -266,33 +413,24 @@ func (db *Adapter) TokenGenerate(ctx context.Context,
 }
 
 // TokenVerify проверяет токен. Если токен найден, то почтовый адрес, на который
-// он был  отправлен, автоматически помечается подтвержденным.
+// он был  отправлен, автоматически помечается подтвержденным. Если адрес
+// подтверждается впервые, а у пользователя не задан пароль, то внешние
+// провайдеры авторизации от него отвязываются (см. verifyEmail).
 // Cам токен автоматически удаляется и повторное его использование невозможно.
+//
+// Токен ищется только среди токенов указанного типа, поэтому токен другого
+// типа не будет найден и не удаляется. Если время жизни токена, заданное в
+// TokenTTL для данного типа, истекло, то возвращается ошибка ErrTokenExpired.
+//
 // Может возвращаеть ошибку ErrNotFound, если адрес пользователя с тех пор
 // изменился. Так же может быть ошибка ErrBlocked, если пользователь
 // заблокирован.
 func (db *Adapter) TokenVerify(ctx context.Context,
-	token string) (*UserInfo, error) {
-	// декодируем токен в бинарный формат
-	tokenUUID, err := tokenCoder.DecodeString(token)
-	if err != nil {
-		return nil, ErrBadToken
-	}
-	// удаляем токен из базы и получаем почтовый адрес и тип, с которыми он
-	// был зарегистрирован
-	var (
-		email     string
-		tokenType int32     // на текущий момент тип токена не проверяется
-		created   time.Time // время его создания
-	)
+	token string, tokenType int32) (*UserInfo, error) {
 	// удаляем токен в любом случае, раз уж он проверяется, чтобы нельзя было
 	// его повторно использовать, поэтому делаем это вне транзакции
-	err = db.QueryRow(ctx, sqlDeleteToken, tokenUUID).Scan(
-		&email, &tokenType, &created)
+	email, err := db.deleteToken(ctx, db, token, tokenType)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrBadToken
-		}
 		return nil, err
 	}
 	// стартуем транзакцию, чтобы добавление проверенного адреса было
@@ -303,15 +441,86 @@ func (db *Adapter) TokenVerify(ctx context.Context,
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
-	// добавляем email в список подтвержденных, какой бы тип токена не
-	// использовался, т.к. все равно передано по почте, что однозначно ее
-	// подтверждает
-	_, err = tx.Exec(ctx, sqlInsertVerifiedEmail, email)
+	// подтверждаем email, какой бы тип токена не использовался, т.к. все
+	// равно передано по почте, что однозначно ее подтверждает, и
+	// запрашиваем информацию о пользователе
+	user, err := verifyEmail(ctx, tx, email)
 	if err != nil {
 		return nil, err
 	}
-	// запрашиваем и разбираем информацию о пользователе
-	user, err := scanUser(tx.QueryRow(ctx, sqlSelectUser, email))
+	// принимаем транзакцию
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// обновляем дату последней успешной авторизации (ошибку игнорируем)
+	_ = oneRow(db.Exec(ctx, sqlLogged, user.UID))
+	return user, nil
+}
+
+// ResetPassword проверяет токен для сброса пароля и устанавливает новый пароль
+// пользователю, для почтового адреса которого токен был сгенерирован.
+// Удаление токена, подтверждение почтового адреса и изменение пароля
+// происходят в одной транзакции: если пароль не удалось изменить, то токен
+// остается действительным. Так же задается пароль пользователю,
+// зарегистрированному без пароля (см. Register): если его адрес до этого не
+// был подтвержден, то внешние провайдеры авторизации от него отвязываются.
+//
+// Токен ищется только среди токенов указанного типа. Возвращает ошибку
+// ErrBadToken, если токен не найден, и ErrTokenExpired, если время его жизни
+// истекло. Так же может быть ошибка ErrNotFound, если адрес пользователя с
+// тех пор изменился, или ErrBlocked, если пользователь заблокирован.
+//
+// Если задана функция check, то она вызывается с почтовым адресом
+// пользователя перед изменением пароля. Возвращенная ею ошибка отменяет
+// транзакцию и возвращается как есть. Так же, как и в SetPassword, новый
+// пароль проверяется по последним history паролям пользователя и
+// добавляется в историю, а все сессии пользователя завершаются.
+func (db *Adapter) ResetPassword(ctx context.Context,
+	token string, tokenType int32, password string, history int,
+	check func(email string) error) (*UserInfo, error) {
+	// шифруем пароль пользователя перед сохранением
+	hashed, err := db.hasher().Hash(password)
+	if err != nil {
+		return nil, err
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	// удаляем токен и получаем почтовый адрес, для которого он был создан
+	email, err := db.deleteToken(ctx, tx, token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+	// токен был получен по почте, что подтверждает почтовый адрес
+	user, err := verifyEmail(ctx, tx, email)
+	if err != nil {
+		return nil, err
+	}
+	// проверяем новый пароль
+	if check != nil {
+		if err = check(user.Email); err != nil {
+			return nil, err
+		}
+	}
+	err = checkHistory(ctx, tx, user.UID, password, history)
+	if err != nil {
+		return nil, err
+	}
+	// сохраняем новый пароль пользователя
+	if err = savePassword(ctx, tx, user.UID, hashed); err != nil {
+		return nil, err
+	}
+	// завершаем все сессии пользователя
+	if _, err = tx.Exec(ctx, sqlDeleteSessions, user.UID); err != nil {
+		return nil, err
+	}
+	// сбрасываем счетчик неудачных попыток авторизации
+	_, err = tx.Exec(ctx, sqlDeleteLoginAttempts, scopeEmail,
+		strings.ToLower(email))
 	if err != nil {
 		return nil, err
 	}
@@ -325,6 +534,65 @@ func (db *Adapter) TokenVerify(ctx context.Context,
 	return user, nil
 }
 
+// deleteToken удаляет токен указанного типа и возвращает почтовый адрес, для
+// которого он был сгенерирован. Возвращает ошибку ErrBadToken, если токен не
+// найден, и ErrTokenExpired, если время его жизни, заданное в TokenTTL,
+// истекло.
+func (db *Adapter) deleteToken(ctx context.Context, q querier,
+	token string, tokenType int32) (string, error) {
+	// декодируем токен в бинарный формат
+	tokenUUID, err := tokenCoder.DecodeString(token)
+	if err != nil {
+		return "", ErrBadToken
+	}
+	// удаляем токен из базы и получаем почтовый адрес, на который он был
+	// зарегистрирован, и время его создания
+	var (
+		email   string
+		created time.Time
+	)
+	err = q.QueryRow(ctx, sqlDeleteToken, tokenUUID, tokenType).Scan(
+		&email, &created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrBadToken
+		}
+		return "", err
+	}
+	// проверяем, что время жизни токена не истекло
+	if ttl := db.TokenTTL[tokenType]; ttl > 0 && time.Since(created) > ttl {
+		return "", ErrTokenExpired
+	}
+	return email, nil
+}
+
+// verifyEmail добавляет почтовый адрес, подтвержденный токеном из письма, в
+// список подтвержденных и возвращает информацию о пользователе с этим
+// адресом.
+//
+// Учетная запись без пароля с еще не подтвержденным адресом могла быть
+// создана кем угодно через провайдера, не подтвердившего адрес, поэтому все
+// внешние провайдеры при этом от нее отвязываются: доступ к ней остается
+// только у владельца почтового адреса.
+func verifyEmail(ctx context.Context, q querier, email string) (*UserInfo, error) {
+	var hasPassword bool
+	user, err := scanUser(q.QueryRow(ctx, sqlSelectUserHasPassword, email),
+		&hasPassword)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPassword && !user.Verified {
+		if _, err = q.Exec(ctx, sqlDeleteUserOpenID, user.UID); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = q.Exec(ctx, sqlInsertVerifiedEmail, email); err != nil {
+		return nil, err
+	}
+	user.Verified = true
+	return user, nil
+}
+
 // TokenSended помечает токен как отправленный.
 func (db *Adapter) TokenSended(ctx context.Context,
 	token string) error {
@@ -345,13 +613,44 @@ func (db *Adapter) TokenSended(ctx context.Context,
 
 // TokenInfo описывает информацию о токене.
 type TokenInfo struct {
-	Token  string // представлени токена в виде base64 строки
-	Domain string // название домена
-	Email  string // email адрес пользователя
-	Type   int32  // тип токена
+	Token    string // представлени токена в виде base64 строки
+	Domain   string // название домена
+	Email    string // email адрес пользователя
+	Type     int32  // тип токена
+	Attempts int    // количество предыдущих неудачных попыток отправки
+}
+
+// TokensChannel задает канал уведомлений PostgreSQL о новых или
+// перевыпущенных токенах для отправки.
+const TokensChannel = "tokens"
+
+// ListenTokens ожидает уведомлений о новых или перевыпущенных токенах для
+// отправки и вызывает notify для каждого из них. Для ожидания из пула
+// берется отдельное соединение, которое удерживается до завершения работы.
+//
+// Возвращает ошибку при потере соединения с базой данных или отмене ctx.
+func (db *Adapter) ListenTokens(ctx context.Context, notify func()) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// отписываемся, если соединение еще живо и вернется в пул
+		_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+		conn.Release()
+	}()
+	if _, err = conn.Exec(ctx, sqlListenTokens); err != nil {
+		return err
+	}
+	for {
+		if _, err = conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+		notify()
+	}
 }
 
-// TokensToSend возвращает список токенов для отсылки.
+// TokensToSend возвращает список токенов, письма с которыми пора отослать.
 func (db *Adapter) TokensToSend(ctx context.Context) ([]TokenInfo, error) {
 	rows, err := db.Query(ctx, sqlSelectTokens)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -367,12 +666,13 @@ func (db *Adapter) TokensToSend(ctx context.Context) ([]TokenInfo, error) {
 			token TokenInfo
 			id    = make([]byte, 0, 16)
 		)
-		err = rows.Scan(&id, &token.Domain, &token.Email, &token.Type)
+		err = rows.Scan(&id, &token.Domain, &token.Email, &token.Type,
+			&token.Attempts)
 		if err != nil {
 			return nil, err
 		}
 		token.Token = tokenCoder.EncodeToString(id)
 		tokens = append(tokens, token)
 	}
-	return tokens, nil
+	return tokens, rows.Err()
 }
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// AuthCodeTTL задает время жизни кода авторизации OpenID Connect.
+var AuthCodeTTL = time.Minute * 5
+
+// Client описывает приложение, зарегистрированное для авторизации
+// пользователей через сервис по протоколу OpenID Connect.
+type Client struct {
+	ID           string   // идентификатор клиента (client_id)
+	Name         string   // отображаемое название приложения
+	Domain       string   // домен для сессий пользователей
+	RedirectURIs []string // зарегистрированные адреса для возврата
+	Confidential bool     // флаг, что для клиента задан секретный ключ
+}
+
+// HasRedirectURI возвращает true, если адрес для возврата зарегистрирован
+// для клиента. Адреса сравниваются точно.
+func (c *Client) HasRedirectURI(uri string) bool {
+	for _, item := range c.RedirectURIs {
+		if item == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthCode описывает информацию, сохраненную вместе с кодом авторизации.
+type AuthCode struct {
+	ClientID    string    // идентификатор клиента
+	UID         string    // идентификатор авторизованного пользователя
+	RedirectURI string    // адрес для возврата из запроса авторизации
+	Scope       string    // запрошенные области доступа
+	Nonce       string    // nonce из запроса авторизации
+	Challenge   string    // code_challenge для PKCE (S256)
+	Created     time.Time // время создания
+}
+
+// Client возвращает информацию о зарегистрированном клиенте.
+//
+// Возвращает ErrUnknownClient, если клиент не зарегистрирован.
+func (db *Adapter) Client(ctx context.Context, id string) (*Client, error) {
+	return scanClient(db.QueryRow(ctx, sqlSelectClient, id))
+}
+
+// ClientAuthenticate проверяет секретный ключ клиента и возвращает
+// информацию о нем. Если secret не задан, то клиент должен быть публичным
+// (без секретного ключа).
+//
+// Возвращает ErrUnknownClient, если клиент не зарегистрирован или ключ не
+// совпадает.
+func (db *Adapter) ClientAuthenticate(ctx context.Context,
+	id, secret string) (*Client, error) {
+	if secret == "" {
+		client, err := db.Client(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if client.Confidential {
+			return nil, ErrUnknownClient
+		}
+		return client, nil
+	}
+	return scanClient(db.QueryRow(ctx, sqlSelectClientSecret, id, secret))
+}
+
+// AuthCodeCreate сохраняет информацию об авторизации и возвращает код для
+// получения токенов. В базе данных сохраняется только хеш от кода.
+func (db *Adapter) AuthCodeCreate(ctx context.Context,
+	code AuthCode) (string, error) {
+	token, hash, err := refreshToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = db.Exec(ctx, sqlInsertAuthCode, hash, code.ClientID, code.UID,
+		code.RedirectURI, code.Scope, code.Nonce, code.Challenge)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// AuthCodeTake возвращает информацию об авторизации по коду, выданному
+// клиенту, и удаляет код, поэтому он может быть использован только один раз.
+//
+// Возвращает ErrBadToken, если код не найден, выдан другому клиенту или
+// время его жизни истекло.
+func (db *Adapter) AuthCodeTake(ctx context.Context,
+	clientID, token string) (*AuthCode, error) {
+	hash, err := refreshTokenHash(token)
+	if err != nil {
+		return nil, err
+	}
+	var code = &AuthCode{ClientID: clientID}
+	err = db.QueryRow(ctx, sqlDeleteAuthCode, hash, clientID).
+		Scan(&code.UID, &code.RedirectURI, &code.Scope, &code.Nonce,
+			&code.Challenge, &code.Created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBadToken
+		}
+		return nil, err
+	}
+	if time.Since(code.Created) > AuthCodeTTL {
+		return nil, ErrBadToken
+	}
+	return code, nil
+}
+
+// AuthCodeCleanup удаляет коды авторизации, время жизни которых истекло, и
+// возвращает их количество.
+func (db *Adapter) AuthCodeCleanup(ctx context.Context) (int64, error) {
+	tag, err := db.Exec(ctx, sqlDeleteAuthCodes, time.Now().Add(-AuthCodeTTL))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// scanClient разбирает полученные данные о клиенте.
+func scanClient(row pgx.Row) (*Client, error) {
+	var client = new(Client)
+	err := row.Scan(&client.ID, &client.Name, &client.Domain,
+		&client.RedirectURIs, &client.Confidential)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUnknownClient
+		}
+		return nil, err
+	}
+	return client, nil
+}
@@ -9,10 +9,39 @@ var (
 	ErrBlocked = errors.New("blocked")
 	// ErrAlreadyRegisterd возвращается, если пользователь уже зарегистрирован.
 	ErrAlreadyRegisterd = errors.New("already registered")
+	// ErrNoPassword возвращается при регистрации, если пользователь с таким
+	// email уже зарегистрирован через внешнего провайдера авторизации и не
+	// имеет пароля. Пароль ему можно задать только после подтверждения
+	// почтового адреса через сброс пароля.
+	ErrNoPassword = errors.New("registered without password, email verification required")
 	// ErrInvalidPassword возвращается в случае неверного паролья пользователя.
 	ErrInvalidPassword = errors.New("invalid password")
 	// ErrBadToken возвращается, если токен на зарегистрирован.
 	ErrBadToken = errors.New("bad token")
+	// ErrTokenExpired возвращается, если время жизни токена истекло.
+	ErrTokenExpired = errors.New("token expired")
+	// ErrInvalidCode возвращается в случае неверного кода двухфакторной
+	// авторизации.
+	ErrInvalidCode = errors.New("invalid second factor code")
+	// ErrTOTPEnabled возвращается, если двухфакторная авторизация уже
+	// включена.
+	ErrTOTPEnabled = errors.New("two-factor authentication already enabled")
+	// ErrTOTPNotEnabled возвращается, если двухфакторная авторизация не
+	// включена.
+	ErrTOTPNotEnabled = errors.New("two-factor authentication not enabled")
 	// ErrEmptyEmail возвращается, если email адрес пустой.
 	ErrEmptyEmail = errors.New("empty email")
+	// ErrIdentityLinked возвращается, если пользователь внешнего провайдера
+	// авторизации уже привязан к другому пользователю.
+	ErrIdentityLinked = errors.New("identity already linked to another user")
+	// ErrLastIdentity возвращается при попытке отвязать последний способ
+	// авторизации пользователя, у которого не задан пароль.
+	ErrLastIdentity = errors.New("cannot unlink the only way to sign in")
+	// ErrLinkRequired возвращается, если пользователь с email, не
+	// подтвержденным провайдером, уже зарегистрирован и привязку должен
+	// подтвердить владелец учетной записи.
+	ErrLinkRequired = errors.New("account link confirmation required")
+	// ErrUnknownClient возвращается, если клиент OpenID Connect не
+	// зарегистрирован или его секретный ключ не совпадает.
+	ErrUnknownClient = errors.New("unknown client")
 )
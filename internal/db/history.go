@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"itube/users/pkg/passhash"
+	"itube/users/pkg/passpolicy"
+)
+
+// checkHistory проверяет, что новый пароль не совпадает ни с одним из
+// последних history паролей пользователя. Если совпадение найдено, то
+// возвращается ошибка *passpolicy.Error. Если history не больше 0, то
+// проверка не выполняется.
+func checkHistory(ctx context.Context, q querier,
+	uid, password string, history int) error {
+	if history <= 0 {
+		return nil
+	}
+	rows, err := q.Query(ctx, sqlSelectPasswordHistory, uid, history)
+	if err != nil {
+		return err
+	}
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			rows.Close()
+			return err
+		}
+		hashes = append(hashes, hash)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// сравниваем пароль со всеми сохраненными хешами
+	for _, hash := range hashes {
+		if passhash.Compare(hash, password) == nil {
+			return &passpolicy.Error{Violations: []passpolicy.Violation{{
+				Rule: passpolicy.RuleHistory,
+				Description: fmt.Sprintf(
+					"password must not match any of the last %d passwords",
+					history),
+			}}}
+		}
+	}
+	return nil
+}
+
+// savePassword сохраняет новый хеш пароля пользователя и добавляет его в
+// историю паролей.
+func savePassword(ctx context.Context, q querier, uid, hashed string) error {
+	err := oneRow(q.Exec(ctx, sqlUpdatePassword, hashed, uid))
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(ctx, sqlInsertPasswordHistory, uid, hashed)
+	return err
+}
@@ -0,0 +1,334 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Identity описывает привязку пользователя к внешнему провайдеру
+// авторизации.
+type Identity struct {
+	Provider string    // идентификатор провайдера авторизации
+	Subject  string    // идентификатор пользователя у провайдера
+	Created  time.Time // дата и время привязки
+}
+
+// Identities возвращает список внешних провайдеров авторизации, привязанных
+// к пользователю, в порядке привязки.
+func (db *Adapter) Identities(ctx context.Context, uid string) ([]Identity, error) {
+	rows, err := db.Query(ctx, sqlSelectOpenIDs, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []Identity
+	for rows.Next() {
+		var identity Identity
+		err = rows.Scan(&identity.Provider, &identity.Subject, &identity.Created)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, identity)
+	}
+	return list, rows.Err()
+}
+
+// OpenIDLink привязывает к зарегистрированному пользователю внешнего
+// провайдера авторизации.
+//
+// Возвращает ErrNotFound, если пользователь не зарегистрирован, ErrBlocked,
+// если заблокирован, и ErrIdentityLinked, если этот пользователь провайдера уже
+// привязан к другому пользователю.
+func (db *Adapter) OpenIDLink(ctx context.Context,
+	uid, provider, subject string) (*UserInfo, error) {
+	user, err := scanUser(db.QueryRow(ctx, sqlSelectUserByUID, uid))
+	if err != nil {
+		return nil, err
+	}
+	var linked string
+	err = db.QueryRow(ctx, sqlLinkOpenID, provider, subject, uid).Scan(&linked)
+	if err != nil {
+		return nil, err
+	}
+	if linked != uid {
+		return nil, ErrIdentityLinked
+	}
+	return user, nil
+}
+
+// OpenIDUnlink отвязывает от пользователя внешнего провайдера авторизации.
+//
+// Возвращает ErrNotFound, если провайдер не привязан к пользователю, и
+// ErrLastIdentity, если у пользователя не задан пароль и нет других
+// привязанных провайдеров, т.к. после этого он не сможет авторизоваться.
+func (db *Adapter) OpenIDUnlink(ctx context.Context,
+	uid, provider string) error {
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	// блокируем пользователя, чтобы параллельно не отвязать все способы
+	// авторизации, и проверяем, останется ли способ авторизации
+	var (
+		hasPassword bool
+		others      int
+	)
+	err = tx.QueryRow(ctx, sqlSelectUserLogins, provider, uid).
+		Scan(&hasPassword, &others)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if !hasPassword && others == 0 {
+		return ErrLastIdentity
+	}
+	// удаляем привязку
+	tag, err := tx.Exec(ctx, sqlDeleteOpenID, uid, provider)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	// принимаем транзакцию
+	return tx.Commit(ctx)
+}
+
+// LinkTTL задает время жизни запроса на привязку провайдера к
+// существующему пользователю.
+var LinkTTL = time.Minute * 30
+
+// LinkRequest создает запрос на привязку внешнего провайдера к
+// зарегистрированному пользователю с указанным email и возвращает его
+// токен. Используется, если email не подтвержден провайдером и привязку
+// должен подтвердить владелец учетной записи.
+//
+// Вместе с запросом для домена генерируется токен указанного типа для
+// отправки владельцу адреса: по нему привязка подтверждается через
+// LinkConfirmToken без токена запроса.
+//
+// Возвращает ErrNotFound, если пользователь не зарегистрирован.
+func (db *Adapter) LinkRequest(ctx context.Context,
+	domain, provider, subject, email string, tokenType int32) (string, error) {
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+	// генерируем токен для письма владельцу адреса
+	var token []byte
+	err = tx.QueryRow(ctx, sqlInsertToken, domain, email, tokenType).Scan(&token)
+	if err != nil {
+		return "", err
+	}
+	// создаем запрос на привязку
+	var id []byte
+	err = tx.QueryRow(ctx, sqlInsertPendingLink, provider, subject, token,
+		email).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	// принимаем транзакцию
+	if err = tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return tokenCoder.EncodeToString(id), nil
+}
+
+// LinkPending возвращает пользователя, к которому относится запрос на
+// привязку провайдера, и идентификатор провайдера. Сам запрос при этом не
+// удаляется.
+//
+// Возвращает ErrBadToken, если запрос не найден, и ErrTokenExpired, если
+// время его жизни истекло. Так же может быть ошибка ErrBlocked, если
+// пользователь заблокирован.
+func (db *Adapter) LinkPending(ctx context.Context,
+	link string) (*UserInfo, string, error) {
+	// декодируем токен в бинарный формат
+	id, err := tokenCoder.DecodeString(link)
+	if err != nil {
+		return nil, "", ErrBadToken
+	}
+	var (
+		provider string
+		created  time.Time
+	)
+	user, err := scanUser(db.QueryRow(ctx, sqlSelectPendingLink, id),
+		&provider, &created)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", ErrBadToken
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if time.Since(created) > LinkTTL {
+		return nil, "", ErrTokenExpired
+	}
+	return user, provider, nil
+}
+
+// LinkConfirm привязывает провайдера из запроса к пользователю, после того
+// как владелец учетной записи подтвердил привязку. Запрос удаляется и
+// повторное его использование невозможно.
+//
+// Возвращает ErrBadToken, если запрос для этого пользователя не найден, и
+// ErrTokenExpired, если время его жизни истекло.
+func (db *Adapter) LinkConfirm(ctx context.Context,
+	link, uid string) (*UserInfo, error) {
+	// декодируем токен в бинарный формат
+	id, err := tokenCoder.DecodeString(link)
+	if err != nil {
+		return nil, ErrBadToken
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	// удаляем запрос и привязываем провайдера
+	user, _, err := db.linkComplete(ctx, tx,
+		tx.QueryRow(ctx, sqlDeletePendingLink, id, uid), "")
+	return user, err
+}
+
+// LinkConfirmToken привязывает провайдера к пользователю по токену,
+// созданному в LinkRequest и отправленному по почте, которым владелец учетной записи подтвердил привязку, и возвращает
+// пользователя и идентификатор провайдера. Почтовый адрес при этом
+// помечается подтвержденным. Токен и запрос удаляются и повторное их
+// использование невозможно.
+//
+// Возвращает ErrBadToken, если токен или запрос не найдены или адрес
+// пользователя с тех пор изменился, и ErrTokenExpired, если время жизни
+// токена или запроса истекло. Так же может быть ошибка ErrBlocked, если
+// пользователь заблокирован.
+func (db *Adapter) LinkConfirmToken(ctx context.Context,
+	token string, tokenType int32) (*UserInfo, string, error) {
+	// удаляем токен в любом случае, чтобы нельзя было его повторно
+	// использовать, поэтому делаем это вне транзакции
+	email, err := db.deleteToken(ctx, db, token, tokenType)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := tokenCoder.DecodeString(token)
+	if err != nil {
+		return nil, "", ErrBadToken
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback(ctx)
+	// адрес подтвержден, раз токен получен по почте
+	_, err = tx.Exec(ctx, sqlInsertVerifiedEmail, email)
+	if err != nil {
+		return nil, "", err
+	}
+	// удаляем запрос и привязываем провайдера
+	return db.linkComplete(ctx, tx,
+		tx.QueryRow(ctx, sqlDeletePendingLinkByToken, id), email)
+}
+
+// linkComplete привязывает провайдера из удаленного запроса row к
+// пользователю и принимает транзакцию. Если задан email, то он должен
+// совпадать с адресом пользователя. Возвращает пользователя и
+// идентификатор провайдера.
+func (db *Adapter) linkComplete(ctx context.Context, tx pgx.Tx,
+	row pgx.Row, email string) (*UserInfo, string, error) {
+	var (
+		provider, subject, uid string
+		created                time.Time
+	)
+	err := row.Scan(&provider, &subject, &uid, &created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrBadToken
+		}
+		return nil, "", err
+	}
+	if time.Since(created) > LinkTTL {
+		if err = tx.Commit(ctx); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrTokenExpired
+	}
+	// привязываем провайдера к пользователю
+	_, err = tx.Exec(ctx, sqlInsertOpenID, provider, subject, uid, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	// запрашиваем и разбираем информацию о пользователе
+	user, err := scanUser(tx.QueryRow(ctx, sqlSelectUserByUID, uid))
+	if err != nil {
+		return nil, "", err
+	}
+	if email != "" && !strings.EqualFold(user.Email, email) {
+		return nil, "", ErrBadToken
+	}
+	// принимаем транзакцию
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	// обновляем дату последней успешной авторизации (ошибку игнорируем)
+	_ = oneRow(db.Exec(ctx, sqlLogged, uid))
+	return user, provider, nil
+}
+
+// LinkCleanup удаляет запросы на привязку, время жизни которых истекло, и
+// возвращает их количество.
+func (db *Adapter) LinkCleanup(ctx context.Context) (int64, error) {
+	tag, err := db.Exec(ctx, sqlDeletePendingLinks, time.Now().Add(-LinkTTL))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// OpenIDTokenSave сохраняет зашифрованные токены провайдера для привязки
+// пользователя. Если время окончания действия токена доступа не задано, то
+// токен считается бессрочным.
+func (db *Adapter) OpenIDTokenSave(ctx context.Context,
+	provider, subject string, token []byte, expiry time.Time) error {
+	var expires *time.Time
+	if !expiry.IsZero() {
+		expires = &expiry
+	}
+	_, err := db.Exec(ctx, sqlInsertOpenIDToken, provider, subject, token,
+		expires)
+	return err
+}
+
+// OpenIDToken возвращает идентификатор пользователя у провайдера и
+// последние сохраненные для него зашифрованные токены.
+//
+// Возвращает ErrNotFound, если токены не сохранены.
+func (db *Adapter) OpenIDToken(ctx context.Context,
+	uid, provider string) (string, []byte, error) {
+	var (
+		subject string
+		token   []byte
+	)
+	err := db.QueryRow(ctx, sqlSelectOpenIDToken, uid, provider).
+		Scan(&subject, &token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil, ErrNotFound
+		}
+		return "", nil, err
+	}
+	return subject, token, nil
+}
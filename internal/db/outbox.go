@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Retry задает параметры повторных попыток отправки писем с токенами.
+//
+// После неудачной попытки следующая откладывается на время Delay, которое
+// удваивается с каждой следующей неудачной попыткой, но не превышает
+// MaxDelay. После Attempts неудачных попыток подряд письмо помечается как
+// неотправленное и больше не отправляется, пока его не вернут в очередь.
+type Retry struct {
+	Attempts int           // максимальное количество попыток (0 - без ограничений)
+	Delay    time.Duration // начальное время до повторной попытки
+	MaxDelay time.Duration // максимальное время до повторной попытки
+}
+
+// DefaultRetry задает параметры повторных попыток отправки писем по
+// умолчанию.
+var DefaultRetry = Retry{
+	Attempts: 10,
+	Delay:    time.Minute,
+	MaxDelay: time.Hour * 6,
+}
+
+// delay возвращает время до следующей попытки после указанного количества
+// неудачных попыток подряд.
+func (r Retry) delay(attempts int) time.Duration {
+	var delay = r.Delay
+	for i := 1; i < attempts && (r.MaxDelay <= 0 || delay < r.MaxDelay); i++ {
+		delay *= 2
+	}
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return delay
+}
+
+// retry возвращает параметры повторных попыток отправки писем.
+func (db *Adapter) retry() Retry {
+	if db.Retry == (Retry{}) {
+		return DefaultRetry
+	}
+	return db.Retry
+}
+
+// TokenFailed сохраняет ошибку неудачной попытки отправки письма с токеном и
+// откладывает следующую попытку. Если ошибка постоянная (permanent) или
+// попытки закончились, то письмо помечается как неотправленное. Возвращает
+// true, если попытки отправки прекращены.
+func (db *Adapter) TokenFailed(ctx context.Context, token TokenInfo,
+	lastError string, permanent bool) (bool, error) {
+	// декодируем токен в бинарный формат
+	tokenUUID, err := tokenCoder.DecodeString(token.Token)
+	if err != nil {
+		return false, ErrBadToken
+	}
+	var (
+		retry    = db.retry()
+		attempts = token.Attempts + 1
+		failed   = permanent || (retry.Attempts > 0 && attempts >= retry.Attempts)
+	)
+	_, err = db.Exec(ctx, sqlUpdateTokenFailure, attempts, lastError,
+		time.Now().Add(retry.delay(attempts)), failed, tokenUUID)
+	if err != nil {
+		return false, err
+	}
+	return failed, nil
+}
+
+// TokenNextAttempt возвращает время ближайшей попытки отправки письма или
+// нулевое время, если писем для отправки нет.
+func (db *Adapter) TokenNextAttempt(ctx context.Context) (time.Time, error) {
+	var next *time.Time
+	err := db.QueryRow(ctx, sqlSelectNextAttempt).Scan(&next)
+	if err != nil || next == nil {
+		return time.Time{}, err
+	}
+	return *next, nil
+}
+
+// OutboxMessage описывает неотправленное письмо с токеном.
+type OutboxMessage struct {
+	Email       string    // email адрес пользователя
+	Type        int32     // тип токена
+	Attempts    int       // количество неудачных попыток отправки
+	NextAttempt time.Time // время следующей попытки
+	LastError   string    // ошибка последней попытки
+	Failed      bool      // попытки отправки прекращены
+	Created     time.Time // время создания токена
+}
+
+// Outbox возвращает список неотправленных писем домена. Если failed
+// установлен, то возвращаются только письма, попытки отправки которых
+// прекращены.
+//
+// Сами токены не возвращаются, т.к. являются секретными.
+func (db *Adapter) Outbox(ctx context.Context, domain string,
+	failed bool) ([]OutboxMessage, error) {
+	var sql = sqlSelectOutbox
+	if failed {
+		sql = sqlSelectOutboxFailed
+	}
+	rows, err := db.Query(ctx, sql, domain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var messages = make([]OutboxMessage, 0)
+	for rows.Next() {
+		var msg OutboxMessage
+		err = rows.Scan(&msg.Email, &msg.Type, &msg.Attempts,
+			&msg.NextAttempt, &msg.LastError, &msg.Failed, &msg.Created)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// Requeue возвращает в очередь на отправку письмо с токеном, попытки
+// отправки которого прекращены, и сбрасывает счетчик попыток. Если email не
+// указан, то в очередь возвращаются все такие письма домена. Возвращает
+// количество возвращенных в очередь писем.
+func (db *Adapter) Requeue(ctx context.Context, domain, email string,
+	tokenType int32) (int64, error) {
+	var (
+		sql  = sqlRequeueTokens
+		args = []interface{}{false, 0, domain}
+	)
+	if email != "" {
+		sql = sqlRequeueToken
+		args = append(args, email, tokenType)
+	}
+	result, err := db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
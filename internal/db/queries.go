@@ -26,14 +26,13 @@ var (
 		"RETURNING ", strings.Join(userFields, ", "), ", ", sbVerifiedField)
 
 	// SuffixExpr(sbReturnUser)
-	// регистрирует нового пользователя с логином и паролем
-	// если такой пользователь уже зарегистрирован, но не имеет пароля, то
-	// добавляет ему пароль
+	// регистрирует нового пользователя с логином и паролем, только если
+	// пользователь с таким email еще не зарегистрирован
 	sqlInsertUser = toSQL(sb.
 			Insert("users").
 			Columns("email", "password").
 			Values("", "").
-			Suffix("ON CONFLICT (email) DO UPDATE SET password = EXCLUDED.password, updated = DEFAULT, logged = DEFAULT WHERE users.password IS NULL").
+			Suffix("ON CONFLICT (email) DO NOTHING").
 			SuffixExpr(sbReturnUser))
 	// регистрирует нового пользователя
 	// обновляет дату обновления и свойства для уже зарегистрированого
@@ -45,6 +44,15 @@ var (
 				Suffix("ON CONFLICT (email) DO UPDATE SET properties = COALESCE(users.properties, EXCLUDED.properties), updated = DEFAULT, logged = DEFAULT").
 				SuffixExpr(sbReturnUser))
 
+	// регистрирует нового пользователя, только если пользователь с таким
+	// email еще не зарегистрирован
+	sqlInsertUserOpenIDNew = toSQL(sb.
+				Insert("users").
+				Columns("email", "properties").
+				Values("", nil).
+				Suffix("ON CONFLICT (email) DO NOTHING").
+				SuffixExpr(sbReturnUser))
+
 	// заготовка запроса для получения данных о пользователе
 	sbSelectUser = sb.
 			Select(userFields...).
@@ -55,6 +63,9 @@ var (
 				Where(sqrl.Eq{"email": ""})
 	// возвращает информацию о пользователе по email
 	sqlSelectUser = toSQL(sbSelectUserByEmail)
+	// возвращает информацию о пользователе и флаг, что у него задан пароль
+	sqlSelectUserHasPassword = toSQL(sbSelectUserByEmail.
+					Column("password IS NOT NULL"))
 	// возвращает информацию о пользователе и его пароль
 	sqlSelectPassword = toSQL(sbSelectUserByEmail.
 				Column("password").
@@ -64,6 +75,9 @@ var (
 				Join("openid USING (uid)").
 				Where(sqrl.Eq{"provider": ""}).
 				Where(sqrl.Eq{"subject": ""}))
+	// возвращает информацию о пользователе по его идентификатору
+	sqlSelectUserByUID = toSQL(sbSelectUser.
+				Where(sqrl.Eq{"uid": ""}))
 	// возвращает информацию о пользователе по email или идентификатору
 	sqlSelectUserByEmailOrUID = toSQL(sbSelectUser.
 					Where(sqrl.Or{sqrl.Eq{"uid": ""}, sqrl.Eq{"email": ""}}))
@@ -76,6 +90,25 @@ var (
 	// обновляет пароль пользователя
 	sqlUpdatePassword = toSQL(sbUpdateUser.
 				Set("password", ""))
+	// добавляет пароль в историю паролей пользователя
+	sqlInsertPasswordHistory = toSQL(sb.
+					Insert("password_history").
+					Columns("uid", "password").
+					Values("", ""))
+	// возвращает последние пароли пользователя из истории
+	sqlSelectPasswordHistory = toSQL(sb.
+					Select("password").
+					From("password_history").
+					Where(sqrl.Eq{"uid": ""}).
+					OrderBy("created DESC").
+					Suffix("LIMIT ?", 0))
+	// заменяет хеш пароля пользователя, пересчитанный с другими параметрами,
+	// если пароль с тех пор не изменился; дата обновления не меняется
+	sqlRehashPassword = toSQL(sb.
+				Update("users").
+				Set("password", "").
+				Where(sqrl.Eq{"uid": ""}).
+				Where(sqrl.Eq{"password": ""}))
 	// обновляет email и расширенные свойства пользователя
 	sqlUpdateUser = toSQL(sbUpdateUser.
 			Set("email", "").
@@ -90,10 +123,106 @@ var (
 			Where(sqrl.Eq{"uid": ""}))
 	// сохраняет данные о внешней регистрации пользователя
 	sqlInsertOpenID = toSQL(sb.
+			Insert("openid").
+			Columns("provider", "subject", "uid", "claims").
+			Values("", "", "", nil).
+			Suffix("ON CONFLICT (provider,subject) DO UPDATE SET uid = EXCLUDED.uid, claims = COALESCE(EXCLUDED.claims, openid.claims), updated = DEFAULT"))
+	// сохраняет последние данные профиля пользователя от провайдера
+	sqlUpdateOpenIDClaims = toSQL(sb.
+				Update("openid").
+				Set("claims", nil).
+				Set("updated", sqrl.Expr("DEFAULT")).
+				Where(sqrl.Eq{"provider": ""}).
+				Where(sqrl.Eq{"subject": ""}))
+	// дополняет и заменяет расширенные свойства пользователя данными профиля,
+	// если они при этом изменятся
+	sqlMergeProperties = toSQL(sb.
+				Update("users").
+				Set("properties", sqrl.Expr("COALESCE(properties, '{}'::jsonb) || ?::jsonb", "")).
+				Set("updated", sqrl.Expr("DEFAULT")).
+				Where(sqrl.Eq{"uid": ""}).
+				Where("properties IS DISTINCT FROM COALESCE(properties, '{}'::jsonb) || ?::jsonb", "").
+				SuffixExpr(sbReturnUser))
+	// привязывает внешнего провайдера к пользователю; если пользователь
+	// провайдера уже привязан, то возвращает идентификатор того, к кому
+	sqlLinkOpenID = toSQL(sb.
 			Insert("openid").
 			Columns("provider", "subject", "uid").
 			Values("", "", "").
-			Suffix("ON CONFLICT (provider,subject) DO UPDATE SET uid = EXCLUDED.uid"))
+			Suffix("ON CONFLICT (provider,subject) DO UPDATE SET uid = openid.uid RETURNING uid"))
+	// возвращает список внешних провайдеров, привязанных к пользователю
+	sqlSelectOpenIDs = toSQL(sb.
+				Select("provider", "subject", "created").
+				From("openid").
+				Where(sqrl.Eq{"uid": ""}).
+				OrderBy("created"))
+	// блокирует пользователя и возвращает, задан ли у него пароль и сколько
+	// у него других привязанных провайдеров
+	sqlSelectUserLogins = toSQL(sb.
+				Select("password IS NOT NULL").
+				Column("(SELECT count(*) FROM openid WHERE openid.uid = users.uid AND provider <> ?)", "").
+				From("users").
+				Where(sqrl.Eq{"uid": ""}).
+				Suffix("FOR UPDATE"))
+	// создает запрос на привязку провайдера к пользователю с указанным email
+	sqlInsertPendingLink = toSQL(sb.
+				Insert("pending_links").
+				Columns("provider", "subject", "token", "uid").
+				Select(sb.
+					Select().
+					Column("?::varchar", "").
+					Column("?::varchar", "").
+					Column("?::uuid", nil).
+					Column("uid").
+					From("users").
+					Where(sqrl.Eq{"email": ""})).
+				Suffix("RETURNING id"))
+	// возвращает пользователя, провайдера и дату создания запроса на привязку
+	sqlSelectPendingLink = toSQL(sbSelectUser.
+				Column("pending_links.provider").
+				Column("pending_links.created").
+				Join("pending_links USING (uid)").
+				Where(sqrl.Eq{"pending_links.id": ""}))
+	// удаляет запрос на привязку пользователя и возвращает данные провайдера
+	sqlDeletePendingLink = toSQL(sb.
+				Delete("pending_links").
+				Where(sqrl.Eq{"id": ""}).
+				Where(sqrl.Eq{"uid": ""}).
+				Suffix("RETURNING provider, subject, uid, created"))
+	// удаляет запрос на привязку по токену из письма и возвращает данные
+	// провайдера
+	sqlDeletePendingLinkByToken = toSQL(sb.
+					Delete("pending_links").
+					Where(sqrl.Eq{"token": ""}).
+					Suffix("RETURNING provider, subject, uid, created"))
+	// удаляет устаревшие запросы на привязку
+	sqlDeletePendingLinks = toSQL(sb.
+				Delete("pending_links").
+				Where("created < ?", nil))
+	// сохраняет зашифрованные токены провайдера
+	sqlInsertOpenIDToken = toSQL(sb.
+				Insert("openid_tokens").
+				Columns("provider", "subject", "token", "expiry").
+				Values("", "", nil, nil).
+				Suffix("ON CONFLICT (provider,subject) DO UPDATE SET token = EXCLUDED.token, expiry = EXCLUDED.expiry, updated = DEFAULT"))
+	// возвращает последние сохраненные токены провайдера для пользователя
+	sqlSelectOpenIDToken = toSQL(sb.
+				Select("openid_tokens.subject", "openid_tokens.token").
+				From("openid_tokens").
+				Join("openid USING (provider, subject)").
+				Where(sqrl.Eq{"openid.uid": ""}).
+				Where(sqrl.Eq{"openid_tokens.provider": ""}).
+				OrderBy("openid_tokens.updated DESC").
+				Suffix("LIMIT 1"))
+	// отвязывает внешнего провайдера от пользователя
+	sqlDeleteOpenID = toSQL(sb.
+			Delete("openid").
+			Where(sqrl.Eq{"uid": ""}).
+			Where(sqrl.Eq{"provider": ""}))
+	// отвязывает всех внешних провайдеров от пользователя
+	sqlDeleteUserOpenID = toSQL(sb.
+				Delete("openid").
+				Where(sqrl.Eq{"uid": ""}))
 
 	// добавляет email в список подтвержденных
 	sqlInsertVerifiedEmail = toSQL(sb.
@@ -113,22 +242,275 @@ var (
 			Insert("tokens").
 			Columns("domain", "email", "type").
 			Values("", "", 0).
-			Suffix("ON CONFLICT (domain, email, type) DO UPDATE SET id=DEFAULT, sended=DEFAULT, created=DEFAULT").
+			Suffix("ON CONFLICT (domain, email, type) DO UPDATE SET id=DEFAULT, sended=DEFAULT, created=DEFAULT, attempts=DEFAULT, next_attempt=DEFAULT, last_error=DEFAULT, failed=DEFAULT").
 			Suffix("RETURNING id"))
-	// удаляет проверочный токен
+	// удаляет проверочный токен указанного типа
 	sqlDeleteToken = toSQL(sb.
 			Delete("tokens").
 			Where(sqrl.Eq{"id": ""}).
-			Suffix("RETURNING email, type, created"))
-	// возвращает список токенов для отправки
+			Where(sqrl.Eq{"type": 0}).
+			Suffix("RETURNING email, created"))
+	// возвращает список токенов, письма с которыми пора отправить
 	sqlSelectTokens = toSQL(sb.
-			Select("id", "domain", "email", "type").
+			Select("id", "domain", "email", "type", "attempts").
 			From("tokens").
-			Where("sended = FALSE"))
-	sqlUpdateToken = toSQL(sb.
+			Where("sended = FALSE").
+			Where("failed = FALSE").
+			Where("next_attempt <= now()").
+			OrderBy("next_attempt"))
+	// возвращает время ближайшей попытки отправки письма
+	sqlSelectNextAttempt = toSQL(sb.
+				Select("min(next_attempt)").
+				From("tokens").
+				Where("sended = FALSE").
+				Where("failed = FALSE"))
+	// сохраняет результат неудачной попытки отправки письма
+	sqlUpdateTokenFailure = toSQL(sb.
+				Update("tokens").
+				Set("attempts", 0).
+				Set("last_error", "").
+				Set("next_attempt", nil).
+				Set("failed", false).
+				Where(sqrl.Eq{"id": ""}).
+				Where("sended = FALSE"))
+	// заготовка для получения списка неотправленных писем домена
+	sbSelectOutbox = sb.
+			Select("email", "type", "attempts", "next_attempt",
+			"COALESCE(last_error, '')", "failed", "created").
+		From("tokens").
+		Where(sqrl.Eq{"domain": ""}).
+		Where("sended = FALSE").
+		OrderBy("created")
+	// возвращает список неотправленных писем домена
+	sqlSelectOutbox = toSQL(sbSelectOutbox)
+	// возвращает список писем домена, попытки отправки которых прекращены
+	sqlSelectOutboxFailed = toSQL(sbSelectOutbox.
+				Where("failed = TRUE"))
+	// заготовка для возврата писем в очередь на отправку
+	sbRequeueTokens = sb.
+			Update("tokens").
+			Set("failed", false).
+			Set("attempts", 0).
+			Set("next_attempt", sqrl.Expr("now()")).
+			Where(sqrl.Eq{"domain": ""}).
+			Where("failed = TRUE")
+	// возвращает в очередь письмо, попытки отправки которого прекращены
+	sqlRequeueToken = toSQL(sbRequeueTokens.
+			Where(sqrl.Eq{"email": ""}).
+			Where(sqrl.Eq{"type": 0}))
+	// возвращает в очередь все письма домена, попытки отправки которых
+	// прекращены
+	sqlRequeueTokens = toSQL(sbRequeueTokens)
+	// подписывается на уведомления о новых токенах для отправки
+	sqlListenTokens = "LISTEN " + TokensChannel
+	sqlUpdateToken  = toSQL(sb.
 			Update("tokens").
 			Set("sended", sqrl.Expr("TRUE")).
 			Where(sqrl.Eq{"id": ""}))
+
+	// список полей, возвращаемых с информацией о сессии
+	sessionFields = []string{"id", "uid", "domain", "provider", "scope", "ip",
+		"user_agent", "created", "used", "expires"}
+	// создает новую сессию пользователя
+	sqlInsertSession = toSQL(sb.
+				Insert("sessions").
+				Columns("uid", "domain", "provider", "scope", "ip", "user_agent", "token", "expires").
+				Values("", "", nil, nil, nil, nil, nil, nil).
+				Suffix("RETURNING id, created"))
+	// заменяет токен обновления сессии, если его время жизни не истекло
+	sqlUpdateSession = toSQL(sb.
+				Update("sessions").
+				Set("token", nil).
+				Set("expires", nil).
+				Set("used", sqrl.Expr("now()")).
+				Set("ip", sqrl.Expr("COALESCE(?, ip)", nil)).
+				Set("user_agent", sqrl.Expr("COALESCE(?, user_agent)", nil)).
+				Where(sqrl.Eq{"token": ""}).
+				Where(sqrl.Expr("domain = COALESCE(?, domain)", nil)).
+				Where("expires > now()").
+				Suffix("RETURNING " + strings.Join(sessionFields, ", ")))
+	// возвращает действующую сессию по ее идентификатору
+	sqlSelectSession = toSQL(sb.
+				Select(sessionFields...).
+				From("sessions").
+				Where(sqrl.Eq{"id": ""}).
+				Where("expires > now()"))
+	// удаляет сессию по токену обновления
+	sqlDeleteSession = toSQL(sb.
+				Delete("sessions").
+				Where(sqrl.Eq{"token": ""}))
+	// возвращает список действующих сессий пользователя
+	sqlSelectSessions = toSQL(sb.
+				Select(sessionFields...).
+				From("sessions").
+				Where(sqrl.Eq{"uid": ""}).
+				Where("expires > now()").
+				OrderBy("used DESC"))
+	// удаляет сессию пользователя по ее идентификатору
+	sqlDeleteSessionByID = toSQL(sb.
+				Delete("sessions").
+				Where(sqrl.Eq{"id": ""}).
+				Where(sqrl.Eq{"uid": ""}))
+	// удаляет все сессии пользователя
+	sqlDeleteSessions = toSQL(sb.
+				Delete("sessions").
+				Where(sqrl.Eq{"uid": ""}))
+
+	// сохраняет новый секретный ключ двухфакторной авторизации, если она еще
+	// не включена
+	sqlInsertTOTP = toSQL(sb.
+			Insert("totp").
+			Columns("uid", "secret").
+			Values("", "").
+			Suffix("ON CONFLICT (uid) DO UPDATE SET secret = EXCLUDED.secret, counter = DEFAULT, created = DEFAULT WHERE totp.enabled = FALSE"))
+	// возвращает секретный ключ двухфакторной авторизации
+	sqlSelectTOTP = toSQL(sb.
+			Select("secret", "enabled", "counter").
+			From("totp").
+			Where(sqrl.Eq{"uid": ""}).
+			Suffix("FOR UPDATE"))
+	// включает двухфакторную авторизацию
+	sqlEnableTOTP = toSQL(sb.
+			Update("totp").
+			Set("enabled", sqrl.Expr("TRUE")).
+			Set("counter", 0).
+			Where(sqrl.Eq{"uid": ""}))
+	// сохраняет номер периода последнего использованного кода
+	sqlUpdateTOTPCounter = toSQL(sb.
+				Update("totp").
+				Set("counter", 0).
+				Where(sqrl.Eq{"uid": ""}))
+	// удаляет секретный ключ двухфакторной авторизации
+	sqlDeleteTOTP = toSQL(sb.
+			Delete("totp").
+			Where(sqrl.Eq{"uid": ""}))
+	// добавляет код восстановления
+	sqlInsertRecoveryCode = toSQL(sb.
+				Insert("recovery_codes").
+				Columns("uid", "code").
+				Values("", nil))
+	// удаляет использованный код восстановления
+	sqlDeleteRecoveryCode = toSQL(sb.
+				Delete("recovery_codes").
+				Where(sqrl.Eq{"uid": ""}).
+				Where(sqrl.Eq{"code": ""}))
+	// удаляет все коды восстановления пользователя
+	sqlDeleteRecoveryCodes = toSQL(sb.
+				Delete("recovery_codes").
+				Where(sqrl.Eq{"uid": ""}))
+	// создает запрос второго фактора, если двухфакторная авторизация включена
+	sqlInsertChallenge = toSQL(sb.
+				Insert("challenges").
+				Columns("uid").
+				Select(sb.
+					Select("uid").
+					From("totp").
+					Where(sqrl.Eq{"uid": ""}).
+					Where("enabled")).
+				Suffix("RETURNING id"))
+	// возвращает информацию о запросе второго фактора и секретный ключ
+	sqlSelectChallenge = toSQL(sb.
+				Select("challenges.uid", "challenges.created", "totp.secret", "totp.counter").
+				From("challenges").
+				Join("totp USING (uid)").
+				Where(sqrl.Eq{"challenges.id": ""}).
+				Where("totp.enabled").
+				Suffix("FOR UPDATE"))
+	// возвращает пользователя, для которого создан запрос второго фактора
+	sqlSelectChallengeUID = toSQL(sb.
+				Select("uid").
+				From("challenges").
+				Where(sqrl.Eq{"id": ""}))
+	// увеличивает счетчик неудачных попыток ввода кода
+	sqlUpdateChallenge = toSQL(sb.
+				Update("challenges").
+				Set("attempts", sqrl.Expr("attempts + 1")).
+				Where(sqrl.Eq{"id": ""}).
+				Suffix("RETURNING attempts"))
+	// удаляет запрос второго фактора
+	sqlDeleteChallenge = toSQL(sb.
+				Delete("challenges").
+				Where(sqrl.Eq{"id": ""}))
+	// удаляет устаревшие запросы второго фактора
+	sqlDeleteChallenges = toSQL(sb.
+				Delete("challenges").
+				Where("created < ?", nil))
+
+	// сохраняет состояние авторизации OpenID Connect
+	sqlInsertOpenIDState = toSQL(sb.
+				Insert("openid_states").
+				Columns("key", "redirect_uri", "nonce", "verifier", "data", "created").
+				Values("", "", "", "", nil, nil))
+	// удаляет и возвращает состояние авторизации OpenID Connect
+	sqlDeleteOpenIDState = toSQL(sb.
+				Delete("openid_states").
+				Where(sqrl.Eq{"key": ""}).
+				Suffix("RETURNING redirect_uri, nonce, verifier, data, created"))
+	// удаляет устаревшие состояния авторизации OpenID Connect
+	sqlDeleteOpenIDStates = toSQL(sb.
+				Delete("openid_states").
+				Where("created < ?", nil))
+
+	// возвращает информацию о зарегистрированном клиенте OpenID Connect
+	sqlSelectClient = toSQL(sb.
+			Select("id", "name", "domain", "redirect_uris", "secret IS NOT NULL").
+			From("oidc_clients").
+			Where(sqrl.Eq{"id": ""}))
+	// возвращает информацию о клиенте OpenID Connect, если секретный ключ
+	// совпадает
+	sqlSelectClientSecret = toSQL(sb.
+				Select("id", "name", "domain", "redirect_uris", "secret IS NOT NULL").
+				From("oidc_clients").
+				Where(sqrl.Eq{"id": ""}).
+				Where("secret = crypt(?, secret)", ""))
+	// сохраняет код авторизации OpenID Connect
+	sqlInsertAuthCode = toSQL(sb.
+				Insert("oidc_codes").
+				Columns("code", "client_id", "uid", "redirect_uri", "scope", "nonce", "challenge").
+				Values(nil, "", "", "", "", "", ""))
+	// удаляет и возвращает информацию о коде авторизации OpenID Connect
+	sqlDeleteAuthCode = toSQL(sb.
+				Delete("oidc_codes").
+				Where(sqrl.Eq{"code": ""}).
+				Where(sqrl.Eq{"client_id": ""}).
+				Suffix("RETURNING uid, redirect_uri, scope, nonce, challenge, created"))
+	// удаляет устаревшие коды авторизации OpenID Connect
+	sqlDeleteAuthCodes = toSQL(sb.
+				Delete("oidc_codes").
+				Where("created < ?", nil))
+
+	// возвращает время окончания блокировки авторизации для почтового адреса
+	// или IP-адреса клиента
+	sqlSelectLoginLocked = toSQL(sb.
+				Select("locked").
+				From("login_attempts").
+				Where(sqrl.Or{
+			sqrl.And{sqrl.Eq{"scope": ""}, sqrl.Eq{"key": ""}},
+			sqrl.And{sqrl.Eq{"scope": ""}, sqrl.Eq{"key": ""}},
+		}).
+		Where("locked > now()").
+		OrderBy("locked DESC").
+		Limit(1))
+	// увеличивает счетчик неудачных попыток авторизации; если последняя
+	// неудачная попытка была раньше указанного времени, то счетчик
+	// начинается заново
+	sqlInsertLoginFailure = toSQL(sb.
+				Insert("login_attempts").
+				Columns("scope", "key").
+				Values("", "").
+				Suffix("ON CONFLICT (scope, key) DO UPDATE SET failures = CASE WHEN login_attempts.updated < ? THEN 1 ELSE login_attempts.failures + 1 END, updated = DEFAULT", nil).
+				Suffix("RETURNING failures"))
+	// устанавливает время окончания блокировки авторизации
+	sqlUpdateLoginLocked = toSQL(sb.
+				Update("login_attempts").
+				Set("locked", nil).
+				Where(sqrl.Eq{"scope": ""}).
+				Where(sqrl.Eq{"key": ""}))
+	// сбрасывает счетчик неудачных попыток авторизации
+	sqlDeleteLoginAttempts = toSQL(sb.
+				Delete("login_attempts").
+				Where(sqrl.Eq{"scope": ""}).
+				Where(sqrl.Eq{"key": ""}))
 )
 
 // toSQL формирует и возвращает строку с sql-запросом.
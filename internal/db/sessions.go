@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultSessionTTL задает время жизни токена обновления сессии, если оно не
+// задано в настройках Adapter.
+var DefaultSessionTTL = time.Hour * 24 * 30
+
+// Session описывает информацию о сессии авторизованного пользователя.
+type Session struct {
+	ID        string    // уникальный идентификатор сессии
+	UID       string    // уникальный идентификатор пользователя
+	Domain    string    // домен, для которого была произведена авторизация
+	Provider  string    // провайдер авторизации (пустой для авторизации по паролю)
+	Scope     string    // области доступа клиента OpenID Connect
+	IP        string    // IP-адрес клиента
+	UserAgent string    // информация о приложении клиента
+	Token     string    // токен обновления сессии (только при создании и обновлении)
+	Created   time.Time // время создания сессии
+	Used      time.Time // время последнего обновления сессии
+	Expires   time.Time // время окончания действия токена обновления
+}
+
+// refreshToken генерирует новый токен обновления сессии. Возвращает строковое
+// представление токена и его хеш, который сохраняется в базе данных.
+func refreshToken() (string, []byte, error) {
+	var token = make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", nil, err
+	}
+	var hash = sha256.Sum256(token)
+	return tokenCoder.EncodeToString(token), hash[:], nil
+}
+
+// refreshTokenHash возвращает хеш от строкового представления токена
+// обновления сессии.
+func refreshTokenHash(token string) ([]byte, error) {
+	data, err := tokenCoder.DecodeString(token)
+	if err != nil {
+		return nil, ErrBadToken
+	}
+	var hash = sha256.Sum256(data)
+	return hash[:], nil
+}
+
+// sessionTTL возвращает время жизни токена обновления сессии.
+func (db *Adapter) sessionTTL() time.Duration {
+	if db.SessionTTL > 0 {
+		return db.SessionTTL
+	}
+	return DefaultSessionTTL
+}
+
+// SessionCreate создает новую сессию пользователя и возвращает информацию о
+// ней вместе с токеном обновления. В базе данных сохраняется только хеш от
+// токена, поэтому получить его повторно невозможно.
+//
+// provider, ip и userAgent являются необязательными и сохраняются только для
+// информации. scope задает области доступа, разрешенные клиенту OpenID
+// Connect, и сохраняется для токенов доступа, выдаваемых при обновлении
+// сессии.
+func (db *Adapter) SessionCreate(ctx context.Context,
+	uid, domain, provider, scope, ip, userAgent string) (*Session, error) {
+	token, hash, err := refreshToken()
+	if err != nil {
+		return nil, err
+	}
+	var session = &Session{
+		UID:       uid,
+		Domain:    domain,
+		Provider:  provider,
+		Scope:     scope,
+		IP:        ip,
+		UserAgent: userAgent,
+		Token:     token,
+		Expires:   time.Now().Add(db.sessionTTL()),
+	}
+	err = db.QueryRow(ctx, sqlInsertSession, uid, domain, null(provider),
+		null(scope), null(ip), null(userAgent), hash, session.Expires).
+		Scan(&session.ID, &session.Created)
+	if err != nil {
+		return nil, err
+	}
+	session.Used = session.Created
+	return session, nil
+}
+
+// SessionRefresh проверяет токен обновления и заменяет его на новый. Вместе с
+// информацией о сессии возвращает актуальную информацию о пользователе.
+// Старый токен обновления после этого становится недействительным.
+//
+// Так же обновляется время последнего использования сессии и, если заданы,
+// IP-адрес и информация о приложении клиента.
+//
+// Если задан domain, то токен принимается только для сессии этого домена:
+// токен сессии другого домена не заменяется.
+//
+// Возвращает ошибку ErrBadToken, если токен не найден, выдан для другого
+// домена или время его жизни истекло. Так же может быть ошибка ErrBlocked,
+// если пользователь заблокирован.
+func (db *Adapter) SessionRefresh(ctx context.Context,
+	token, domain, ip, userAgent string) (*Session, *UserInfo, error) {
+	oldHash, err := refreshTokenHash(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, hash, err := refreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	var session = &Session{
+		Token:   token,
+		Expires: time.Now().Add(db.sessionTTL()),
+	}
+	// стартуем транзакцию, чтобы токен не заменялся, если пользователь
+	// заблокирован
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+	// заменяем токен обновления на новый
+	err = scanSession(tx.QueryRow(ctx, sqlUpdateSession, hash, session.Expires,
+		null(ip), null(userAgent), oldHash, null(domain)), session)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrBadToken
+		}
+		return nil, nil, err
+	}
+	// запрашиваем и разбираем информацию о пользователе
+	user, err := scanUser(tx.QueryRow(ctx, sqlSelectUserByUID, session.UID))
+	if err != nil {
+		return nil, nil, err
+	}
+	// принимаем транзакцию
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, user, nil
+}
+
+// SessionGet возвращает действующую сессию по ее идентификатору.
+//
+// Возвращает ошибку ErrNotFound, если сессия не найдена, завершена или время
+// ее жизни истекло.
+func (db *Adapter) SessionGet(ctx context.Context,
+	id string) (*Session, error) {
+	var session = new(Session)
+	err := scanSession(db.QueryRow(ctx, sqlSelectSession, id), session)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// SessionDelete удаляет сессию по ее токену обновления.
+//
+// Возвращает ошибку ErrBadToken, если сессия с таким токеном не найдена.
+func (db *Adapter) SessionDelete(ctx context.Context,
+	token string) error {
+	hash, err := refreshTokenHash(token)
+	if err != nil {
+		return err
+	}
+	err = oneRow(db.Exec(ctx, sqlDeleteSession, hash))
+	if errors.Is(err, ErrNotFound) {
+		return ErrBadToken
+	}
+	return err
+}
+
+// SessionList возвращает список действующих сессий пользователя, начиная с
+// последней использованной.
+func (db *Adapter) SessionList(ctx context.Context,
+	uid string) ([]Session, error) {
+	rows, err := db.Query(ctx, sqlSelectSessions, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var sessions = make([]Session, 0)
+	for rows.Next() {
+		var session Session
+		if err = scanSession(rows, &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// SessionRevoke завершает сессию пользователя по ее идентификатору.
+//
+// Возвращает ошибку ErrNotFound, если у пользователя нет такой сессии.
+func (db *Adapter) SessionRevoke(ctx context.Context,
+	uid, id string) error {
+	return oneRow(db.Exec(ctx, sqlDeleteSessionByID, id, uid))
+}
+
+// SessionRevokeAll завершает все сессии пользователя. Если у пользователя нет
+// ни одной сессии, то ошибка не возвращается.
+func (db *Adapter) SessionRevokeAll(ctx context.Context,
+	uid string) error {
+	_, err := db.Exec(ctx, sqlDeleteSessions, uid)
+	return err
+}
+
+// scanSession разбирает полученные данные о сессии. Токен обновления при этом
+// не заполняется.
+func scanSession(row pgx.Row, session *Session) error {
+	var provider, scope, ip, userAgent *string
+	err := row.Scan(
+		&session.ID,
+		&session.UID,
+		&session.Domain,
+		&provider,
+		&scope,
+		&ip,
+		&userAgent,
+		&session.Created,
+		&session.Used,
+		&session.Expires,
+	)
+	if err != nil {
+		return err
+	}
+	if provider != nil {
+		session.Provider = *provider
+	}
+	if scope != nil {
+		session.Scope = *scope
+	}
+	if ip != nil {
+		session.IP = *ip
+	}
+	if userAgent != nil {
+		session.UserAgent = *userAgent
+	}
+	return nil
+}
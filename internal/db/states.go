@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"itube/users/pkg/openid"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// проверка, что хранилище поддерживает интерфейс
+var _ openid.StateStore = new(OpenIDStates)
+
+// OpenIDStates хранит состояния авторизации OpenID Connect в базе данных.
+// В отличие от хранилища в памяти, позволяет начать авторизацию на одном
+// экземпляре сервиса, а завершить на другом, и не теряет состояния при
+// перезапуске.
+type OpenIDStates struct {
+	*Adapter
+}
+
+// Save сохраняет состояние авторизации с указанным ключом.
+func (db *OpenIDStates) Save(ctx context.Context,
+	key string, state openid.State) error {
+	var data []byte
+	if len(state.Data) > 0 {
+		data = state.Data
+	}
+	_, err := db.Exec(ctx, sqlInsertOpenIDState, key, state.RedirectURI,
+		state.Nonce, state.Verifier, data, state.Created)
+	return err
+}
+
+// Take возвращает и удаляет состояние авторизации с указанным ключом.
+// Возвращает openid.ErrBadState, если состояние не найдено или время его
+// жизни истекло.
+func (db *OpenIDStates) Take(ctx context.Context,
+	key string) (*openid.State, error) {
+	var (
+		state = new(openid.State)
+		data  []byte
+	)
+	err := db.QueryRow(ctx, sqlDeleteOpenIDState, key).Scan(
+		&state.RedirectURI, &state.Nonce, &state.Verifier, &data,
+		&state.Created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, openid.ErrBadState
+		}
+		return nil, err
+	}
+	if state.Created.Before(time.Now().Add(-openid.StateTTL)) {
+		return nil, openid.ErrBadState
+	}
+	state.Data = data
+	return state, nil
+}
+
+// Cleanup удаляет устаревшие состояния авторизации. Возвращает количество
+// удаленных состояний.
+func (db *OpenIDStates) Cleanup(ctx context.Context) (int64, error) {
+	tag, err := db.Exec(ctx, sqlDeleteOpenIDStates,
+		time.Now().Add(-openid.StateTTL))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Throttle задает ограничения на количество неудачных попыток авторизации.
+//
+// Неудачные попытки считаются отдельно для каждого почтового адреса и
+// IP-адреса клиента. После Failures неудачных попыток подряд авторизация
+// временно блокируется на время Lockout, которое удваивается с каждой
+// следующей неудачной попыткой, но не превышает MaxLockout. Счетчик
+// сбрасывается после успешной авторизации, сброса пароля или если в течение
+// Window не было неудачных попыток.
+type Throttle struct {
+	EmailFailures int           // количество попыток для почтового адреса (0 - без ограничений)
+	IPFailures    int           // количество попыток для IP-адреса (0 - без ограничений)
+	Lockout       time.Duration // начальное время блокировки
+	MaxLockout    time.Duration // максимальное время блокировки
+	Window        time.Duration // время, после которого счетчик сбрасывается
+}
+
+// DefaultThrottle задает ограничения на количество неудачных попыток
+// авторизации по умолчанию.
+var DefaultThrottle = Throttle{
+	EmailFailures: 5,
+	IPFailures:    20,
+	Lockout:       time.Minute,
+	MaxLockout:    time.Hour,
+	Window:        time.Hour * 24,
+}
+
+// типы счетчиков неудачных попыток авторизации
+const (
+	scopeEmail = "email"
+	scopeIP    = "ip"
+	scopeTOTP  = "totp" // проверка кода второго фактора пользователя
+)
+
+// lockout возвращает время блокировки после указанного количества неудачных
+// попыток подряд или 0, если блокировка не требуется.
+func (t Throttle) lockout(failures, limit int) time.Duration {
+	if limit <= 0 || failures < limit {
+		return 0
+	}
+	var lockout = t.Lockout
+	for i := limit; i < failures && (t.MaxLockout <= 0 || lockout < t.MaxLockout); i++ {
+		lockout *= 2
+	}
+	if t.MaxLockout > 0 && lockout > t.MaxLockout {
+		lockout = t.MaxLockout
+	}
+	return lockout
+}
+
+// LoginLocked проверяет, не заблокирована ли временно авторизация для
+// почтового адреса или IP-адреса клиента. Возвращает время, оставшееся до
+// окончания блокировки, или 0, если авторизация не заблокирована.
+func (db *Adapter) LoginLocked(ctx context.Context,
+	email, ip string) (time.Duration, error) {
+	var locked time.Time
+	err := db.QueryRow(ctx, sqlSelectLoginLocked,
+		scopeEmail, strings.ToLower(email), scopeIP, ip).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil // блокировки нет
+		}
+		return 0, err
+	}
+	return time.Until(locked), nil
+}
+
+// LoginFailed увеличивает счетчики неудачных попыток авторизации для
+// почтового адреса и IP-адреса клиента. Если количество попыток превысило
+// ограничение, то авторизация временно блокируется. Возвращает время
+// блокировки или 0, если блокировка не установлена.
+func (db *Adapter) LoginFailed(ctx context.Context,
+	email, ip string) (time.Duration, error) {
+	var (
+		limits = db.Throttle
+		result time.Duration
+	)
+	for _, counter := range []struct {
+		scope, key string
+		limit      int
+	}{
+		{scopeEmail, strings.ToLower(email), limits.EmailFailures},
+		{scopeIP, ip, limits.IPFailures},
+	} {
+		lockout, err := db.attemptFailed(ctx, counter.scope, counter.key,
+			counter.limit)
+		if err != nil {
+			return 0, err
+		}
+		if lockout > result {
+			result = lockout
+		}
+	}
+	return result, nil
+}
+
+// LoginSucceeded сбрасывает счетчик неудачных попыток авторизации для
+// почтового адреса. Счетчик для IP-адреса не сбрасывается, чтобы успешная
+// авторизация в одну учетную запись не позволяла продолжать перебор
+// паролей для других.
+func (db *Adapter) LoginSucceeded(ctx context.Context,
+	email string) error {
+	_, err := db.Exec(ctx, sqlDeleteLoginAttempts, scopeEmail,
+		strings.ToLower(email))
+	return err
+}
+
+// TOTPLocked проверяет, не заблокирована ли временно проверка кода второго
+// фактора пользователя (при авторизации и отключении двухфакторной
+// авторизации). Возвращает время, оставшееся до окончания блокировки, или 0,
+// если проверка не заблокирована.
+func (db *Adapter) TOTPLocked(ctx context.Context,
+	uid string) (time.Duration, error) {
+	var locked time.Time
+	err := db.QueryRow(ctx, sqlSelectLoginLocked,
+		scopeTOTP, uid, scopeTOTP, uid).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil // блокировки нет
+		}
+		return 0, err
+	}
+	return time.Until(locked), nil
+}
+
+// TOTPFailed увеличивает счетчик неверных кодов второго фактора
+// пользователя. Счетчик общий для всех запросов второго фактора, поэтому
+// создание новых запросов его не сбрасывает. После ChallengeAttempts
+// неудачных попыток подряд проверка временно блокируется так же, как и
+// авторизация. Возвращает время блокировки или 0, если блокировка не
+// установлена.
+func (db *Adapter) TOTPFailed(ctx context.Context,
+	uid string) (time.Duration, error) {
+	return db.attemptFailed(ctx, scopeTOTP, uid, ChallengeAttempts)
+}
+
+// attemptFailed увеличивает счетчик неудачных попыток и, если их количество
+// превысило limit, блокирует попытки. Возвращает время блокировки или 0,
+// если блокировка не установлена.
+func (db *Adapter) attemptFailed(ctx context.Context,
+	scope, key string, limit int) (time.Duration, error) {
+	if key == "" || limit <= 0 {
+		return 0, nil // счетчик не используется
+	}
+	// увеличиваем счетчик неудачных попыток
+	var (
+		limits   = db.Throttle
+		failures int
+	)
+	err := db.QueryRow(ctx, sqlInsertLoginFailure, scope, key,
+		time.Now().Add(-limits.Window)).Scan(&failures)
+	if err != nil {
+		return 0, err
+	}
+	// блокируем попытки, если они закончились
+	var lockout = limits.lockout(failures, limit)
+	if lockout <= 0 {
+		return 0, nil
+	}
+	_, err = db.Exec(ctx, sqlUpdateLoginLocked, time.Now().Add(lockout),
+		scope, key)
+	if err != nil {
+		return 0, err
+	}
+	return lockout, nil
+}
@@ -0,0 +1,372 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"itube/users/pkg/totp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// Настройки двухфакторной авторизации.
+var (
+	ChallengeTTL      = time.Minute * 5 // время жизни запроса второго фактора
+	ChallengeAttempts = 5               // количество попыток ввода кода на запрос
+	RecoveryCodes     = 10              // количество кодов восстановления
+)
+
+// recoveryAlphabet задает символы, используемые в кодах восстановления.
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// recoveryCodes генерирует новый набор кодов восстановления. Возвращает
+// сами коды и их хеши, которые сохраняются в базе данных.
+func recoveryCodes() ([]string, [][]byte, error) {
+	var (
+		codes  = make([]string, RecoveryCodes)
+		hashes = make([][]byte, RecoveryCodes)
+		buf    = make([]byte, 10)
+	)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		for j, b := range buf {
+			buf[j] = recoveryAlphabet[int(b)%len(recoveryAlphabet)]
+		}
+		codes[i] = string(buf[:5]) + "-" + string(buf[5:])
+		hashes[i] = recoveryCodeHash(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// recoveryCodeHash возвращает хеш от кода восстановления. Перед вычислением
+// хеша код приводится к нижнему регистру, а разделители удаляются.
+func recoveryCodeHash(code string) []byte {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	var hash = sha256.Sum256([]byte(code))
+	return hash[:]
+}
+
+// TOTPEnroll генерирует новый секретный ключ для двухфакторной авторизации
+// пользователя. Двухфакторная авторизация включается только после
+// подтверждения ключа кодом из приложения с помощью TOTPConfirm. Повторный
+// вызов до подтверждения заменяет ключ на новый.
+//
+// Возвращает ошибку ErrTOTPEnabled, если двухфакторная авторизация уже
+// включена, и ErrNotFound, если пользователь не зарегистрирован.
+func (db *Adapter) TOTPEnroll(ctx context.Context,
+	uid string) (string, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", err
+	}
+	err = oneRow(db.Exec(ctx, sqlInsertTOTP, uid, secret))
+	if err != nil {
+		// ключ не заменяется, если двухфакторная авторизация уже включена
+		if errors.Is(err, ErrNotFound) {
+			return "", ErrTOTPEnabled
+		}
+		// проверяем, что пользователь с таким идентификатором
+		// зарегистрирован
+		var dbErr = new(pgconn.PgError)
+		if errors.As(err, &dbErr) && dbErr.Code == "23503" {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return secret, nil
+}
+
+// TOTPConfirm проверяет код из приложения и включает двухфакторную
+// авторизацию пользователя. Возвращает список одноразовых кодов
+// восстановления, которые можно использовать вместо кода из приложения.
+// В базе данных сохраняются только хеши от этих кодов.
+//
+// Возвращает ошибку ErrInvalidCode, если код неверен, ErrTOTPNotEnabled,
+// если ключ не был сгенерирован, и ErrTOTPEnabled, если двухфакторная
+// авторизация уже включена.
+func (db *Adapter) TOTPConfirm(ctx context.Context,
+	uid, code string) ([]string, error) {
+	codes, hashes, err := recoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	// получаем секретный ключ пользователя
+	var (
+		secret  string
+		enabled bool
+		counter int64
+	)
+	err = tx.QueryRow(ctx, sqlSelectTOTP, uid).Scan(&secret, &enabled, &counter)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTOTPNotEnabled
+		}
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrTOTPEnabled
+	}
+	// проверяем код из приложения
+	counter, err = totp.Validate(secret, code, time.Now(), counter)
+	if err != nil {
+		return nil, err
+	}
+	if counter == 0 {
+		return nil, ErrInvalidCode
+	}
+	// включаем двухфакторную авторизацию
+	_, err = tx.Exec(ctx, sqlEnableTOTP, counter, uid)
+	if err != nil {
+		return nil, err
+	}
+	// заменяем коды восстановления на новые
+	_, err = tx.Exec(ctx, sqlDeleteRecoveryCodes, uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, hash := range hashes {
+		_, err = tx.Exec(ctx, sqlInsertRecoveryCode, uid, hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// принимаем транзакцию
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// TOTPDisable проверяет код из приложения или код восстановления и отключает
+// двухфакторную авторизацию пользователя.
+//
+// Возвращает ошибку ErrInvalidCode, если код неверен, и ErrTOTPNotEnabled,
+// если двухфакторная авторизация не включена. Неверные коды подсчитываются
+// через TOTPFailed, а после отключения счетчик сбрасывается.
+func (db *Adapter) TOTPDisable(ctx context.Context,
+	uid, code string) error {
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	// получаем секретный ключ пользователя
+	var (
+		secret  string
+		enabled bool
+		counter int64
+	)
+	err = tx.QueryRow(ctx, sqlSelectTOTP, uid).Scan(&secret, &enabled, &counter)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTOTPNotEnabled
+		}
+		return err
+	}
+	if !enabled {
+		return ErrTOTPNotEnabled
+	}
+	// проверяем код
+	err = checkSecondFactor(ctx, tx, uid, secret, counter, code)
+	if err != nil {
+		return err
+	}
+	// удаляем ключ и коды восстановления
+	_, err = tx.Exec(ctx, sqlDeleteTOTP, uid)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, sqlDeleteRecoveryCodes, uid)
+	if err != nil {
+		return err
+	}
+	// сбрасываем счетчик неверных кодов
+	_, err = tx.Exec(ctx, sqlDeleteLoginAttempts, scopeTOTP, uid)
+	if err != nil {
+		return err
+	}
+	// принимаем транзакцию
+	return tx.Commit(ctx)
+}
+
+// ChallengeCreate создает запрос второго фактора авторизации, если у
+// пользователя включена двухфакторная авторизация, и возвращает его токен.
+// Если двухфакторная авторизация не включена, то возвращается пустая строка.
+func (db *Adapter) ChallengeCreate(ctx context.Context,
+	uid string) (string, error) {
+	var challenge []byte
+	err := db.QueryRow(ctx, sqlInsertChallenge, uid).Scan(&challenge)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil // двухфакторная авторизация не включена
+		}
+		return "", err
+	}
+	return tokenCoder.EncodeToString(challenge), nil
+}
+
+// ChallengeUID возвращает идентификатор пользователя, для которого создан
+// запрос второго фактора авторизации. Используется для проверки
+// ограничения неудачных попыток до проверки кода. Возвращает ошибку
+// ErrBadToken, если запрос не найден.
+func (db *Adapter) ChallengeUID(ctx context.Context,
+	challenge string) (string, error) {
+	// декодируем токен в бинарный формат
+	id, err := tokenCoder.DecodeString(challenge)
+	if err != nil {
+		return "", ErrBadToken
+	}
+	var uid string
+	err = db.QueryRow(ctx, sqlSelectChallengeUID, id).Scan(&uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrBadToken
+		}
+		return "", err
+	}
+	return uid, nil
+}
+
+// ChallengeVerify проверяет код из приложения или код восстановления для
+// запроса второго фактора авторизации и возвращает информацию о
+// пользователе. После успешной проверки запрос удаляется и повторное его
+// использование невозможно.
+//
+// Количество попыток ввода кода для одного запроса ограничено
+// ChallengeAttempts, после чего запрос удаляется; общее количество неверных
+// кодов пользователя подсчитывается через TOTPFailed и сбрасывается после
+// успешной проверки. Возвращает ошибку
+// ErrInvalidCode, если код неверен, ErrBadToken, если запрос не найден, и
+// ErrTokenExpired, если время его жизни истекло. Так же может быть ошибка
+// ErrBlocked, если пользователь заблокирован.
+func (db *Adapter) ChallengeVerify(ctx context.Context,
+	challenge, code string) (*UserInfo, error) {
+	// декодируем токен в бинарный формат
+	id, err := tokenCoder.DecodeString(challenge)
+	if err != nil {
+		return nil, ErrBadToken
+	}
+	// стартуем транзакцию
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	// получаем информацию о запросе и секретный ключ пользователя
+	var (
+		uid, secret string
+		counter     int64
+		created     time.Time
+	)
+	err = tx.QueryRow(ctx, sqlSelectChallenge, id).Scan(
+		&uid, &created, &secret, &counter)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBadToken
+		}
+		return nil, err
+	}
+	// проверяем время жизни запроса
+	if time.Since(created) > ChallengeTTL {
+		_, err = tx.Exec(ctx, sqlDeleteChallenge, id)
+		if err == nil {
+			err = tx.Commit(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenExpired
+	}
+	// проверяем код
+	err = checkSecondFactor(ctx, tx, uid, secret, counter, code)
+	if errors.Is(err, ErrInvalidCode) {
+		// увеличиваем счетчик неудачных попыток и удаляем запрос, если
+		// попытки закончились
+		var attempts int
+		err = tx.QueryRow(ctx, sqlUpdateChallenge, id).Scan(&attempts)
+		if err == nil && attempts >= ChallengeAttempts {
+			_, err = tx.Exec(ctx, sqlDeleteChallenge, id)
+		}
+		if err == nil {
+			err = tx.Commit(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCode
+	}
+	if err != nil {
+		return nil, err
+	}
+	// удаляем использованный запрос
+	_, err = tx.Exec(ctx, sqlDeleteChallenge, id)
+	if err != nil {
+		return nil, err
+	}
+	// сбрасываем счетчик неверных кодов пользователя
+	_, err = tx.Exec(ctx, sqlDeleteLoginAttempts, scopeTOTP, uid)
+	if err != nil {
+		return nil, err
+	}
+	// запрашиваем и разбираем информацию о пользователе
+	user, err := scanUser(tx.QueryRow(ctx, sqlSelectUserByUID, uid))
+	if err != nil {
+		return nil, err
+	}
+	// принимаем транзакцию
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// обновляем дату последней успешной авторизации (ошибку игнорируем)
+	_ = oneRow(db.Exec(ctx, sqlLogged, user.UID))
+	return user, nil
+}
+
+// ChallengeCleanup удаляет запросы второго фактора, время жизни которых
+// истекло, и возвращает их количество.
+func (db *Adapter) ChallengeCleanup(ctx context.Context) (int64, error) {
+	tag, err := db.Exec(ctx, sqlDeleteChallenges, time.Now().Add(-ChallengeTTL))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// checkSecondFactor проверяет код из приложения или код восстановления.
+// Использованный код восстановления удаляется, а для кода из приложения
+// запоминается номер периода, чтобы его нельзя было использовать повторно.
+//
+// Возвращает ошибку ErrInvalidCode, если код неверен.
+func checkSecondFactor(ctx context.Context, q querier,
+	uid, secret string, counter int64, code string) error {
+	// проверяем код из приложения
+	counter, err := totp.Validate(secret, code, time.Now(), counter)
+	if err != nil {
+		return err
+	}
+	if counter > 0 {
+		_, err = q.Exec(ctx, sqlUpdateTOTPCounter, counter, uid)
+		return err
+	}
+	// проверяем и удаляем код восстановления
+	err = oneRow(q.Exec(ctx, sqlDeleteRecoveryCode, uid, recoveryCodeHash(code)))
+	if errors.Is(err, ErrNotFound) {
+		return ErrInvalidCode
+	}
+	return err
+}
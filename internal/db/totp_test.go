@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"itube/users/pkg/tools"
+)
+
+// testDB возвращает подключение к тестовой базе данных с примененными
+// миграциями, заданной в переменной окружения ITUBE_USERS_TEST_DSN. Если
+// она не задана, то тест пропускается.
+func testDB(t *testing.T) *Adapter {
+	t.Helper()
+	var dsn = os.Getenv("ITUBE_USERS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("ITUBE_USERS_TEST_DSN is not set")
+	}
+	pool, err := tools.Connect(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return &Adapter{Pool: pool}
+}
+
+func TestChallengeCleanup(t *testing.T) {
+	var (
+		db    = testDB(t)
+		ctx   = context.Background()
+		email = fmt.Sprintf("challenge-%d@example.com", time.Now().UnixNano())
+	)
+	user, err := db.Register(ctx, email, "Str0ng-Passw0rd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// включаем двухфакторную авторизацию напрямую: код в тесте не нужен
+	_, err = db.Exec(ctx,
+		"INSERT INTO totp (uid, secret, enabled) VALUES ($1, 'SECRET', TRUE)",
+		user.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var challenges = make([]string, 2)
+	for i := range challenges {
+		if challenges[i], err = db.ChallengeCreate(ctx, user.UID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// первый запрос устарел
+	id, err := tokenCoder.DecodeString(challenges[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(ctx, "UPDATE challenges SET created = $1 WHERE id = $2",
+		time.Now().Add(-ChallengeTTL-time.Minute), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := db.ChallengeCleanup(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count < 1 {
+		t.Errorf("deleted challenges: %d", count)
+	}
+	if _, err = db.ChallengeUID(ctx, challenges[0]); !errors.Is(err, ErrBadToken) {
+		t.Errorf("unexpected expired challenge error: %v", err)
+	}
+	if uid, err := db.ChallengeUID(ctx, challenges[1]); err != nil || uid != user.UID {
+		t.Errorf("actual challenge: %s, %v", uid, err)
+	}
+}
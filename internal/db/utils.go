@@ -1,9 +1,20 @@
 package db
 
 import (
+	"context"
+
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 )
 
+// querier описывает общие методы для выполнения запросов как через пулл
+// соединений, так и внутри транзакции.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 // используется для сохранения пустых строк в базе данных как NULL.
 func null(str string) *string {
 	if str == "" {
@@ -0,0 +1,318 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"itube/users/internal/db"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// authRequest описывает параметры запроса авторизации.
+type authRequest struct {
+	ClientID        string
+	RedirectURI     string
+	ResponseType    string
+	Scope           string
+	State           string
+	Nonce           string
+	Challenge       string
+	ChallengeMethod string
+	LoginHint       string
+}
+
+// parseAuthRequest возвращает параметры запроса авторизации.
+func parseAuthRequest(form url.Values) authRequest {
+	return authRequest{
+		ClientID:        form.Get("client_id"),
+		RedirectURI:     form.Get("redirect_uri"),
+		ResponseType:    form.Get("response_type"),
+		Scope:           form.Get("scope"),
+		State:           form.Get("state"),
+		Nonce:           form.Get("nonce"),
+		Challenge:       form.Get("code_challenge"),
+		ChallengeMethod: form.Get("code_challenge_method"),
+		LoginHint:       form.Get("login_hint"),
+	}
+}
+
+// Params возвращает параметры запроса авторизации для повторной передачи
+// через форму входа.
+func (a authRequest) Params() map[string]string {
+	var params = map[string]string{
+		"client_id":             a.ClientID,
+		"redirect_uri":          a.RedirectURI,
+		"response_type":         a.ResponseType,
+		"scope":                 a.Scope,
+		"state":                 a.State,
+		"nonce":                 a.Nonce,
+		"code_challenge":        a.Challenge,
+		"code_challenge_method": a.ChallengeMethod,
+	}
+	for name, value := range params {
+		if value == "" {
+			delete(params, name)
+		}
+	}
+	return params
+}
+
+// validate проверяет параметры запроса авторизации и возвращает код ошибки
+// OAuth2, если запрос не может быть выполнен.
+func (a authRequest) validate(client *db.Client) string {
+	switch {
+	case a.ResponseType != "code":
+		return "unsupported_response_type"
+	case !hasScope(a.Scope, "openid"):
+		return "invalid_scope"
+	case a.Challenge == "" && !client.Confidential:
+		// для публичных клиентов PKCE обязателен
+		return "invalid_request"
+	case a.Challenge != "" && a.ChallengeMethod != "S256":
+		return "invalid_request"
+	}
+	return ""
+}
+
+// hasScope возвращает true, если область доступа есть в списке scope.
+func hasScope(scope, name string) bool {
+	for _, item := range strings.Fields(scope) {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+// loginPage описывает данные для отображения формы входа.
+type loginPage struct {
+	Client    string            // название приложения
+	Params    map[string]string // параметры запроса авторизации
+	Email     string            // email пользователя
+	Challenge string            // запрос второго фактора авторизации
+	Error     string            // сообщение об ошибке
+}
+
+// loginTemplate задает шаблон формы входа и ввода кода второго фактора.
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Вход{{if .Client}} в {{.Client}}{{end}}</title>
+</head>
+<body>
+<form method="post">
+<h1>Вход{{if .Client}} в {{.Client}}{{end}}</h1>
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}{{if .Challenge}}<input type="hidden" name="challenge" value="{{.Challenge}}">
+<p><label>Код из приложения или код восстановления<br>
+<input name="code" autocomplete="one-time-code" required autofocus></label></p>
+{{else}}<p><label>Email<br>
+<input type="email" name="email" value="{{.Email}}" autocomplete="username" required{{if not .Email}} autofocus{{end}}></label></p>
+<p><label>Пароль<br>
+<input type="password" name="password" autocomplete="current-password" required{{if .Email}} autofocus{{end}}></label></p>
+{{end}}<p><button type="submit">Войти</button></p>
+</form>
+</body>
+</html>
+`))
+
+// renderLogin отображает форму входа.
+func renderLogin(w http.ResponseWriter, page loginPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+	if page.Error != "" {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	_ = loginTemplate.Execute(w, page)
+}
+
+// authorize обрабатывает запрос авторизации: отображает форму входа,
+// проверяет пароль и код второго фактора и перенаправляет пользователя на
+// адрес для возврата с кодом авторизации.
+//
+// Ошибки в идентификаторе клиента и адресе для возврата отображаются
+// пользователю, а остальные ошибки передаются приложению через адрес для
+// возврата.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	var (
+		ctx = r.Context()
+		req = parseAuthRequest(r.Form)
+	)
+	client, err := s.db.Client(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, db.ErrUnknownClient) {
+			http.Error(w, "unknown client", http.StatusBadRequest)
+		} else {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}
+		return
+	}
+	if !client.HasRedirectURI(req.RedirectURI) {
+		http.Error(w, "redirect uri is not allowed", http.StatusBadRequest)
+		return
+	}
+	if code := req.validate(client); code != "" {
+		s.redirect(w, r, req, url.Values{"error": {code}})
+		return
+	}
+	var page = loginPage{
+		Client: client.Name,
+		Params: req.Params(),
+		Email:  req.LoginHint,
+	}
+	if r.Method != http.MethodPost {
+		renderLogin(w, page)
+		return
+	}
+	// проверяем пароль или код второго фактора
+	var user *db.UserInfo
+	if challenge := r.PostForm.Get("challenge"); challenge != "" {
+		user, page.Challenge, page.Error = s.secondFactor(ctx, challenge,
+			r.PostForm.Get("code"))
+	} else {
+		page.Email = r.PostForm.Get("email")
+		user, page.Challenge, page.Error = s.login(ctx, r,
+			page.Email, r.PostForm.Get("password"))
+	}
+	if page.Error != "" || page.Challenge != "" {
+		renderLogin(w, page)
+		return
+	}
+	if user == nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	// выдаем код авторизации
+	code, err := s.db.AuthCodeCreate(ctx, db.AuthCode{
+		ClientID:    client.ID,
+		UID:         user.UID,
+		RedirectURI: req.RedirectURI,
+		Scope:       req.Scope,
+		Nonce:       req.Nonce,
+		Challenge:   req.Challenge,
+	})
+	if err != nil {
+		s.redirect(w, r, req, url.Values{"error": {"server_error"}})
+		return
+	}
+	s.redirect(w, r, req, url.Values{"code": {code}})
+}
+
+// login проверяет email и пароль пользователя с учетом ограничения
+// неудачных попыток авторизации. Если у пользователя включена
+// двухфакторная авторизация, то вместо информации о пользователе
+// возвращается токен запроса второго фактора. В случае ошибки возвращается
+// сообщение для пользователя; причина ошибки при этом не раскрывается.
+func (s *Server) login(ctx context.Context, r *http.Request,
+	email, password string) (*db.UserInfo, string, string) {
+	const invalid = "Неверный email или пароль"
+	// проверяем, не заблокирована ли авторизация
+	ip, _ := s.clientInfo(r)
+	retry, err := s.db.LoginLocked(ctx, email, ip)
+	if err != nil {
+		return nil, "", invalid
+	}
+	if retry > 0 {
+		return nil, "", lockedMessage(retry)
+	}
+	user, err := s.db.Authorize(ctx, email, password)
+	if err != nil {
+		// подсчитываем неудачные попытки авторизации
+		if err == db.ErrInvalidPassword || err == db.ErrNotFound {
+			retry, lerr := s.db.LoginFailed(ctx, email, ip)
+			if lerr == nil && retry > 0 {
+				return nil, "", lockedMessage(retry)
+			}
+		}
+		return nil, "", invalid
+	}
+	// проверяем, требуется ли второй фактор авторизации: счетчик неудачных
+	// попыток в этом случае сбрасывается только после его проверки
+	challenge, err := s.db.ChallengeCreate(ctx, user.UID)
+	if err != nil {
+		return nil, "", invalid
+	}
+	if challenge != "" {
+		return nil, challenge, ""
+	}
+	// сбрасываем счетчик неудачных попыток (ошибку игнорируем)
+	_ = s.db.LoginSucceeded(ctx, email)
+	return user, "", ""
+}
+
+// secondFactor проверяет код второго фактора авторизации с учетом
+// ограничения неверных кодов для пользователя. Возвращает пользователя
+// или запрос для повторного ввода кода и сообщение об ошибке.
+func (s *Server) secondFactor(ctx context.Context,
+	challenge, code string) (*db.UserInfo, string, string) {
+	const expired = "Время ввода кода истекло. Войдите заново"
+	uid, err := s.db.ChallengeUID(ctx, challenge)
+	if err != nil {
+		return nil, "", expired
+	}
+	retry, err := s.db.TOTPLocked(ctx, uid)
+	if err != nil {
+		return nil, "", "Неверный email или пароль"
+	}
+	if retry > 0 {
+		return nil, "", lockedMessage(retry)
+	}
+	user, err := s.db.ChallengeVerify(ctx, challenge, code)
+	switch err {
+	case nil:
+	case db.ErrInvalidCode:
+		// подсчитываем неверные коды
+		retry, lerr := s.db.TOTPFailed(ctx, uid)
+		if lerr == nil && retry > 0 {
+			return nil, "", lockedMessage(retry)
+		}
+		return nil, challenge, "Неверный код"
+	case db.ErrBadToken, db.ErrTokenExpired:
+		return nil, "", expired
+	default:
+		return nil, "", "Неверный email или пароль"
+	}
+	// сбрасываем счетчик неудачных попыток (ошибку игнорируем)
+	_ = s.db.LoginSucceeded(ctx, user.Email)
+	return user, "", ""
+}
+
+// lockedMessage возвращает сообщение о временной блокировке авторизации.
+func lockedMessage(retry time.Duration) string {
+	return fmt.Sprintf("Слишком много неудачных попыток. Повторите через %s",
+		retry.Round(time.Second))
+}
+
+// redirect перенаправляет пользователя на адрес для возврата с указанными
+// параметрами и state из запроса авторизации.
+func (s *Server) redirect(w http.ResponseWriter, r *http.Request,
+	req authRequest, params url.Values) {
+	redirectURI, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+	var query = redirectURI.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	query.Set("iss", s.url) // RFC 9207
+	redirectURI.RawQuery = query.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
@@ -0,0 +1,102 @@
+// Package oidc реализует HTTP-сервер авторизации по протоколу OpenID Connect,
+// позволяющий сторонним приложениям авторизовать пользователей сервиса.
+//
+// Поддерживается авторизация по коду (authorization code flow) с PKCE,
+// выдача токенов идентификации, доступа и обновления и запрос профиля
+// пользователя. Приложения регистрируются в таблице oidc_clients.
+package oidc
+
+import (
+	"encoding/json"
+	"itube/users/internal/db"
+	"itube/users/pkg/session"
+	"itube/users/pkg/tools"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Server реализует HTTP-обработчик сервера авторизации OpenID Connect.
+//
+// Идентификатор сервиса у issuer должен быть URL, по которому доступен
+// сервер: относительно него формируются адреса всех сервисов.
+type Server struct {
+	db     *db.Adapter
+	issuer *session.Issuer // выдача токенов, идентификатор — URL сервера
+	url    string          // URL сервера без завершающего слеша
+	mux    *http.ServeMux
+	// доверенные прокси-серверы, от которых принимаются заголовки с адресом
+	// клиента; если не заданы, то адрес берется из подключения
+	Proxies tools.Proxies
+}
+
+// NewServer возвращает инициализированный сервер авторизации OpenID Connect.
+func NewServer(db *db.Adapter, issuer *session.Issuer) (*Server, error) {
+	var issuerURL = strings.TrimSuffix(issuer.String(), "/")
+	parsed, err := url.Parse(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+	var s = &Server{
+		db:     db,
+		issuer: issuer,
+		url:    issuerURL,
+		mux:    http.NewServeMux(),
+	}
+	var prefix = parsed.Path
+	s.mux.HandleFunc(prefix+"/.well-known/openid-configuration", s.discovery)
+	s.mux.HandleFunc(prefix+"/jwks", s.jwks)
+	s.mux.HandleFunc(prefix+"/authorize", s.authorize)
+	s.mux.HandleFunc(prefix+"/token", s.token)
+	s.mux.HandleFunc(prefix+"/userinfo", s.userinfo)
+	return s, nil
+}
+
+// ServeHTTP обрабатывает HTTP-запросы к серверу авторизации.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// discovery возвращает описание сервисов сервера авторизации.
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.url,
+		"authorization_endpoint":                s.url + "/authorize",
+		"token_endpoint":                        s.url + "/token",
+		"userinfo_endpoint":                     s.url + "/userinfo",
+		"jwks_uri":                              s.url + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"response_modes_supported":              []string{"query"},
+		"grant_types_supported":                 []string{"authorization_code", "refresh_token"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{s.issuer.Algorithm()},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported": append([]string{"iss", "sub", "aud", "exp",
+			"iat", "auth_time", "nonce", "email", "email_verified"}, profileClaims...),
+	})
+}
+
+// jwks возвращает открытые ключи для проверки подписи токенов.
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.issuer.PublicKeys())
+}
+
+// clientInfo возвращает IP-адрес и информацию о приложении клиента.
+//
+// IP-адрес берется из адреса подключения, а если запрос пришел через
+// доверенный прокси-сервер, — из заголовков X-Forwarded-For или X-Real-IP.
+func (s *Server) clientInfo(r *http.Request) (ip, userAgent string) {
+	ip = s.Proxies.ClientIP(r.RemoteAddr, r.Header.Values("X-Forwarded-For"),
+		r.Header.Get("X-Real-IP"))
+	return ip, r.UserAgent()
+}
+
+// writeJSON отправляет ответ в формате JSON.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,210 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"itube/users/internal/db"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// profileClaims задает список данных профиля пользователя, которые
+// передаются из его расширенных свойств для области доступа profile.
+var profileClaims = []string{"name", "family_name", "given_name",
+	"middle_name", "nickname", "preferred_username", "profile", "picture",
+	"website", "gender", "birthdate", "zoneinfo", "locale"}
+
+// tokenError отправляет ошибку запроса токена в формате OAuth2.
+func tokenError(w http.ResponseWriter, status int, code string) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="token"`)
+	}
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+// clientCredentials возвращает идентификатор и секретный ключ клиента из
+// заголовка авторизации (client_secret_basic) или параметров запроса
+// (client_secret_post и none).
+func clientCredentials(r *http.Request) (id, secret string) {
+	if id, secret, ok := r.BasicAuth(); ok {
+		// по RFC 6749 значения в заголовке кодируются как в форме
+		if value, err := url.QueryUnescape(id); err == nil {
+			id = value
+		}
+		if value, err := url.QueryUnescape(secret); err == nil {
+			secret = value
+		}
+		return id, secret
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// verifyPKCE проверяет code_verifier для метода S256. Если code_challenge
+// не задан, то проверка не выполняется.
+func verifyPKCE(challenge, verifier string) bool {
+	if challenge == "" {
+		return true
+	}
+	var sum = sha256.Sum256([]byte(verifier))
+	var expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// token выдает токены по коду авторизации или токену обновления.
+//
+// При обмене кода авторизации создается сессия пользователя для домена
+// клиента, а ее токен обновления возвращается как refresh_token. Токен
+// идентификации выдается только при обмене кода авторизации.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		tokenError(w, http.StatusMethodNotAllowed, "invalid_request")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	var ctx = r.Context()
+	// проверяем клиента
+	id, secret := clientCredentials(r)
+	client, err := s.db.ClientAuthenticate(ctx, id, secret)
+	if err != nil {
+		if err == db.ErrUnknownClient {
+			tokenError(w, http.StatusUnauthorized, "invalid_client")
+		} else {
+			tokenError(w, http.StatusInternalServerError, "server_error")
+		}
+		return
+	}
+	ip, userAgent := s.clientInfo(r)
+	var (
+		user    *db.UserInfo
+		session *db.Session
+		code    *db.AuthCode
+	)
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code, err = s.db.AuthCodeTake(ctx, client.ID, r.PostForm.Get("code"))
+		if err != nil || code.RedirectURI != r.PostForm.Get("redirect_uri") ||
+			!verifyPKCE(code.Challenge, r.PostForm.Get("code_verifier")) {
+			tokenError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		user, err = s.db.GetUser(ctx, code.UID, "")
+		if err != nil {
+			tokenError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		session, err = s.db.SessionCreate(ctx, user.UID, client.Domain, "",
+			code.Scope, ip, userAgent)
+	case "refresh_token":
+		// токен сессии другого клиента не принимается и не заменяется
+		session, user, err = s.db.SessionRefresh(ctx,
+			r.PostForm.Get("refresh_token"), client.Domain, ip, userAgent)
+		if err == db.ErrBadToken || err == db.ErrBlocked || err == db.ErrNotFound {
+			tokenError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+	default:
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	// формируем токены
+	accessToken, expires, err := s.issuer.Token(session.ID, user.UID,
+		user.Email, client.ID, session.Scope)
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	var response = map[string]interface{}{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(time.Until(expires).Round(time.Second).Seconds()),
+		"refresh_token": session.Token,
+	}
+	if code != nil {
+		idToken, err := s.issuer.IDToken(user.UID, client.ID, code.Nonce,
+			code.Created, userClaims(user, code.Scope))
+		if err != nil {
+			tokenError(w, http.StatusInternalServerError, "server_error")
+			return
+		}
+		response["id_token"] = idToken
+		response["scope"] = code.Scope
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// userinfo возвращает профиль пользователя по токену доступа. Возвращаются
+// только данные для областей доступа, разрешенных клиенту и сохраненных в
+// токене. Токен принимается, только пока его сессия не завершена.
+func (s *Server) userinfo(w http.ResponseWriter, r *http.Request) {
+	var token string
+	if auth := r.Header.Get("Authorization"); len(auth) > 7 &&
+		strings.EqualFold(auth[:7], "bearer ") {
+		token = strings.TrimSpace(auth[7:])
+	} else if r.Method == http.MethodPost && r.ParseForm() == nil {
+		token = r.PostForm.Get("access_token")
+	}
+	if token == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="userinfo"`)
+		writeJSON(w, http.StatusUnauthorized,
+			map[string]string{"error": "invalid_request"})
+		return
+	}
+	claims, err := s.issuer.Verify(token, "")
+	if err == nil && claims.SessionID == "" {
+		err = db.ErrBadToken
+	}
+	// проверяем, что сессия не завершена
+	var session *db.Session
+	if err == nil {
+		session, err = s.db.SessionGet(r.Context(), claims.SessionID)
+	}
+	if err == nil && session.UID != claims.Subject {
+		err = db.ErrBadToken
+	}
+	var user *db.UserInfo
+	if err == nil {
+		user, err = s.db.GetUser(r.Context(), claims.Subject, "")
+	}
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		writeJSON(w, http.StatusUnauthorized,
+			map[string]string{"error": "invalid_token"})
+		return
+	}
+	var profile = userClaims(user, claims.Scope)
+	profile["sub"] = user.UID
+	writeJSON(w, http.StatusOK, profile)
+}
+
+// userClaims возвращает данные профиля пользователя для запрошенных
+// областей доступа. Данные для области profile берутся из расширенных
+// свойств пользователя.
+func userClaims(user *db.UserInfo, scope string) map[string]interface{} {
+	var claims = make(map[string]interface{})
+	if hasScope(scope, "email") {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.Verified
+	}
+	if hasScope(scope, "profile") && user.Properties != nil {
+		var properties map[string]interface{}
+		_ = json.Unmarshal([]byte(*user.Properties), &properties)
+		for _, name := range profileClaims {
+			if value, ok := properties[name]; ok && value != nil && value != "" {
+				claims[name] = value
+			}
+		}
+	}
+	return claims
+}
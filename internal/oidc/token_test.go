@@ -0,0 +1,104 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"itube/users/internal/db"
+	"itube/users/pkg/session"
+	"itube/users/pkg/tools"
+)
+
+// testDB возвращает подключение к тестовой базе данных с примененными
+// миграциями, заданной в переменной окружения ITUBE_USERS_TEST_DSN. Если
+// она не задана, то тест пропускается.
+func testDB(t *testing.T) *db.Adapter {
+	t.Helper()
+	var dsn = os.Getenv("ITUBE_USERS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("ITUBE_USERS_TEST_DSN is not set")
+	}
+	pool, err := tools.Connect(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return &db.Adapter{Pool: pool}
+}
+
+func TestUserinfo(t *testing.T) {
+	var adapter = testDB(t)
+	key, err := session.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := session.NewIssuer("https://id.example.com", 0, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := NewServer(adapter, issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		ctx   = context.Background()
+		email = fmt.Sprintf("userinfo-%d@example.com", time.Now().UnixNano())
+	)
+	user, err := adapter.Register(ctx, email, "Str0ng-Passw0rd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// userinfo возвращает статус и профиль пользователя для сессии с
+	// указанными областями доступа
+	var userinfo = func(sess *db.Session) (int, map[string]interface{}) {
+		t.Helper()
+		token, _, err := issuer.Token(sess.ID, user.UID, user.Email, "app",
+			sess.Scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r = httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		var w = httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		var profile map[string]interface{}
+		_ = json.NewDecoder(w.Body).Decode(&profile)
+		return w.Code, profile
+	}
+	for _, test := range []struct {
+		scope string
+		email bool
+	}{
+		{"openid", false},
+		{"openid email", true},
+	} {
+		sess, err := adapter.SessionCreate(ctx, user.UID, "app.example.com", "",
+			test.scope, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		code, profile := userinfo(sess)
+		if code != http.StatusOK {
+			t.Fatalf("%s: unexpected status: %d", test.scope, code)
+		}
+		if profile["sub"] != user.UID {
+			t.Errorf("%s: unexpected subject: %v", test.scope, profile["sub"])
+		}
+		if _, ok := profile["email"]; ok != test.email {
+			t.Errorf("%s: unexpected email: %v", test.scope, profile["email"])
+		}
+		// токен завершенной сессии не принимается
+		if err = adapter.SessionRevoke(ctx, user.UID, sess.ID); err != nil {
+			t.Fatal(err)
+		}
+		if code, _ = userinfo(sess); code != http.StatusUnauthorized {
+			t.Errorf("%s: revoked session status: %d", test.scope, code)
+		}
+	}
+}
@@ -19,61 +19,202 @@ var _ api.IdentityServer = new(Identity)
 
 // Identity реализует grpc-сервис для авторизации и регистрации пользователей.
 type Identity struct {
-	db *db.Adapter
+	db       *db.Adapter
+	sessions *Sessions // создание сессий авторизованных пользователей
+	// домены, для которых ответы на регистрацию и авторизацию не позволяют
+	// определить, зарегистрирован ли пользователь
+	Private Domains
+	// политики паролей; если не заданы, то пароли не проверяются
+	Passwords *Passwords
 }
 
 // NewIdentity инициализирует и возвращает серверный обработчик grpc для авторизации
-// пользователей.
-func NewIdentity(db *db.Adapter) *Identity {
-	return &Identity{db: db}
+// пользователей. Если sessions не задан, то сессии при авторизации не
+// создаются.
+func NewIdentity(db *db.Adapter, sessions *Sessions) *Identity {
+	return &Identity{db: db, sessions: sessions}
 }
 
 // Register регистрирует и возвращает информацию о пользователе.
 //
-// Если пользователь уже зарегистрирован, но пароль для него не установлен, то
-// устанавливает новый пароль. Данный случай возникает, если до этого
-// пользователь был зарегистрирован через внешнего провайдера.
+// Если пользователь уже зарегистрирован через внешнего провайдера и пароль
+// для него не установлен, то пароль не устанавливается: адрес мог быть не
+// подтвержден провайдером. Вместо этого владельцу адреса отправляется письмо
+// для сброса пароля, и пароль задается через Tokens.ResetPassword. Если до
+// этого адрес не был подтвержден, то внешние провайдеры от пользователя
+// отвязываются.
+//
+// Для доменов из списка Private ответ не зависит от того, был ли пользователь
+// зарегистрирован ранее: возвращаются только домен и email. Новому
+// пользователю отправляется письмо для подтверждения почтового адреса, а уже
+// зарегистрированному с паролем — письмо о попытке повторной регистрации.
+//
+// Пароль проверяется на соответствие политике паролей домена. При нарушении
+// возвращается ошибка InvalidArgument, в деталях которой передаются
+// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
 //
 // Возвращает ошибки:
 //  - AlreadyExists - пользователь уже зарегистрирован и у него задан пароль
+//  - FailedPrecondition - пользователь зарегистрирован без пароля: пароль
+//     задается после подтверждения адреса через сброс пароля
 //  - NotFound - пользователь заблокирован
-//  - InvalidArgument - неверный формат данных входящего запроса
+//  - InvalidArgument - неверный формат данных или пароль не соответствует политике
 //  - Internal - внутренние ошибки
 func (s *Identity) Register(ctx context.Context, req *api.Login) (*api.User, error) {
-	user, err := s.db.Register(ctx, req.Email, req.Password)
+	// проверяем пароль на соответствие политике домена
+	err := s.Passwords.check(req.Domain, req.Email, req.Password)
 	if err != nil {
 		return nil, statusError(err)
 	}
+	var private = s.Private.Has(req.Domain)
+	user, err := s.db.Register(ctx, req.Email, req.Password)
+	if err == db.ErrNoPassword {
+		// пароль задается только после подтверждения почтового адреса:
+		// отправляем владельцу адреса письмо для сброса пароля
+		_, tokenErr := s.db.TokenGenerate(ctx, req.Domain, req.Email,
+			int32(api.PASSWORD))
+		if tokenErr != nil {
+			return nil, statusError(tokenErr)
+		}
+	}
+	if err != nil {
+		if !private {
+			return nil, statusError(err)
+		}
+		switch err {
+		case db.ErrAlreadyRegisterd:
+			// уведомляем владельца почтового адреса о попытке регистрации
+			_, err = s.db.TokenGenerate(ctx, req.Domain, req.Email,
+				int32(api.REGISTERED))
+			if err != nil {
+				return nil, statusError(err)
+			}
+		case db.ErrNoPassword: // письмо для сброса пароля уже отправлено
+		case db.ErrBlocked: // заблокированному пользователю ничего не отправляем
+		default:
+			return nil, statusError(err)
+		}
+		return &api.User{Domain: req.Domain, Email: req.Email}, nil
+	}
 	// добавляем в журнал запись о регистрации (возможную ошибку игнорируем)
 	_ = s.db.RegInfo(ctx, req.Domain, user.UID, user.Email, "",
 		req.RegInfo.Referer, utm(req.RegInfo.UTM))
+	if private {
+		// отправляем письмо для подтверждения почтового адреса
+		_, err = s.db.TokenGenerate(ctx, req.Domain, user.Email,
+			int32(api.EMAIL))
+		if err != nil {
+			return nil, statusError(err)
+		}
+		return &api.User{Domain: req.Domain, Email: req.Email}, nil
+	}
 	return apiUser(req.Domain, user) // возвращаем информацию о пользователе
 }
 
 // Authorize авторизует пользователя по логину (email) и паролю. Возвращает
 // информацию о пользователе в случае успешной авторизации. В противном случае
-// возвращает ошибку.
+// возвращает ошибку. Вместе с информацией о пользователе возвращаются токены
+// созданной сессии.
+//
+// Если у пользователя включена двухфакторная авторизация, то возвращается
+// ошибка FailedPrecondition, в деталях которой передается Challenge с токеном
+// для завершения авторизации через TwoFactor.Authorize.
+//
+// Неудачные попытки авторизации подсчитываются для почтового адреса и
+// IP-адреса клиента. После превышения ограничения авторизация временно
+// блокируется и возвращается ошибка ResourceExhausted, в деталях которой
+// передается RetryInfo со временем до окончания блокировки.
+//
+// Для доменов из списка Private вместо NotFound и InvalidArgument
+// возвращается единая ошибка Unauthenticated.
 //
 // Возвращает ошибки:
+//  - Unauthenticated - неверный email или пароль (для доменов из Private)
 //  - NotFound - пользователь не зарегистрирован или блокирован
+//  - FailedPrecondition - требуется второй фактор авторизации
 //  - InvalidArgument - неверный пароль пользователя
+//  - ResourceExhausted - слишком много неудачных попыток авторизации
 //  - Internal - внутренние ошибки
 func (s *Identity) Authorize(ctx context.Context, req *api.Login) (*api.User, error) {
-	user, err := s.db.Authorize(ctx, req.Email, req.Password)
+	// проверяем, не заблокирована ли авторизация
+	ip, _ := clientInfo(ctx)
+	retry, err := s.db.LoginLocked(ctx, req.Email, ip)
 	if err != nil {
 		return nil, statusError(err)
 	}
-	return apiUser(req.Domain, user) // возвращаем информацию о пользователе
+	if retry > 0 {
+		return nil, lockedError(retry)
+	}
+	userInfo, err := s.db.Authorize(ctx, req.Email, req.Password)
+	if err != nil {
+		// подсчитываем неудачные попытки авторизации
+		if err == db.ErrInvalidPassword || err == db.ErrNotFound {
+			retry, lerr := s.db.LoginFailed(ctx, req.Email, ip)
+			if lerr != nil {
+				return nil, statusError(lerr)
+			}
+			if retry > 0 {
+				return nil, lockedError(retry)
+			}
+		}
+		// не раскрываем причину ошибки для закрытых доменов
+		if s.Private.Has(req.Domain) && (err == db.ErrInvalidPassword ||
+			err == db.ErrNotFound || err == db.ErrBlocked) {
+			return nil, status.Error(codes.Unauthenticated,
+				"invalid email or password")
+		}
+		return nil, statusError(err)
+	}
+	// проверяем, требуется ли второй фактор авторизации: счетчик неудачных
+	// попыток в этом случае сбрасывается только после его проверки
+	challenge, err := s.db.ChallengeCreate(ctx, userInfo.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if challenge != "" {
+		return nil, challengeError(req.Domain, challenge)
+	}
+	// сбрасываем счетчик неудачных попыток (ошибку игнорируем)
+	_ = s.db.LoginSucceeded(ctx, req.Email)
+	user, err := apiUser(req.Domain, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	// создаем сессию авторизованного пользователя
+	if err = s.sessions.issue(ctx, user, ""); err != nil {
+		return nil, err
+	}
+	return user, nil // возвращаем информацию о пользователе
 }
 
 // SetPassword заменяет пароль пользователя. Возвращает ошибку, если
-// пользователь не зарегистрирован.
+// пользователь не зарегистрирован. Все сессии пользователя при этом
+// завершаются.
+//
+// Пароль проверяется на соответствие политике паролей домена. При нарушении
+// возвращается ошибка InvalidArgument, в деталях которой передаются
+// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
+// Новый пароль так же не должен совпадать с последними паролями
+// пользователя, если это задано политикой домена.
 //
 // Возвращает ошибки:
 //  - NotFound - пользователь не зарегистрирован
+//  - InvalidArgument - пароль не соответствует политике
 //  - Internal - внутренние ошибки
 func (s *Identity) SetPassword(ctx context.Context, req *api.Password) (*types.Empty, error) {
-	err := s.db.SetPassword(ctx, req.UID, req.Password)
+	// проверяем пароль на соответствие политике домена
+	if s.Passwords != nil {
+		user, err := s.db.GetUser(ctx, req.UID, "")
+		if err != nil {
+			return nil, statusError(err)
+		}
+		err = s.Passwords.check(req.Domain, user.Email, req.Password)
+		if err != nil {
+			return nil, statusError(err)
+		}
+	}
+	err := s.db.SetPassword(ctx, req.UID, req.Password,
+		s.Passwords.history(req.Domain))
 	if err != nil {
 		return nil, statusError(err)
 	}
@@ -109,7 +250,8 @@ func (s *Identity) Update(ctx context.Context, req *api.User) (*types.Empty, err
 
 // Block используется для блокировки/разблокировки пользователя.
 // Заблокированный пользователь продолжает оставаться зарегистрированных,
-// но не может авторизоваться.
+// но не может авторизоваться. При блокировке все сессии пользователя
+// завершаются.
 //
 // Возвращает ошибки:
 //  - NotFound - пользователь не зарегистрирован
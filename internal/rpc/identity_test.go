@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"itube/users/internal/db"
+	"itube/users/pkg/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterPasswordless(t *testing.T) {
+	var (
+		adapter  = testDB(t)
+		identity = NewIdentity(adapter, nil)
+		tokens   = NewTokens(adapter, nil)
+		ctx      = context.Background()
+		email    = fmt.Sprintf("passwordless-%d@example.com", time.Now().UnixNano())
+		subject  = fmt.Sprintf("subject-%d", time.Now().UnixNano())
+		login    = &api.Login{
+			Domain:   "example.com",
+			Email:    email,
+			Password: "Str0ng-Passw0rd",
+		}
+	)
+	// учетная запись создана через провайдера, не подтвердившего адрес
+	registered, err := adapter.OpenIDRegister(ctx, "dev", subject, email, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// регистрация с паролем не задает пароль такой учетной записи
+	_, err = identity.Register(ctx, login)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unexpected register error: %v", err)
+	}
+	if _, err = adapter.Authorize(ctx, email, login.Password); err == nil {
+		t.Fatal("password set without email verification")
+	}
+	// пароль задается после подтверждения адреса через сброс пароля
+	token, err := adapter.TokenGenerate(ctx, login.Domain, email,
+		int32(api.PASSWORD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := tokens.ResetPassword(ctx, &api.PasswordReset{
+		Domain:   login.Domain,
+		Token:    token,
+		Password: login.Password,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UID != registered.UID || !user.Verified {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	// провайдер с неподтвержденным адресом отвязан
+	_, err = adapter.OpenIDAuthorize(ctx, "dev", subject, "", "")
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("unexpected openid authorize error: %v", err)
+	}
+	if _, err = adapter.Authorize(ctx, email, login.Password); err != nil {
+		t.Errorf("authorize error: %v", err)
+	}
+	// теперь пользователь зарегистрирован с паролем
+	_, err = identity.Register(ctx, login)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("unexpected second register error: %v", err)
+	}
+}
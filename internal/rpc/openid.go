@@ -2,11 +2,18 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"itube/users/internal/db"
 	"itube/users/pkg/api"
+	"itube/users/pkg/keyring"
 	"itube/users/pkg/openid"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/oauth2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -18,17 +25,56 @@ var _ api.OpenIDServer = new(OpenID)
 // с помощью внешних провайдеров авторизации по протоколу OpenID Connect.
 type OpenID struct {
 	db        *db.Adapter
-	providers map[string]*openid.Provider // провайдеры авторизации
+	sessions  *Sessions                       // создание сессий авторизованных пользователей
+	providers map[string]openid.Authenticator // провайдеры авторизации
+	// допустимые адреса для возврата после авторизации для доменов; если
+	// для домена список не задан, то проверяется только список провайдера
+	RedirectURIs map[string]openid.RedirectURIs
+	// ключи шифрования токенов провайдеров; если не заданы, то токены не
+	// сохраняются
+	Keyring *keyring.Keyring
+	// домены, для которых ответы на авторизацию не позволяют определить,
+	// зарегистрирован ли пользователь с email, не подтвержденным провайдером
+	Private Domains
 }
 
-// NewOpenID возвращает инициализированный сервис для авторизации.
-func NewOpenID(db *db.Adapter, providers ...*openid.Provider) *OpenID {
-	var list = make(map[string]*openid.Provider, len(providers))
+// errRedirectURI возвращается, если адрес для возврата после авторизации не
+// входит в список допустимых для домена или провайдера.
+var errRedirectURI = status.Error(codes.InvalidArgument,
+	openid.ErrRedirectURI.Error())
+
+// errConfirmEmail возвращается для доменов из списка Private, если email не
+// подтвержден провайдером: продолжить можно только по ссылке из письма.
+var errConfirmEmail = status.Error(codes.FailedPrecondition,
+	"email confirmation required")
+
+// errLinkUser возвращается, если привязка провайдера через Link завершается
+// не тем пользователем, который ее начал.
+var errLinkUser = status.Error(codes.PermissionDenied,
+	"identity link was started by another user")
+
+// redirectAllowed возвращает true, если адрес для возврата после авторизации
+// допустим для домена.
+func (s *OpenID) redirectAllowed(domain, redirectURI string) bool {
+	return s.RedirectURIs[strings.ToLower(domain)].Match(redirectURI)
+}
+
+// loginState описывает данные, сохраняемые в состоянии авторизации.
+type loginState struct {
+	api.RegInfo        // информация об источнике регистрации
+	LinkUID     string `json:"link_uid,omitempty"` // пользователь для привязки провайдера
+}
+
+// NewOpenID возвращает инициализированный сервис для авторизации. Если
+// sessions не задан, то сессии при авторизации не создаются.
+func NewOpenID(db *db.Adapter, sessions *Sessions, providers ...openid.Authenticator) *OpenID {
+	var list = make(map[string]openid.Authenticator, len(providers))
 	for _, provider := range providers {
 		list[provider.String()] = provider
 	}
 	return &OpenID{
 		db:        db,
+		sessions:  sessions,
 		providers: list,
 	}
 }
@@ -36,7 +82,9 @@ func NewOpenID(db *db.Adapter, providers ...*openid.Provider) *OpenID {
 // Login выдает URL для перехода на авторизацию к провайдеру.
 //
 // Возвращает InvalidArgument, если указан неподдерживаемый идентификатор
-// провайдера авторизации.
+// провайдера авторизации, адрес для возврата не входит в список
+// допустимых для домена или провайдера или дополнительные параметры
+// переопределяют формируемые провайдером (redirect_uri, state и т.д.).
 func (s *OpenID) Login(ctx context.Context, req *api.Provider) (*api.LoginURL, error) {
 	// получаем провайдера, ответственного за авторизацию
 	provider, ok := s.providers[req.Provider]
@@ -44,18 +92,71 @@ func (s *OpenID) Login(ctx context.Context, req *api.Provider) (*api.LoginURL, e
 		return nil, status.Errorf(codes.InvalidArgument,
 			"unsupported provider: %s", req.Provider)
 	}
+	// проверяем, что адрес для возврата разрешен для домена
+	if !s.redirectAllowed(req.Domain, req.RedirectURI) {
+		return nil, errRedirectURI
+	}
 	// формируем url для перехода на авторизацию
-	var loginURL = provider.LoginURL(req.RedirectURI, req.Params, req.RegInfo)
+	loginURL, err := provider.LoginURL(ctx, req.RedirectURI, req.Params,
+		loginState{RegInfo: req.RegInfo})
+	if errors.Is(err, openid.ErrRedirectURI) {
+		return nil, errRedirectURI
+	}
+	if errors.Is(err, openid.ErrReservedParam) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal,
+			"openid login error: %s", err)
+	}
 	return &api.LoginURL{Domain: req.Domain, URL: loginURL}, nil
 }
 
 // Authorize проверяет авторизацию и возвращает информацию об
 // авторизованном пользователе. Если пользователь не зарегистрирован,
-// то происходит его автоматическая регистрация.
+// то происходит его автоматическая регистрация. Вместе с информацией о
+// пользователе возвращаются токены созданной сессии.
+//
+// Адрес для возврата, использованный при начале авторизации, повторно
+// проверяется по спискам допустимых адресов домена и провайдера.
+//
+// При каждой авторизации запрашивается актуальный профиль пользователя,
+// который сохраняется для привязки к провайдеру, а выбранные в настройках
+// провайдера данные профиля переносятся в свойства пользователя.
+//
+// Данные из user, которые провайдер передает только при первой
+// авторизации, дополняют профиль и всегда переносятся в свойства
+// пользователя, т.к. повторно они не передаются.
+//
+// Если авторизация была начата через Link, то провайдер привязывается к
+// указанному в ней пользователю. Идентификатор авторизованного пользователя
+// при этом должен быть передан в uid и совпадать с указанным в Link: иначе
+// чужую ссылку на привязку можно было бы подсунуть жертве и привязать ее
+// учетную запись провайдера к своему пользователю.
+//
+// Если провайдер не подтвердил email-адрес, а пользователь с таким адресом
+// уже зарегистрирован, то автоматическая привязка не выполняется и
+// возвращается ошибка FailedPrecondition, в деталях которой передается
+// LinkRequired с токеном запроса на привязку. Владельцу адреса при этом
+// отправляется письмо LINK с токеном для подтверждения. Привязка
+// завершается через ConfirmLink: паролем с токеном запроса или токеном из
+// письма.
+//
+// Для доменов из списка Private ошибка FailedPrecondition в этом случае
+// возвращается без деталей. Так же без деталей она возвращается и при
+// регистрации нового пользователя с неподтвержденным адресом, которому
+// отправляется письмо EMAIL для подтверждения адреса: так по ответу нельзя
+// определить, зарегистрирован ли адрес.
 //
 // Возвращает ошибки:
+//  - AlreadyExists - пользователь провайдера привязан к другому
+//     пользователю (при привязке через Link)
+//  - PermissionDenied - привязка через Link начата другим пользователем
 //  - NotFound - пользователь заблокирован
-//  - InvalidArgument - неверный формат данных входящего запроса
+//  - FailedPrecondition - требуется подтверждение привязки или второй
+//     фактор авторизации
+//  - InvalidArgument - неверный формат данных входящего запроса или
+//     недопустимый адрес для возврата
 //  - Internal - внутренние ошибки
 func (s *OpenID) Authorize(ctx context.Context, req *api.AuthCode) (*api.User, error) {
 	// получаем провайдера, ответственного за авторизацию
@@ -66,14 +167,56 @@ func (s *OpenID) Authorize(ctx context.Context, req *api.AuthCode) (*api.User, e
 	}
 	// запрашиваем информацию о пользователе у системы авторизации
 	userinfo, data, err := provider.UserInfo(ctx, req.State, req.Code)
+	if errors.Is(err, openid.ErrRedirectURI) {
+		return nil, errRedirectURI
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"openid authorization error: %s", err)
 	}
-	// запрашиваем из базы данные о пользователе
-	user, err := s.db.OpenIDAuthorize(ctx, provider.String(), userinfo.Subject)
+	if !s.redirectAllowed(req.Domain, userinfo.RedirectURI) {
+		return nil, errRedirectURI
+	}
+	// разбираем данные, сохраненные при начале авторизации (ошибку
+	// игнорируем, т.к. данные записаны самим сервисом)
+	var loginData loginState
+	_ = json.Unmarshal(data, &loginData)
+	// привязываем провайдера к пользователю, если авторизация начата через Link
+	if loginData.LinkUID != "" {
+		// привязку завершает только тот пользователь, который ее начал
+		if req.UID != loginData.LinkUID {
+			return nil, errLinkUser
+		}
+		user, err := s.db.OpenIDLink(ctx, loginData.LinkUID, provider.String(),
+			userinfo.Subject)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		if err = s.saveToken(ctx, provider, userinfo); err != nil {
+			return nil, err
+		}
+		return apiUser(req.Domain, user)
+	}
+	// запрашиваем актуальный профиль пользователя (при ошибке используем
+	// данные, полученные при авторизации, но профиль другого пользователя
+	// означает ошибку провайдера или подмену)
+	if err = userinfo.Update(ctx); errors.Is(err, openid.ErrSubjectMismatch) {
+		return nil, status.Errorf(codes.Internal,
+			"openid authorization error: %s", err)
+	}
+	// дополняем профиль данными первой авторизации: они переносятся в
+	// свойства пользователя всегда, т.к. повторно не передаются
+	var propagate = append([]string(nil), provider.Propagate()...)
+	propagate = append(propagate, provider.FirstLogin(userinfo, []byte(req.User))...)
+	// запрашиваем из базы данные о пользователе, сохраняя последние данные
+	// профиля и переносимые в свойства пользователя
+	user, err := s.db.OpenIDAuthorize(ctx, provider.String(), userinfo.Subject,
+		string(userinfo.JSON()), string(userinfo.Select(propagate)))
 	if err == nil {
-		return apiUser(req.Domain, user) // возвращаем информацию о пользователе
+		if err = s.saveToken(ctx, provider, userinfo); err != nil {
+			return nil, err
+		}
+		return s.authorized(ctx, req.Domain, provider.String(), user)
 	}
 	// произошла ошибка
 	if !errors.Is(err, db.ErrNotFound) {
@@ -82,12 +225,374 @@ func (s *OpenID) Authorize(ctx context.Context, req *api.AuthCode) (*api.User, e
 	// пользователь не зарегистрирован - регистрируем
 	user, err = s.db.OpenIDRegister(ctx, provider.String(), userinfo.Subject,
 		userinfo.Email, userinfo.Verified, string(userinfo.JSON()))
+	if errors.Is(err, db.ErrLinkRequired) {
+		// адрес не подтвержден провайдером: привязку должен подтвердить
+		// владелец уже зарегистрированной учетной записи
+		return nil, s.linkRequired(ctx, req.Domain, provider.String(), userinfo)
+	}
 	if err != nil {
 		return nil, statusError(err)
 	}
 	// добавляем в журнал запись о регистрации (возможную ошибку игнорируем)
-	var reginfo, _ = data.(api.RegInfo)
 	_ = s.db.RegInfo(ctx, req.Domain, user.UID, user.Email, provider.String(),
-		reginfo.Referer, utm(reginfo.UTM))
-	return apiUser(req.Domain, user) // возвращаем информацию о пользователе
+		loginData.Referer, utm(loginData.UTM))
+	if err = s.saveToken(ctx, provider, userinfo); err != nil {
+		return nil, err
+	}
+	// для закрытых доменов ответ не должен отличаться от случая, когда
+	// адрес уже зарегистрирован: новый пользователь тоже продолжает по
+	// ссылке из письма для подтверждения адреса
+	if !userinfo.Verified && s.Private.Has(req.Domain) {
+		_, err = s.db.TokenGenerate(ctx, req.Domain, user.Email, int32(api.EMAIL))
+		if err != nil {
+			return nil, statusError(err)
+		}
+		return nil, errConfirmEmail
+	}
+	return s.authorized(ctx, req.Domain, provider.String(), user)
+}
+
+// ListProviders возвращает список поддерживаемых провайдеров авторизации,
+// упорядоченный по названию. Используется для отображения кнопок входа.
+func (s *OpenID) ListProviders(ctx context.Context, req *api.ProvidersRequest) (*api.ProviderList, error) {
+	var list = &api.ProviderList{
+		Domain:    req.Domain,
+		Providers: make([]*api.ProviderInfo, 0, len(s.providers)),
+	}
+	for _, provider := range s.providers {
+		list.Providers = append(list.Providers, &api.ProviderInfo{
+			Name:  provider.String(),
+			Title: provider.Title(),
+		})
+	}
+	sort.Slice(list.Providers, func(i, j int) bool {
+		return list.Providers[i].Name < list.Providers[j].Name
+	})
+	return list, nil
+}
+
+// ConfirmLink подтверждает привязку внешнего провайдера к существующей
+// учетной записи паролем пользователя или токеном из письма LINK и
+// возвращает информацию о пользователе вместе с токенами созданной сессии.
+// Пароль проверяется вместе с токеном запроса из LinkRequired, а токен из
+// письма сам указывает на запрос и подтверждает email-адрес.
+//
+// Если у пользователя включена двухфакторная авторизация, то после
+// привязки возвращается ошибка FailedPrecondition с Challenge для
+// завершения авторизации через TwoFactor.Authorize.
+//
+// Возвращает ошибки:
+//  - NotFound - пользователь заблокирован
+//  - FailedPrecondition - время жизни запроса истекло или требуется
+//     второй фактор авторизации
+//  - InvalidArgument - неверный токен, пароль или формат данных
+//  - ResourceExhausted - слишком много неудачных попыток авторизации
+//  - Internal - внутренние ошибки
+func (s *OpenID) ConfirmLink(ctx context.Context, req *api.LinkConfirm) (*api.User, error) {
+	var (
+		user     *db.UserInfo
+		provider string
+		err      error
+	)
+	switch {
+	case req.Token != "":
+		// токен из письма сам указывает на запрос и подтверждает владельца
+		user, provider, err = s.db.LinkConfirmToken(ctx, req.Token,
+			int32(api.LINK))
+		if err != nil {
+			return nil, statusError(err)
+		}
+	case req.Password != "" && req.Link != "":
+		// получаем пользователя, к которому относится запрос
+		user, provider, err = s.db.LinkPending(ctx, req.Link)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		// проверяем, что привязку подтвердил владелец учетной записи
+		if err = s.checkPassword(ctx, user.Email, req.Password); err != nil {
+			return nil, err
+		}
+		// привязываем провайдера
+		user, err = s.db.LinkConfirm(ctx, req.Link, user.UID)
+		if err != nil {
+			return nil, statusError(err)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument,
+			"token or link with password required")
+	}
+	// проверяем, требуется ли второй фактор авторизации
+	challenge, err := s.db.ChallengeCreate(ctx, user.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if challenge != "" {
+		return nil, challengeError(req.Domain, challenge)
+	}
+	return s.authorized(ctx, req.Domain, provider, user)
+}
+
+// checkPassword проверяет пароль пользователя с учетом ограничения на
+// количество неудачных попыток авторизации.
+func (s *OpenID) checkPassword(ctx context.Context, email, password string) error {
+	ip, _ := clientInfo(ctx)
+	retry, err := s.db.LoginLocked(ctx, email, ip)
+	if err != nil {
+		return statusError(err)
+	}
+	if retry > 0 {
+		return lockedError(retry)
+	}
+	_, err = s.db.Authorize(ctx, email, password)
+	if err != nil {
+		// подсчитываем неудачные попытки авторизации
+		if err == db.ErrInvalidPassword || err == db.ErrNotFound {
+			retry, lerr := s.db.LoginFailed(ctx, email, ip)
+			if lerr != nil {
+				return statusError(lerr)
+			}
+			if retry > 0 {
+				return lockedError(retry)
+			}
+		}
+		return statusError(err)
+	}
+	// сбрасываем счетчик неудачных попыток (ошибку игнорируем)
+	_ = s.db.LoginSucceeded(ctx, email)
+	return nil
+}
+
+// linkRequired создает запрос на привязку провайдера к существующей учетной
+// записи, отправляет ее владельцу письмо для подтверждения и возвращает
+// ошибку с информацией о запросе в деталях. Для доменов из списка Private
+// возвращается ошибка errConfirmEmail без деталей.
+func (s *OpenID) linkRequired(ctx context.Context, domain, provider string,
+	userinfo *openid.UserInfo) error {
+	// вместе с запросом создается токен для письма владельцу адреса
+	link, err := s.db.LinkRequest(ctx, domain, provider, userinfo.Subject,
+		userinfo.Email, int32(api.LINK))
+	if err != nil {
+		return statusError(err)
+	}
+	// не раскрываем, что адрес зарегистрирован
+	if s.Private.Has(domain) {
+		return errConfirmEmail
+	}
+	var expires = time.Now().Add(db.LinkTTL)
+	st, err := status.New(codes.FailedPrecondition, db.ErrLinkRequired.Error()).
+		WithDetails(&api.LinkRequired{
+			Domain:   domain,
+			Link:     link,
+			Provider: provider,
+			Email:    userinfo.Email,
+			Expires:  &expires,
+		})
+	if err != nil {
+		return status.Errorf(codes.Internal, "link required error: %s", err)
+	}
+	return st.Err()
+}
+
+// Identities возвращает список внешних провайдеров авторизации,
+// привязанных к пользователю.
+//
+// Возвращает ошибки:
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *OpenID) Identities(ctx context.Context, req *api.IdentitiesRequest) (*api.IdentityList, error) {
+	identities, err := s.db.Identities(ctx, req.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	var list = &api.IdentityList{
+		Domain:     req.Domain,
+		UID:        req.UID,
+		Identities: make([]*api.LinkedIdentity, 0, len(identities)),
+	}
+	for _, identity := range identities {
+		list.Identities = append(list.Identities, &api.LinkedIdentity{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Created:  identity.Created,
+		})
+	}
+	return list, nil
+}
+
+// Link выдает URL для перехода на авторизацию к провайдеру, чтобы привязать
+// его к уже авторизованному пользователю. Идентификатор пользователя
+// сохраняется в состоянии авторизации, и после возврата от провайдера
+// Authorize вместо авторизации привязывает провайдера к этому пользователю
+// и возвращает информацию о нем без создания новой сессии. Для завершения
+// привязки в Authorize нужно передать uid того же авторизованного
+// пользователя.
+//
+// Возвращает ошибки:
+//  - NotFound - пользователь не зарегистрирован или заблокирован
+//  - InvalidArgument - неподдерживаемый провайдер, неверный формат данных,
+//     недопустимый адрес для возврата или зарезервированные параметры
+//  - Internal - внутренние ошибки
+func (s *OpenID) Link(ctx context.Context, req *api.LinkRequest) (*api.LoginURL, error) {
+	// получаем провайдера, ответственного за авторизацию
+	provider, ok := s.providers[req.Provider]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"unsupported provider: %s", req.Provider)
+	}
+	// проверяем, что адрес для возврата разрешен для домена
+	if !s.redirectAllowed(req.Domain, req.RedirectURI) {
+		return nil, errRedirectURI
+	}
+	// проверяем, что пользователь зарегистрирован и не заблокирован
+	if _, err := s.db.GetUser(ctx, req.UID, ""); err != nil {
+		return nil, statusError(err)
+	}
+	// формируем url для перехода на авторизацию
+	loginURL, err := provider.LoginURL(ctx, req.RedirectURI, req.Params,
+		loginState{LinkUID: req.UID})
+	if errors.Is(err, openid.ErrRedirectURI) {
+		return nil, errRedirectURI
+	}
+	if errors.Is(err, openid.ErrReservedParam) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal,
+			"openid login error: %s", err)
+	}
+	return &api.LoginURL{Domain: req.Domain, URL: loginURL}, nil
+}
+
+// Unlink отвязывает внешнего провайдера авторизации от пользователя.
+// Отвязать провайдера нельзя, если у пользователя не задан пароль и нет
+// других привязанных провайдеров.
+//
+// Возвращает ошибки:
+//  - NotFound - провайдер не привязан к пользователю
+//  - FailedPrecondition - это единственный способ авторизации пользователя
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *OpenID) Unlink(ctx context.Context, req *api.UnlinkRequest) (*types.Empty, error) {
+	err := s.db.OpenIDUnlink(ctx, req.UID, req.Provider)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return new(types.Empty), nil
+}
+
+// AccessToken возвращает действующий токен доступа к API провайдера от
+// имени пользователя. Если время действия сохраненного токена истекло, то
+// он обновляется у провайдера с помощью токена обновления.
+//
+// Токены сохраняются при авторизации только для провайдеров, для которых
+// это разрешено, и если на сервисе задан ключ шифрования. Токены,
+// зашифрованные не основным ключом, при обращении шифруются им заново.
+//
+// Возвращает ошибки:
+//  - NotFound - токены для пользователя и провайдера не сохранены
+//  - FailedPrecondition - сохранение токенов не включено или токен
+//     доступа не удалось обновить
+//  - InvalidArgument - неподдерживаемый провайдер или неверный формат
+//     данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *OpenID) AccessToken(ctx context.Context, req *api.AccessTokenRequest) (*api.ProviderToken, error) {
+	// получаем провайдера, выдавшего токен
+	provider, ok := s.providers[req.Provider]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"unsupported provider: %s", req.Provider)
+	}
+	if s.Keyring == nil || !provider.StoreTokens() {
+		return nil, status.Error(codes.FailedPrecondition,
+			"provider tokens storage is disabled")
+	}
+	// получаем и расшифровываем сохраненный токен
+	subject, data, err := s.db.OpenIDToken(ctx, req.UID, req.Provider)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	plain, err := s.Keyring.Decrypt(data, tokenOwner(req.Provider, subject))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "provider token: %s", err)
+	}
+	var token = new(oauth2.Token)
+	if err = json.Unmarshal(plain, token); err != nil {
+		return nil, status.Errorf(codes.Internal, "provider token: %s", err)
+	}
+	// обновляем токен, если время его действия истекло, и шифруем заново,
+	// если он зашифрован не основным ключом
+	var save = s.Keyring.NeedsRotation(data)
+	if !token.Valid() {
+		token, err = provider.Refresh(ctx, token)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"provider token expired: %s", err)
+		}
+		save = true
+	}
+	if save {
+		if err = s.storeToken(ctx, req.Provider, subject, token); err != nil {
+			return nil, err
+		}
+	}
+	var result = &api.ProviderToken{
+		Domain:      req.Domain,
+		Provider:    req.Provider,
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+	if !token.Expiry.IsZero() {
+		result.Expires = &token.Expiry
+	}
+	return result, nil
+}
+
+// saveToken сохраняет токены, полученные от провайдера при авторизации, если
+// это разрешено для провайдера и задан ключ шифрования.
+func (s *OpenID) saveToken(ctx context.Context, provider openid.Authenticator,
+	userinfo *openid.UserInfo) error {
+	if s.Keyring == nil || !provider.StoreTokens() || userinfo.Token() == nil {
+		return nil
+	}
+	return s.storeToken(ctx, provider.String(), userinfo.Subject,
+		userinfo.Token())
+}
+
+// storeToken шифрует и сохраняет токены провайдера для привязки пользователя.
+func (s *OpenID) storeToken(ctx context.Context, provider, subject string,
+	token *oauth2.Token) error {
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return status.Errorf(codes.Internal, "provider token: %s", err)
+	}
+	data, err := s.Keyring.Encrypt(plain, tokenOwner(provider, subject))
+	if err != nil {
+		return status.Errorf(codes.Internal, "provider token: %s", err)
+	}
+	err = s.db.OpenIDTokenSave(ctx, provider, subject, data, token.Expiry)
+	if err != nil {
+		return statusError(err)
+	}
+	return nil
+}
+
+// tokenOwner возвращает дополнительные данные для шифрования токенов, чтобы
+// зашифрованный токен нельзя было перенести к другой привязке.
+func tokenOwner(provider, subject string) []byte {
+	return []byte(provider + "\x00" + subject)
+}
+
+// authorized создает сессию авторизованного пользователя и возвращает
+// информацию о нем.
+func (s *OpenID) authorized(ctx context.Context, domain, provider string,
+	userInfo *db.UserInfo) (*api.User, error) {
+	user, err := apiUser(domain, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	// создаем сессию авторизованного пользователя
+	if err = s.sessions.issue(ctx, user, provider); err != nil {
+		return nil, err
+	}
+	return user, nil // возвращаем информацию о пользователе
 }
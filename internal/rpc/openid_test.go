@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"itube/users/internal/db"
+	"itube/users/pkg/api"
+	"itube/users/pkg/openid"
+	"itube/users/pkg/openid/openidtest"
+	"itube/users/pkg/tools"
+)
+
+// testDB возвращает подключение к тестовой базе данных с примененными
+// миграциями, заданной в переменной окружения ITUBE_USERS_TEST_DSN. Если
+// она не задана, то тест пропускается.
+func testDB(t *testing.T) *db.Adapter {
+	t.Helper()
+	var dsn = os.Getenv("ITUBE_USERS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("ITUBE_USERS_TEST_DSN is not set")
+	}
+	pool, err := tools.Connect(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return &db.Adapter{Pool: pool}
+}
+
+func TestOpenIDDevProvider(t *testing.T) {
+	var adapter = testDB(t)
+	var devServer = openidtest.NewServer()
+	defer devServer.Close()
+	// провайдер настраивается так же, как при запуске сервиса
+	var cfg = devServer.Config("dev")
+	cfg.TrustEmail = openid.TrustNever
+	provider, err := openid.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		service     = NewOpenID(adapter, nil, provider)
+		ctx         = context.Background()
+		email       = fmt.Sprintf("dev-%d@example.com", time.Now().UnixNano())
+		redirectURI = "https://app.example.com/callback"
+		client      = &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	)
+	// login выполняет авторизацию через тестовый провайдер
+	var login = func() *api.User {
+		t.Helper()
+		loginURL, err := service.Login(ctx, &api.Provider{
+			Domain:      "example.com",
+			Provider:    "dev",
+			RedirectURI: redirectURI,
+			Params:      map[string]string{"login_hint": email},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Get(loginURL.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		location, err := url.Parse(resp.Header.Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var query = location.Query()
+		if query.Get("code") == "" {
+			t.Fatalf("authorization error: %s", location)
+		}
+		user, err := service.Authorize(ctx, &api.AuthCode{
+			Domain:   "example.com",
+			Provider: "dev",
+			State:    query.Get("state"),
+			Code:     query.Get("code"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return user
+	}
+	// первая авторизация регистрирует пользователя
+	var registered = login()
+	if registered.Email != email {
+		t.Errorf("unexpected email: %s", registered.Email)
+	}
+	if registered.Verified {
+		t.Error("dev provider email is verified")
+	}
+	// повторная авторизация возвращает того же пользователя
+	if user := login(); user.UID != registered.UID {
+		t.Errorf("unexpected user: %s", user.UID)
+	}
+}
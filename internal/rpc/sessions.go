@@ -0,0 +1,189 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"itube/users/internal/db"
+	"itube/users/pkg/api"
+	"itube/users/pkg/session"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// проверка, что сервис поддерживает все методы сервиса
+var _ api.SessionsServer = new(Sessions)
+
+// Sessions реализует grpc-сервис для обновления и завершения сессий
+// авторизованных пользователей.
+//
+// Так же используется другими сервисами для создания сессии при успешной
+// авторизации пользователя.
+type Sessions struct {
+	db     *db.Adapter
+	issuer *session.Issuer // выдача токенов доступа
+}
+
+// NewSessions возвращает инициализированный сервис для работы с сессиями
+// пользователей.
+func NewSessions(db *db.Adapter, issuer *session.Issuer) *Sessions {
+	return &Sessions{db: db, issuer: issuer}
+}
+
+// Refresh проверяет токен обновления и возвращает актуальную информацию
+// о пользователе с новой парой токенов доступа и обновления. Старый токен
+// обновления после этого становится недействительным. Токен принимается
+// только для сессии домена из запроса.
+//
+// Возвращает ошибки:
+//  - NotFound - пользователь заблокирован
+//  - InvalidArgument - неверный или устаревший токен обновления или токен
+//     сессии другого домена
+//  - Internal - внутренние ошибки
+func (s *Sessions) Refresh(ctx context.Context, req *api.RefreshToken) (*api.User, error) {
+	ip, userAgent := clientInfo(ctx)
+	session, userInfo, err := s.db.SessionRefresh(ctx, req.RefreshToken,
+		req.Domain, ip, userAgent)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	user, err := apiUser(req.Domain, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	// добавляем информацию о сессии с новыми токенами
+	if err = s.apiSession(user, session); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Logout завершает сессию пользователя. Токен обновления после этого
+// становится недействительным. Уже выданные токены доступа продолжают
+// действовать до окончания их времени жизни.
+//
+// Возвращает ошибки:
+//  - InvalidArgument - неверный токен обновления
+//  - Internal - внутренние ошибки
+func (s *Sessions) Logout(ctx context.Context, req *api.RefreshToken) (*types.Empty, error) {
+	err := s.db.SessionDelete(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return new(types.Empty), nil
+}
+
+// PublicKeys возвращает список открытых ключей для проверки подписи
+// токенов доступа в формате JWKS (RFC 7517).
+func (s *Sessions) PublicKeys(ctx context.Context, _ *types.Empty) (*api.KeySet, error) {
+	// т.к. названия полей совпадают с форматом JWK, то проще всего
+	// преобразовать ключи через json
+	data, err := json.Marshal(s.issuer.PublicKeys())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "public keys: %s", err)
+	}
+	var keys = new(api.KeySet)
+	if err = json.Unmarshal(data, keys); err != nil {
+		return nil, status.Errorf(codes.Internal, "public keys: %s", err)
+	}
+	return keys, nil
+}
+
+// List возвращает список действующих сессий пользователя, начиная с
+// последней использованной.
+//
+// Возвращает ошибки:
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *Sessions) List(ctx context.Context, req *api.UserSessions) (*api.SessionList, error) {
+	sessions, err := s.db.SessionList(ctx, req.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	var result = &api.SessionList{
+		Domain:   req.Domain,
+		Sessions: make([]*api.SessionInfo, len(sessions)),
+	}
+	for i, session := range sessions {
+		result.Sessions[i] = apiSessionInfo(session)
+	}
+	return result, nil
+}
+
+// Revoke завершает указанную сессию пользователя.
+//
+// Возвращает ошибки:
+//  - NotFound - сессия не найдена
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *Sessions) Revoke(ctx context.Context, req *api.SessionID) (*types.Empty, error) {
+	err := s.db.SessionRevoke(ctx, req.UID, req.ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return new(types.Empty), nil
+}
+
+// RevokeAll завершает все сессии пользователя.
+//
+// Возвращает ошибки:
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *Sessions) RevokeAll(ctx context.Context, req *api.UserSessions) (*types.Empty, error) {
+	err := s.db.SessionRevokeAll(ctx, req.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return new(types.Empty), nil
+}
+
+// issue создает новую сессию для авторизованного пользователя и добавляет
+// информацию о ней к данным о пользователе. Для авторизации по паролю или
+// токену provider не указывается. Если сервис не инициализирован, то сессия
+// не создается.
+func (s *Sessions) issue(ctx context.Context, user *api.User, provider string) error {
+	if s == nil {
+		return nil
+	}
+	ip, userAgent := clientInfo(ctx)
+	session, err := s.db.SessionCreate(ctx, user.UID, user.Domain, provider,
+		"", ip, userAgent)
+	if err != nil {
+		return statusError(err)
+	}
+	return s.apiSession(user, session)
+}
+
+// apiSession формирует токен доступа для сессии и добавляет информацию о
+// сессии к данным о пользователе.
+func (s *Sessions) apiSession(user *api.User, session *db.Session) error {
+	token, expires, err := s.issuer.Token(session.ID, user.UID, user.Email,
+		session.Domain, session.Scope)
+	if err != nil {
+		return status.Errorf(codes.Internal, "access token: %s", err)
+	}
+	user.Session = &api.Session{
+		ID:             session.ID,
+		AccessToken:    token,
+		TokenType:      "Bearer",
+		Expires:        &expires,
+		RefreshToken:   session.Token,
+		RefreshExpires: &session.Expires,
+	}
+	return nil
+}
+
+// apiSessionInfo преобразует информацию о сессии в grpc формат.
+func apiSessionInfo(session db.Session) *api.SessionInfo {
+	return &api.SessionInfo{
+		ID:        session.ID,
+		Domain:    session.Domain,
+		Provider:  session.Provider,
+		IP:        session.IP,
+		UserAgent: session.UserAgent,
+		Created:   &session.Created,
+		Used:      &session.Used,
+		Expires:   &session.Expires,
+	}
+}
@@ -4,27 +4,48 @@ import (
 	"context"
 	"itube/users/internal/db"
 	"itube/users/pkg/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // проверка, что сервис поддерживает все методы сервиса
 var _ api.TokensServer = new(Tokens)
 
+// errTokenType возвращается для типов токенов, которые создаются только
+// самим сервисом.
+var errTokenType = status.Error(codes.InvalidArgument, "unsupported token type")
+
 // Tokens реализует grpc-сервис для генерации и проверки токенов для сброса
 // пароля пользователя или подтверждения почтового адреса.
 type Tokens struct {
-	db *db.Adapter
+	db       *db.Adapter
+	sessions *Sessions // создание сессий авторизованных пользователей
+	// политики паролей; если не заданы, то пароли не проверяются
+	Passwords *Passwords
+}
+
+// NewTokens возвращает инициализированный сервис для генерации и проверки
+// токенов. Если sessions не задан, то сессии при проверке токена не
+// создаются.
+func NewTokens(db *db.Adapter, sessions *Sessions) *Tokens {
+	return &Tokens{db: db, sessions: sessions}
 }
 
 // Generate создает запрос для проверки адреса email пользователя или
 // сброса пароля. При вызове сервер отправляет соответствующее письмо
 // на email адрес пользователя с токеном для верификации.
 // Повторный вызов с теми же значениями параметров заменяет токен на новый,
-// а действие старого отменяет.
+// а действие старого отменяет. Токены типа REGISTERED и LINK не
+// поддерживаются.
 //
 // Возвращает ошибки:
 //  - InvalidArgument - неверный формат данных входящего запроса
 //  - Internal - внутренние ошибки
 func (s *Tokens) Generate(ctx context.Context, req *api.VerifyRequest) (*api.TokenInfo, error) {
+	if req.Type == api.REGISTERED || req.Type == api.LINK {
+		return nil, errTokenType
+	}
 	token, err := s.db.TokenGenerate(ctx, req.Domain, req.Email, int32(req.Type))
 	if err != nil {
 		return nil, statusError(err)
@@ -37,20 +58,134 @@ func (s *Tokens) Generate(ctx context.Context, req *api.VerifyRequest) (*api.Tok
 }
 
 // Verify проверяет токен и возвращает зарегистрированного пользователя.
-// Если токен неверен, то возвращается ошибка NotFound. После проверки
+// Если токен неверен, то возвращается ошибка InvalidArgument. После проверки
 // токен автоматически удаляется и повторное его использование невозможно.
 //
 // Так же автоматически подтверждает почтовый адрес, через который был
-// отправлен данный токен.
+// отправлен данный токен. Вместе с информацией о пользователе возвращаются
+// токены созданной сессии.
+//
+// Если у пользователя включена двухфакторная авторизация, то сессия не
+// создается, а возвращается ошибка FailedPrecondition, в деталях которой
+// передается Challenge с токеном для завершения авторизации через
+// TwoFactor.Authorize: доступа к почте для входа недостаточно.
+//
+// Тип токена в запросе должен совпадать с типом, с которым он был
+// сгенерирован, иначе токен считается неверным. Токены типа REGISTERED и LINK
+// не поддерживаются.
 //
 // Возвращает ошибки:
 //  - NotFound - пользователь не зарегистрирован
+//  - FailedPrecondition - время жизни токена истекло или требуется второй
+//     фактор авторизации
 //  - InvalidArgument - неверный формат данных входящего запроса
 //  - Internal - внутренние ошибки
 func (s *Tokens) Verify(ctx context.Context, req *api.TokenInfo) (*api.User, error) {
-	user, err := s.db.TokenVerify(ctx, req.Token)
+	if req.Type == api.REGISTERED || req.Type == api.LINK {
+		return nil, errTokenType
+	}
+	userInfo, err := s.db.TokenVerify(ctx, req.Token, int32(req.Type))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	// проверяем, требуется ли второй фактор авторизации
+	challenge, err := s.db.ChallengeCreate(ctx, userInfo.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if challenge != "" {
+		return nil, challengeError(req.Domain, challenge)
+	}
+	user, err := apiUser(req.Domain, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	// создаем сессию авторизованного пользователя
+	if err = s.sessions.issue(ctx, user, ""); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResetPassword проверяет токен для сброса пароля и устанавливает новый
+// пароль пользователя. Проверка токена, его удаление и замена пароля
+// выполняются атомарно. Токены для подтверждения почтового адреса
+// не принимаются.
+//
+// Так же автоматически подтверждает почтовый адрес, через который был
+// отправлен данный токен. Все сессии пользователя при этом завершаются.
+//
+// Пароль проверяется на соответствие политике паролей домена. При нарушении
+// возвращается ошибка InvalidArgument, в деталях которой передаются
+// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
+// Новый пароль так же не должен совпадать с последними паролями
+// пользователя, если это задано политикой домена.
+// Токен при этом остается действительным.
+//
+// Возвращает ошибки:
+//  - NotFound - пользователь не зарегистрирован или заблокирован
+//  - FailedPrecondition - время жизни токена истекло
+//  - InvalidArgument - неверный токен, формат данных или пароль не
+//     соответствует политике
+//  - Internal - внутренние ошибки
+func (s *Tokens) ResetPassword(ctx context.Context, req *api.PasswordReset) (*api.User, error) {
+	user, err := s.db.ResetPassword(ctx, req.Token, int32(api.PASSWORD),
+		req.Password, s.Passwords.history(req.Domain), func(email string) error {
+			return s.Passwords.check(req.Domain, email, req.Password)
+		})
 	if err != nil {
 		return nil, statusError(err)
 	}
 	return apiUser(req.Domain, user)
 }
+
+// Outbox возвращает список неотправленных писем с токенами домена: как
+// ожидающих повторной попытки отправки, так и тех, попытки отправки
+// которых прекращены из-за постоянной ошибки или исчерпания попыток.
+// Сами токены не возвращаются.
+//
+// Возвращает ошибки:
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *Tokens) Outbox(ctx context.Context, req *api.OutboxRequest) (*api.OutboxList, error) {
+	messages, err := s.db.Outbox(ctx, req.Domain, req.Failed)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	var list = &api.OutboxList{
+		Domain:   req.Domain,
+		Messages: make([]*api.OutboxMessage, len(messages)),
+	}
+	for i, msg := range messages {
+		var msg = msg
+		list.Messages[i] = &api.OutboxMessage{
+			Email:       msg.Email,
+			Type:        api.TokenType(msg.Type),
+			Attempts:    int32(msg.Attempts),
+			NextAttempt: &msg.NextAttempt,
+			LastError:   msg.LastError,
+			Failed:      msg.Failed,
+			Created:     &msg.Created,
+		}
+	}
+	return list, nil
+}
+
+// Requeue возвращает в очередь на отправку письмо с токеном, попытки
+// отправки которого прекращены, и сбрасывает счетчик попыток. Если email не
+// указан, то в очередь возвращаются все такие письма домена. Токен при
+// этом не меняется.
+//
+// Возвращает ошибки:
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *Tokens) Requeue(ctx context.Context, req *api.RequeueRequest) (*api.RequeueResult, error) {
+	count, err := s.db.Requeue(ctx, req.Domain, req.Email, int32(req.Type))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &api.RequeueResult{
+		Domain: req.Domain,
+		Count:  count,
+	}, nil
+}
@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"context"
+	"itube/users/internal/db"
+	"itube/users/pkg/api"
+	"itube/users/pkg/totp"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// проверка, что сервис поддерживает все методы сервиса
+var _ api.TwoFactorServer = new(TwoFactor)
+
+// TwoFactor реализует grpc-сервис для двухфакторной авторизации пользователей
+// с помощью одноразовых паролей.
+type TwoFactor struct {
+	db       *db.Adapter
+	sessions *Sessions // создание сессий авторизованных пользователей
+}
+
+// NewTwoFactor возвращает инициализированный сервис двухфакторной
+// авторизации. Если sessions не задан, то сессии при авторизации не
+// создаются.
+func NewTwoFactor(db *db.Adapter, sessions *Sessions) *TwoFactor {
+	return &TwoFactor{db: db, sessions: sessions}
+}
+
+// Enroll генерирует новый секретный ключ для двухфакторной авторизации.
+// Двухфакторная авторизация включается только после подтверждения ключа
+// с помощью Confirm. Повторный вызов до подтверждения заменяет ключ на
+// новый.
+//
+// Возвращает ошибки:
+//  - NotFound - пользователь не зарегистрирован или заблокирован
+//  - FailedPrecondition - двухфакторная авторизация уже включена
+//  - InvalidArgument - неверный формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *TwoFactor) Enroll(ctx context.Context, req *api.TwoFactorUser) (*api.TOTPSecret, error) {
+	// email пользователя используется в качестве названия учетной записи
+	user, err := s.db.GetUser(ctx, req.UID, "")
+	if err != nil {
+		return nil, statusError(err)
+	}
+	secret, err := s.db.TOTPEnroll(ctx, user.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &api.TOTPSecret{
+		Domain: req.Domain,
+		Secret: secret,
+		URI:    totp.URI(req.Domain, user.Email, secret),
+	}, nil
+}
+
+// Confirm проверяет код из приложения и включает двухфакторную
+// авторизацию. Возвращает список одноразовых кодов восстановления,
+// которые можно использовать вместо кода из приложения.
+//
+// Возвращает ошибки:
+//  - FailedPrecondition - ключ не сгенерирован или авторизация уже включена
+//  - InvalidArgument - неверный код или формат данных входящего запроса
+//  - Internal - внутренние ошибки
+func (s *TwoFactor) Confirm(ctx context.Context, req *api.TwoFactorCode) (*api.RecoveryCodes, error) {
+	recovery, err := s.db.TOTPConfirm(ctx, req.UID, req.Code)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &api.RecoveryCodes{Domain: req.Domain, Codes: recovery}, nil
+}
+
+// Disable проверяет код из приложения или код восстановления и отключает
+// двухфакторную авторизацию.
+//
+// Неверные коды подсчитываются для пользователя. После превышения
+// ограничения проверка временно блокируется и возвращается ошибка
+// ResourceExhausted, в деталях которой передается RetryInfo со временем до
+// окончания блокировки.
+//
+// Возвращает ошибки:
+//  - FailedPrecondition - двухфакторная авторизация не включена
+//  - InvalidArgument - неверный код или формат данных входящего запроса
+//  - ResourceExhausted - слишком много неверных кодов
+//  - Internal - внутренние ошибки
+func (s *TwoFactor) Disable(ctx context.Context, req *api.TwoFactorCode) (*types.Empty, error) {
+	// проверяем, не заблокирована ли проверка кода
+	retry, err := s.db.TOTPLocked(ctx, req.UID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if retry > 0 {
+		return nil, lockedError(retry)
+	}
+	err = s.db.TOTPDisable(ctx, req.UID, req.Code)
+	if err != nil {
+		// подсчитываем неверные коды
+		if err == db.ErrInvalidCode {
+			retry, lerr := s.db.TOTPFailed(ctx, req.UID)
+			if lerr != nil {
+				return nil, statusError(lerr)
+			}
+			if retry > 0 {
+				return nil, lockedError(retry)
+			}
+		}
+		return nil, statusError(err)
+	}
+	return new(types.Empty), nil
+}
+
+// Authorize завершает авторизацию пользователя по коду из приложения или
+// коду восстановления. Возвращает информацию о пользователе и токены
+// созданной сессии.
+//
+// Количество попыток ввода кода для одного запроса ограничено, после чего
+// необходимо заново авторизоваться по паролю. Кроме того, неверные коды
+// подсчитываются для пользователя по всем запросам: после превышения
+// ограничения проверка временно блокируется и возвращается ошибка
+// ResourceExhausted, в деталях которой передается RetryInfo со временем до
+// окончания блокировки. Счетчик неудачных попыток авторизации по паролю
+// сбрасывается только после успешной проверки кода.
+//
+// Возвращает ошибки:
+//  - NotFound - пользователь заблокирован
+//  - FailedPrecondition - время жизни запроса истекло
+//  - InvalidArgument - неверный код, запрос или формат данных
+//  - ResourceExhausted - слишком много неверных кодов
+//  - Internal - внутренние ошибки
+func (s *TwoFactor) Authorize(ctx context.Context, req *api.SecondFactor) (*api.User, error) {
+	// проверяем, не заблокирована ли проверка кода для пользователя
+	uid, err := s.db.ChallengeUID(ctx, req.Challenge)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	retry, err := s.db.TOTPLocked(ctx, uid)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if retry > 0 {
+		return nil, lockedError(retry)
+	}
+	userInfo, err := s.db.ChallengeVerify(ctx, req.Challenge, req.Code)
+	if err != nil {
+		// подсчитываем неверные коды
+		if err == db.ErrInvalidCode {
+			retry, lerr := s.db.TOTPFailed(ctx, uid)
+			if lerr != nil {
+				return nil, statusError(lerr)
+			}
+			if retry > 0 {
+				return nil, lockedError(retry)
+			}
+		}
+		return nil, statusError(err)
+	}
+	// сбрасываем счетчик неудачных попыток авторизации (ошибку игнорируем)
+	_ = s.db.LoginSucceeded(ctx, userInfo.Email)
+	user, err := apiUser(req.Domain, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	// создаем сессию авторизованного пользователя
+	if err = s.sessions.issue(ctx, user, ""); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// challengeError возвращает ошибку с запросом второго фактора авторизации.
+// Токен запроса передается в деталях ошибки.
+func challengeError(domain, challenge string) error {
+	var expires = time.Now().Add(db.ChallengeTTL)
+	st, err := status.New(codes.FailedPrecondition, "second factor required").
+		WithDetails(&api.Challenge{
+			Domain:    domain,
+			Challenge: challenge,
+			Expires:   &expires,
+		})
+	if err != nil {
+		return status.Errorf(codes.Internal, "challenge error: %s", err)
+	}
+	return st.Err()
+}
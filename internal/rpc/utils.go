@@ -1,15 +1,24 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"itube/users/internal/db"
 	"itube/users/pkg/api"
+	"itube/users/pkg/passpolicy"
+	"itube/users/pkg/tools"
+	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/jackc/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -42,12 +51,20 @@ func apiUser(domain string, userInfo *db.UserInfo) (*api.User, error) {
 func statusError(err error) error {
 	// подменяем стандартные ошибки
 	switch err {
-	case db.ErrAlreadyRegisterd:
+	case db.ErrAlreadyRegisterd, db.ErrIdentityLinked:
 		return status.Error(codes.AlreadyExists, err.Error())
-	case db.ErrBadToken, db.ErrEmptyEmail, db.ErrInvalidPassword:
+	case db.ErrBadToken, db.ErrEmptyEmail, db.ErrInvalidPassword,
+		db.ErrInvalidCode:
 		return status.Error(codes.InvalidArgument, err.Error())
 	case db.ErrBlocked, db.ErrNotFound:
 		return status.Error(codes.NotFound, err.Error())
+	case db.ErrTokenExpired, db.ErrTOTPEnabled, db.ErrTOTPNotEnabled,
+		db.ErrLastIdentity, db.ErrNoPassword:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	var policyErr = new(passpolicy.Error)
+	if errors.As(err, &policyErr) {
+		return policyError(policyErr)
 	}
 	var dbErr = new(pgconn.PgError)
 	if !errors.As(err, &dbErr) {
@@ -78,3 +95,114 @@ func utm(utm map[string]string) string {
 	}
 	return result
 }
+
+// TrustedProxies задает список доверенных прокси-серверов, от которых
+// принимаются метаданные с адресом клиента. Если список пустой, то адрес
+// клиента всегда берется из адреса подключения.
+var TrustedProxies tools.Proxies
+
+// clientInfo возвращает IP-адрес и информацию о приложении клиента.
+//
+// IP-адрес берется из адреса подключения, а если запрос пришел через
+// доверенный прокси-сервер (см. TrustedProxies), — из метаданных
+// x-forwarded-for или x-real-ip. Информация о приложении берется из
+// метаданных x-user-agent, grpcgateway-user-agent или user-agent.
+func clientInfo(ctx context.Context) (ip, userAgent string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	// первое не пустое значение из метаданных
+	var first = func(keys ...string) string {
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+		return ""
+	}
+	userAgent = first("x-user-agent", "grpcgateway-user-agent", "user-agent")
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = p.Addr.String()
+	}
+	ip = TrustedProxies.ClientIP(remote, md.Get("x-forwarded-for"),
+		first("x-real-ip"))
+	return ip, userAgent
+}
+
+// lockedError возвращает ошибку о временной блокировке авторизации. Время до
+// окончания блокировки передается в деталях ошибки как RetryInfo.
+func lockedError(retry time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, "too many login attempts").
+		WithDetails(&errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(retry.Round(time.Second)),
+		})
+	if err != nil {
+		return status.Errorf(codes.Internal, "locked error: %s", err)
+	}
+	return st.Err()
+}
+
+// Domains описывает список доменов. Значение "*" соответствует любому домену.
+type Domains []string
+
+// Has возвращает true, если домен входит в список.
+func (d Domains) Has(domain string) bool {
+	for _, name := range d {
+		if name == "*" || strings.EqualFold(name, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// Passwords задает политики паролей для доменов и список
+// скомпрометированных паролей.
+type Passwords struct {
+	Policies passpolicy.Policies  // политики паролей для доменов
+	Breached *passpolicy.Breached // скомпрометированные пароли
+}
+
+// check проверяет пароль на соответствие политике домена. Если политики не
+// заданы, то пароль не проверяется.
+func (p *Passwords) check(domain, email, password string) error {
+	if p == nil {
+		return nil
+	}
+	return p.Policies.Get(domain).Check(password, email, p.Breached)
+}
+
+// history возвращает количество последних паролей пользователя, которые
+// нельзя использовать повторно для указанного домена.
+func (p *Passwords) history(domain string) int {
+	if p == nil {
+		return 0
+	}
+	return p.Policies.Get(domain).History
+}
+
+// policyError возвращает ошибку о несоответствии пароля политике. Список
+// нарушений передается в деталях ошибки как BadRequest, а коды правил — как
+// ErrorInfo.
+func policyError(err *passpolicy.Error) error {
+	var (
+		badRequest = new(errdetails.BadRequest)
+		info       = &errdetails.ErrorInfo{
+			Reason:   "PASSWORD_POLICY",
+			Domain:   "itube.users",
+			Metadata: make(map[string]string, len(err.Violations)),
+		}
+	)
+	for _, v := range err.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations,
+			&errdetails.BadRequest_FieldViolation{
+				Field:       "password",
+				Description: v.Description,
+			})
+		info.Metadata[v.Rule] = v.Description
+	}
+	st, serr := status.New(codes.InvalidArgument, err.Error()).
+		WithDetails(badRequest, info)
+	if serr != nil {
+		return status.Errorf(codes.Internal, "policy error: %s", serr)
+	}
+	return st.Err()
+}
@@ -2,9 +2,12 @@ package sender
 
 import (
 	"context"
+	"errors"
 	"itube/users/internal/db"
 	"itube/users/pkg/api"
 	"itube/users/pkg/email"
+	"net/textproto"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/mail.v2"
@@ -21,48 +24,126 @@ func New(db *db.Adapter, t *email.Templates) *Sender {
 	return &Sender{db: db, tmplts: t}
 }
 
-// Send запрашивает список ключей для отправки и отправляет их по почте.
+// ListenRetry задает время ожидания перед повторной подпиской на
+// уведомления о новых токенах после потери соединения с базой данных.
+var ListenRetry = time.Second * 5
+
+// Run отправляет письма с токенами сразу после получения уведомления о новом
+// или перевыпущенном токене. Дополнительно письма отправляются при запуске,
+// после восстановления подписки на уведомления и с интервалом interval на
+// случай пропущенных уведомлений. Работает до отмены ctx.
+func (s Sender) Run(ctx context.Context, interval time.Duration) {
+	// несколько уведомлений, пришедших во время отправки, объединяются в одно
+	var wake = make(chan struct{}, 1)
+	var notify = func() {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+	notify() // отправляем накопившиеся письма при запуске
+	// подписываемся на уведомления и переподписываемся при ошибках
+	go func() {
+		for {
+			err := s.db.ListenTokens(ctx, notify)
+			if ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Warn("tokens notification listener error")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(ListenRetry):
+			}
+			notify() // уведомления могли быть пропущены
+		}
+	}()
+	var timer = time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-timer.C:
+		}
+		// откладываем проверку по таймеру после каждой отправки, но не
+		// дольше, чем до ближайшей повторной попытки
+		var wait = interval
+		if err := s.Send(ctx); err != nil {
+			if ctx.Err() == nil {
+				log.WithError(err).Error("error sending tokens")
+			}
+		} else if next, err := s.db.TokenNextAttempt(ctx); err != nil {
+			if ctx.Err() == nil {
+				log.WithError(err).Error("error getting next send attempt")
+			}
+		} else if !next.IsZero() && time.Until(next) < wait {
+			wait = time.Until(next)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// Send запрашивает список писем с токенами, которые пора отправить, и
+// отправляет их по почте.
+//
+// Каждое письмо отправляется независимо от остальных: при ошибке отправки
+// следующая попытка для него откладывается (см. db.Retry), а при постоянной
+// ошибке (нет шаблона для домена или типа токена, SMTP-сервер отклонил
+// письмо с кодом 5xx) или исчерпании попыток письмо помечается как
+// неотправленное. Ошибка соединения с SMTP-сервером не относится к
+// конкретному письму, поэтому прерывает отправку без изменения счетчиков.
 func (s Sender) Send(ctx context.Context) error {
 	// запрашиваем список токенов для отправки
 	tokens, err := s.db.TokensToSend(ctx)
 	if err != nil {
 		return err
 	}
-	if len(tokens) == 0 {
-		return nil // нечего отсылать
-	}
-	// устанавливаем соединение с SMTP-сервером
-	sender, err := s.tmplts.Dialer.Dial()
-	if err != nil {
-		return err
-	}
-	defer sender.Close()
+	// соединение с SMTP-сервером устанавливается при первой необходимости
+	var sender mail.SendCloser
+	defer func() {
+		if sender != nil {
+			sender.Close()
+		}
+	}()
 	var msg = mail.NewMessage() // инициализируем почтовое сообщение
 	// перебираем все токены
 	for _, token := range tokens {
-		domain, err := s.tmplts.Domain(token.Domain)
-		if err != nil {
-			log.WithError(err).Warn("ignore token for domain")
-			continue
-		}
-		email, err := domain.Email(api.TokenType(token.Type).String())
-		if err != nil {
-			log.WithError(err).Warn("ignore token for token type")
-			continue
-		}
 		// формируем почтовое сообщение
 		msg.Reset()
-		msg.SetHeader("From", domain.From)
-		msg.SetHeader("To", token.Email)
-		err = email.WithToken(token.Token).Apply(msg)
+		from, err := s.message(msg, token)
 		if err != nil {
-			log.WithError(err).Warn("ignore email template")
+			// без шаблона письмо не отправить и при повторной попытке
+			if err = s.failed(ctx, token, err, true); err != nil {
+				return err
+			}
 			continue
 		}
+		// устанавливаем соединение с SMTP-сервером
+		if sender == nil {
+			if sender, err = s.tmplts.Dialer.Dial(); err != nil {
+				return err
+			}
+		}
 		// отсылаем письмо
-		err = sender.Send(domain.From, []string{token.Email}, msg)
-		if err != nil {
-			return err
+		if err = sender.Send(from, []string{token.Email}, msg); err != nil {
+			// после ошибки состояние соединения не определено
+			sender.Close()
+			sender = nil
+			if err = s.failed(ctx, token, err, permanent(err)); err != nil {
+				return err
+			}
+			continue
 		}
 		// ставим метку, что письмо отправлено
 		err = s.db.TokenSended(ctx, token.Token)
@@ -72,3 +153,51 @@ func (s Sender) Send(ctx context.Context) error {
 	}
 	return nil
 }
+
+// message заполняет почтовое сообщение с токеном по шаблону домена и
+// возвращает адрес отправителя.
+func (s Sender) message(msg *mail.Message, token db.TokenInfo) (string, error) {
+	domain, err := s.tmplts.Domain(token.Domain)
+	if err != nil {
+		return "", err
+	}
+	email, err := domain.Email(api.TokenType(token.Type).String())
+	if err != nil {
+		return "", err
+	}
+	msg.SetHeader("From", domain.From)
+	msg.SetHeader("To", token.Email)
+	if err = email.WithToken(token.Token).Apply(msg); err != nil {
+		return "", err
+	}
+	return domain.From, nil
+}
+
+// failed сохраняет ошибку отправки письма с токеном и откладывает следующую
+// попытку.
+func (s Sender) failed(ctx context.Context, token db.TokenInfo,
+	sendErr error, permanent bool) error {
+	failed, err := s.db.TokenFailed(ctx, token, sendErr.Error(), permanent)
+	if err != nil {
+		return err
+	}
+	var entry = log.WithError(sendErr).WithFields(log.Fields{
+		"domain":   token.Domain,
+		"email":    token.Email,
+		"type":     api.TokenType(token.Type).String(),
+		"attempts": token.Attempts + 1,
+	})
+	if failed {
+		entry.Error("email sending failed")
+	} else {
+		entry.Warn("email sending postponed")
+	}
+	return nil
+}
+
+// permanent возвращает true, если SMTP-сервер окончательно отклонил письмо
+// (код ответа 5xx) и повторять попытки бесполезно.
+func permanent(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500
+}
@@ -0,0 +1,145 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"itube/users/internal/db"
+	"itube/users/internal/rpc"
+	"itube/users/pkg/api"
+	"itube/users/pkg/email"
+	"itube/users/pkg/tools"
+
+	"gopkg.in/mail.v2"
+)
+
+// testDB возвращает подключение к тестовой базе данных с примененными
+// миграциями, заданной в переменной окружения ITUBE_USERS_TEST_DSN. Если
+// она не задана, то тест пропускается.
+func testDB(t *testing.T) *db.Adapter {
+	t.Helper()
+	var dsn = os.Getenv("ITUBE_USERS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("ITUBE_USERS_TEST_DSN is not set")
+	}
+	pool, err := tools.Connect(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return &db.Adapter{Pool: pool}
+}
+
+// testDialer сохраняет отправленные письма вместо отправки по почте.
+type testDialer struct {
+	sent map[string][]byte // письма по адресу получателя
+}
+
+func (d *testDialer) Dial() (mail.SendCloser, error) {
+	return d, nil
+}
+
+func (d *testDialer) Send(from string, to []string, msg io.WriterTo) error {
+	var buf bytes.Buffer
+	if _, err := msg.WriteTo(&buf); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		d.sent[addr] = buf.Bytes()
+	}
+	return nil
+}
+
+func (d *testDialer) Close() error {
+	return nil
+}
+
+// testTemplates задает шаблон письма для проверки адреса тестового домена.
+const testTemplates = `example.com:
+  from: noreply@example.com
+  emails:
+    EMAIL:
+      subject: Confirm your account
+      text: "Token: _TOKEN_PLACEHOLDER_"
+`
+
+func TestSendVerify(t *testing.T) {
+	var adapter = testDB(t)
+	// загружаем шаблоны писем и подменяем соединение с SMTP-сервером
+	file, err := ioutil.TempFile("", "templates*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(testTemplates)
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmplts, err := email.Init("smtp://localhost", file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dialer = &testDialer{sent: make(map[string][]byte)}
+	tmplts.Dialer = dialer
+	// регистрируем пользователя и запрашиваем токен для проверки адреса
+	var (
+		ctx     = context.Background()
+		address = fmt.Sprintf("sender-%d@example.com", time.Now().UnixNano())
+		tokens  = rpc.NewTokens(adapter, nil)
+	)
+	if _, err = adapter.Register(ctx, address, "Str0ng-Passw0rd"); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := tokens.Generate(ctx, &api.VerifyRequest{
+		Domain: "example.com",
+		Email:  address,
+		Type:   api.EMAIL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// отправляем письмо и берем токен из него
+	if err = New(adapter, tmplts).Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := dialer.sent[address]
+	if !ok {
+		t.Fatal("email was not sent")
+	}
+	var match = regexp.MustCompile(`Token: ([A-Za-z0-9_-]+)`).FindSubmatch(msg)
+	if match == nil {
+		t.Fatalf("token not found in email:\n%s", msg)
+	}
+	var token = string(match[1])
+	if token != generated.Token {
+		t.Errorf("unexpected token: %s", token)
+	}
+	// токен из письма подтверждает адрес пользователя
+	user, err := tokens.Verify(ctx, &api.TokenInfo{
+		Domain: "example.com",
+		Token:  token,
+		Type:   api.EMAIL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != address || !user.Verified {
+		t.Errorf("unexpected user: %s (verified: %t)", user.Email, user.Verified)
+	}
+	// письмо отправляется только один раз
+	delete(dialer.sent, address)
+	if err = New(adapter, tmplts).Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = dialer.sent[address]; ok {
+		t.Error("email was sent twice")
+	}
+}
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS sessions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  uid UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+  domain VARCHAR NOT NULL,
+  token BYTEA NOT NULL UNIQUE,
+  created TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sessions_uid_idx ON sessions (uid);
+
+COMMENT ON TABLE sessions IS 'Сессии авторизованных пользователей';
+COMMENT ON COLUMN sessions.id IS 'Уникальный идентификатор сессии';
+COMMENT ON COLUMN sessions.uid IS 'Уникальный идентификатор пользователя';
+COMMENT ON COLUMN sessions.domain IS 'Домен';
+COMMENT ON COLUMN sessions.token IS 'Хеш от токена обновления (sha256)';
+COMMENT ON COLUMN sessions.created IS 'Дата и время создания';
+COMMENT ON COLUMN sessions.expires IS 'Дата и время окончания действия токена обновления';
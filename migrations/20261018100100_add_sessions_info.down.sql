@@ -0,0 +1,5 @@
+ALTER TABLE sessions
+  DROP COLUMN IF EXISTS provider,
+  DROP COLUMN IF EXISTS ip,
+  DROP COLUMN IF EXISTS user_agent,
+  DROP COLUMN IF EXISTS used;
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE sessions
+  ADD COLUMN IF NOT EXISTS provider VARCHAR,
+  ADD COLUMN IF NOT EXISTS ip VARCHAR,
+  ADD COLUMN IF NOT EXISTS user_agent VARCHAR,
+  ADD COLUMN IF NOT EXISTS used TIMESTAMPTZ NOT NULL DEFAULT now();
+
+COMMENT ON COLUMN sessions.provider IS 'Идентификатор провайдера авторизации';
+COMMENT ON COLUMN sessions.ip IS 'IP-адрес клиента';
+COMMENT ON COLUMN sessions.user_agent IS 'Информация о приложении клиента (User-Agent)';
+COMMENT ON COLUMN sessions.used IS 'Дата и время последнего обновления сессии';
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS totp (
+  uid UUID PRIMARY KEY REFERENCES users ON DELETE CASCADE,
+  secret VARCHAR NOT NULL,
+  enabled BOOL NOT NULL DEFAULT FALSE,
+  counter BIGINT NOT NULL DEFAULT 0,
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE totp IS 'Настройки двухфакторной авторизации пользователей (RFC 6238)';
+COMMENT ON COLUMN totp.uid IS 'Уникальный идентификатор пользователя';
+COMMENT ON COLUMN totp.secret IS 'Секретный ключ (base32)';
+COMMENT ON COLUMN totp.enabled IS 'Флаг, что ключ подтвержден и двухфакторная авторизация включена';
+COMMENT ON COLUMN totp.counter IS 'Номер периода последнего использованного одноразового пароля';
+COMMENT ON COLUMN totp.created IS 'Дата и время создания';
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+  uid UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+  code BYTEA NOT NULL,
+  PRIMARY KEY (uid, code)
+);
+
+COMMENT ON TABLE recovery_codes IS 'Одноразовые коды восстановления для двухфакторной авторизации';
+COMMENT ON COLUMN recovery_codes.uid IS 'Уникальный идентификатор пользователя';
+COMMENT ON COLUMN recovery_codes.code IS 'Хеш от кода восстановления (sha256)';
+
+CREATE TABLE IF NOT EXISTS challenges (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  uid UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+  attempts SMALLINT NOT NULL DEFAULT 0,
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE challenges IS 'Запросы второго фактора авторизации';
+COMMENT ON COLUMN challenges.id IS 'Токен запроса';
+COMMENT ON COLUMN challenges.uid IS 'Уникальный идентификатор пользователя';
+COMMENT ON COLUMN challenges.attempts IS 'Количество неудачных попыток ввода кода';
+COMMENT ON COLUMN challenges.created IS 'Дата и время создания';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+  scope VARCHAR NOT NULL,
+  key VARCHAR NOT NULL,
+  failures INTEGER NOT NULL DEFAULT 1,
+  locked TIMESTAMPTZ,
+  updated TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (scope, key)
+);
+
+COMMENT ON TABLE login_attempts IS 'Счетчики неудачных попыток авторизации';
+COMMENT ON COLUMN login_attempts.scope IS 'Тип счетчика: email или ip';
+COMMENT ON COLUMN login_attempts.key IS 'Почтовый адрес или IP-адрес клиента';
+COMMENT ON COLUMN login_attempts.failures IS 'Количество неудачных попыток подряд';
+COMMENT ON COLUMN login_attempts.locked IS 'Дата и время окончания временной блокировки';
+COMMENT ON COLUMN login_attempts.updated IS 'Дата и время последней неудачной попытки';
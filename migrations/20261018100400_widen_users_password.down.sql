@@ -0,0 +1,5 @@
+-- хеши argon2id длиннее 60 символов, поэтому откат не выполнится, пока у
+-- пользователей остаются такие пароли
+ALTER TABLE users ALTER COLUMN password TYPE VARCHAR(60);
+
+COMMENT ON COLUMN users.password IS 'Хеш от пароля (bcrypt)';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users ALTER COLUMN password TYPE VARCHAR;
+
+COMMENT ON COLUMN users.password IS 'Хеш от пароля в формате PHC (bcrypt или argon2id)';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS password_history (
+  uid UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+  password VARCHAR NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS password_history_uid_idx
+  ON password_history (uid, created DESC);
+
+COMMENT ON TABLE password_history IS 'История паролей пользователей';
+COMMENT ON COLUMN password_history.uid IS 'Уникальный идентификатор пользователя';
+COMMENT ON COLUMN password_history.password IS 'Хеш от пароля в формате PHC';
+COMMENT ON COLUMN password_history.created IS 'Дата и время установки пароля';
+
+-- текущие пароли пользователей считаются первой записью истории
+INSERT INTO password_history (uid, password, created)
+  SELECT uid, password, updated FROM users WHERE password IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS openid_states;
\ No newline at end of file
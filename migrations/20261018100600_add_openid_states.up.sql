@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS openid_states (
+  key VARCHAR PRIMARY KEY,
+  redirect_uri VARCHAR NOT NULL DEFAULT '',
+  data JSONB,
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS openid_states_created_idx ON openid_states (created);
+
+COMMENT ON TABLE openid_states IS 'Состояния авторизации через внешних провайдеров OpenID Connect';
+COMMENT ON COLUMN openid_states.key IS 'Сессионный ключ авторизации (state)';
+COMMENT ON COLUMN openid_states.redirect_uri IS 'Адрес для редиректа после авторизации';
+COMMENT ON COLUMN openid_states.data IS 'Дополнительные данные';
+COMMENT ON COLUMN openid_states.created IS 'Дата и время создания';
@@ -0,0 +1,3 @@
+ALTER TABLE openid_states
+  DROP COLUMN IF EXISTS nonce,
+  DROP COLUMN IF EXISTS verifier;
\ No newline at end of file
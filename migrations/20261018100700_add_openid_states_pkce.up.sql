@@ -0,0 +1,6 @@
+ALTER TABLE openid_states
+  ADD COLUMN IF NOT EXISTS nonce VARCHAR NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS verifier VARCHAR NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN openid_states.nonce IS 'Nonce для проверки токена идентификации';
+COMMENT ON COLUMN openid_states.verifier IS 'Code verifier для PKCE (RFC 7636)';
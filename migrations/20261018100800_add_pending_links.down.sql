@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pending_links;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS pending_links (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  provider VARCHAR NOT NULL,
+  subject VARCHAR NOT NULL,
+  uid UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pending_links_created_idx ON pending_links (created);
+
+COMMENT ON TABLE pending_links IS 'Запросы на привязку внешнего провайдера с неподтвержденным email к существующему пользователю';
+COMMENT ON COLUMN pending_links.id IS 'Токен запроса';
+COMMENT ON COLUMN pending_links.provider IS 'Идентификатор провайдера авторизации';
+COMMENT ON COLUMN pending_links.subject IS 'Уникальный идентификатор пользователя у провайдера';
+COMMENT ON COLUMN pending_links.uid IS 'Уникальный идентификатор существующего пользователя с тем же email';
+COMMENT ON COLUMN pending_links.created IS 'Дата и время создания';
@@ -0,0 +1,2 @@
+ALTER TABLE openid DROP COLUMN IF EXISTS updated;
+ALTER TABLE openid DROP COLUMN IF EXISTS claims;
\ No newline at end of file
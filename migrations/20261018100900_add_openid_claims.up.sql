@@ -0,0 +1,5 @@
+ALTER TABLE openid ADD COLUMN IF NOT EXISTS claims JSONB;
+ALTER TABLE openid ADD COLUMN IF NOT EXISTS updated TIMESTAMPTZ NOT NULL DEFAULT now();
+
+COMMENT ON COLUMN openid.claims IS 'Последние данные профиля пользователя, полученные от провайдера';
+COMMENT ON COLUMN openid.updated IS 'Дата и время последнего обновления данных профиля';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS openid_tokens;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS openid_tokens (
+  provider VARCHAR NOT NULL,
+  subject VARCHAR NOT NULL,
+  token BYTEA NOT NULL,
+  expiry TIMESTAMPTZ,
+  updated TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (provider, subject),
+  FOREIGN KEY (provider, subject) REFERENCES openid ON DELETE CASCADE
+);
+
+COMMENT ON TABLE openid_tokens IS 'Зашифрованные токены внешних провайдеров авторизации';
+COMMENT ON COLUMN openid_tokens.provider IS 'Идентификатор провайдера авторизации';
+COMMENT ON COLUMN openid_tokens.subject IS 'Уникальный идентификатор пользователя у провайдера';
+COMMENT ON COLUMN openid_tokens.token IS 'Токены доступа и обновления в формате JSON, зашифрованные ключом сервиса';
+COMMENT ON COLUMN openid_tokens.expiry IS 'Дата и время окончания действия токена доступа';
+COMMENT ON COLUMN openid_tokens.updated IS 'Дата и время сохранения';
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS oidc_clients (
+  id VARCHAR PRIMARY KEY,
+  name VARCHAR NOT NULL DEFAULT '',
+  domain VARCHAR NOT NULL,
+  secret VARCHAR,
+  redirect_uris VARCHAR[] NOT NULL DEFAULT '{}',
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE oidc_clients IS 'Приложения, авторизующие пользователей через сервис по протоколу OpenID Connect';
+COMMENT ON COLUMN oidc_clients.id IS 'Идентификатор клиента (client_id)';
+COMMENT ON COLUMN oidc_clients.name IS 'Отображаемое название приложения';
+COMMENT ON COLUMN oidc_clients.domain IS 'Домен, для которого создаются сессии пользователей';
+COMMENT ON COLUMN oidc_clients.secret IS 'Хеш от секретного ключа клиента (crypt из pgcrypto); NULL для публичных клиентов';
+COMMENT ON COLUMN oidc_clients.redirect_uris IS 'Зарегистрированные адреса для возврата после авторизации';
+COMMENT ON COLUMN oidc_clients.created IS 'Дата и время регистрации';
+
+CREATE TABLE IF NOT EXISTS oidc_codes (
+  code BYTEA PRIMARY KEY,
+  client_id VARCHAR NOT NULL REFERENCES oidc_clients ON DELETE CASCADE,
+  uid UUID NOT NULL REFERENCES users ON DELETE CASCADE,
+  redirect_uri VARCHAR NOT NULL,
+  scope VARCHAR NOT NULL DEFAULT '',
+  nonce VARCHAR NOT NULL DEFAULT '',
+  challenge VARCHAR NOT NULL DEFAULT '',
+  created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS oidc_codes_created_idx ON oidc_codes (created);
+
+COMMENT ON TABLE oidc_codes IS 'Выданные коды авторизации OpenID Connect';
+COMMENT ON COLUMN oidc_codes.code IS 'Хеш от кода авторизации (sha256)';
+COMMENT ON COLUMN oidc_codes.client_id IS 'Идентификатор клиента';
+COMMENT ON COLUMN oidc_codes.uid IS 'Уникальный идентификатор авторизованного пользователя';
+COMMENT ON COLUMN oidc_codes.redirect_uri IS 'Адрес для возврата из запроса авторизации';
+COMMENT ON COLUMN oidc_codes.scope IS 'Запрошенные области доступа';
+COMMENT ON COLUMN oidc_codes.nonce IS 'Значение nonce из запроса авторизации';
+COMMENT ON COLUMN oidc_codes.challenge IS 'Значение code_challenge для PKCE (S256)';
+COMMENT ON COLUMN oidc_codes.created IS 'Дата и время создания';
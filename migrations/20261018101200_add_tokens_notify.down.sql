@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS tokens_notify ON tokens;
+DROP FUNCTION IF EXISTS tokens_notify();
\ No newline at end of file
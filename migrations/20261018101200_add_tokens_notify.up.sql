@@ -0,0 +1,14 @@
+CREATE OR REPLACE FUNCTION tokens_notify() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('tokens', NEW.type::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tokens_notify ON tokens;
+CREATE TRIGGER tokens_notify
+  AFTER INSERT OR UPDATE ON tokens
+  FOR EACH ROW WHEN (NOT NEW.sended)
+  EXECUTE PROCEDURE tokens_notify();
+
+COMMENT ON FUNCTION tokens_notify() IS 'Уведомление в канал tokens о новом или перевыпущенном токене для отправки';
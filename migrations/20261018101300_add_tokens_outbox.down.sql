@@ -0,0 +1,14 @@
+-- возвращаем уведомление в прежнем виде, т.к. оно использует удаляемые
+-- колонки
+DROP TRIGGER IF EXISTS tokens_notify ON tokens;
+CREATE TRIGGER tokens_notify
+  AFTER INSERT OR UPDATE ON tokens
+  FOR EACH ROW WHEN (NOT NEW.sended)
+  EXECUTE PROCEDURE tokens_notify();
+
+DROP INDEX IF EXISTS tokens_outbox_idx;
+
+ALTER TABLE tokens DROP COLUMN IF EXISTS failed;
+ALTER TABLE tokens DROP COLUMN IF EXISTS last_error;
+ALTER TABLE tokens DROP COLUMN IF EXISTS next_attempt;
+ALTER TABLE tokens DROP COLUMN IF EXISTS attempts;
\ No newline at end of file
@@ -0,0 +1,19 @@
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS next_attempt TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS last_error VARCHAR;
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS failed BOOL NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS tokens_outbox_idx ON tokens (next_attempt) WHERE NOT sended AND NOT failed;
+
+COMMENT ON COLUMN tokens.attempts IS 'Количество неудачных попыток отправки письма';
+COMMENT ON COLUMN tokens.next_attempt IS 'Дата и время следующей попытки отправки';
+COMMENT ON COLUMN tokens.last_error IS 'Ошибка последней попытки отправки';
+COMMENT ON COLUMN tokens.failed IS 'Флаг, что письмо не удалось отправить и попытки прекращены';
+
+-- уведомляем только о письмах, готовых к отправке, а не о каждой неудачной
+-- попытке
+DROP TRIGGER IF EXISTS tokens_notify ON tokens;
+CREATE TRIGGER tokens_notify
+  AFTER INSERT OR UPDATE ON tokens
+  FOR EACH ROW WHEN (NOT NEW.sended AND NOT NEW.failed AND NEW.next_attempt <= now())
+  EXECUTE PROCEDURE tokens_notify();
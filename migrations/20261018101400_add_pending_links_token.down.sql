@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS pending_links_token_idx;
+ALTER TABLE pending_links DROP COLUMN IF EXISTS token;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE pending_links ADD COLUMN IF NOT EXISTS token UUID;
+
+CREATE UNIQUE INDEX IF NOT EXISTS pending_links_token_idx ON pending_links (token);
+
+COMMENT ON COLUMN pending_links.token IS 'Токен из письма LINK, которым владелец учетной записи подтверждает привязку';
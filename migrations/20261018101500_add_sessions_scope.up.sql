@@ -0,0 +1,3 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS scope VARCHAR;
+
+COMMENT ON COLUMN sessions.scope IS 'Области доступа, разрешенные клиенту OpenID Connect';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS challenges_created_idx;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS challenges_created_idx ON challenges (created);
@@ -268,42 +268,42 @@ func init() { proto.RegisterFile("identity.proto", fileDescriptor_61c7956abb7616
 func init() { golang_proto.RegisterFile("identity.proto", fileDescriptor_61c7956abb761639) }
 
 var fileDescriptor_61c7956abb761639 = []byte{
-	// 554 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x4d, 0x8b, 0xd3, 0x40,
+	// 559 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x4d, 0x8b, 0xd3, 0x40,
 	0x18, 0xce, 0xf4, 0x23, 0xcd, 0x4e, 0x41, 0x70, 0xfc, 0xa0, 0x54, 0x99, 0x86, 0x5e, 0x2c, 0x42,
-	0x13, 0xb7, 0x8b, 0x82, 0xe0, 0xc5, 0x52, 0x75, 0x0b, 0x7b, 0x90, 0x48, 0x61, 0xf1, 0x22, 0xe9,
-	0x66, 0x3a, 0x3b, 0xb4, 0xc9, 0xc4, 0xc9, 0xc4, 0xa2, 0xbf, 0x40, 0x6f, 0x7b, 0xf0, 0xe0, 0x0f,
-	0xf0, 0x87, 0x78, 0xec, 0xb1, 0x47, 0x4f, 0x5d, 0x37, 0xfd, 0x1f, 0x22, 0xf9, 0xaa, 0x1b, 0xd6,
-	0x65, 0x0b, 0xee, 0x29, 0xf3, 0x3e, 0xf3, 0x3c, 0x33, 0x4f, 0x9e, 0x77, 0x5e, 0x78, 0x83, 0x39,
-	0xc4, 0x93, 0x4c, 0x7e, 0x34, 0x7c, 0xc1, 0x25, 0x47, 0x75, 0x26, 0xc3, 0x31, 0x31, 0xc2, 0x80,
-	0x88, 0xa0, 0x09, 0xe3, 0x4f, 0xba, 0xd1, 0xbc, 0x47, 0x39, 0xa7, 0x33, 0x62, 0x26, 0xd5, 0x38,
-	0x9c, 0x98, 0xc4, 0xf5, 0x73, 0x55, 0xb3, 0x4b, 0x99, 0x3c, 0x0e, 0xc7, 0xc6, 0x11, 0x77, 0x4d,
-	0xca, 0x29, 0xff, 0xcb, 0x8a, 0xab, 0xa4, 0x48, 0x56, 0x19, 0xfd, 0xc9, 0x39, 0xba, 0x3b, 0x67,
-	0x72, 0xca, 0xe7, 0x26, 0xe5, 0xdd, 0x64, 0xb3, 0xfb, 0xc1, 0x9e, 0x31, 0xc7, 0x96, 0x5c, 0x04,
-	0xe6, 0x66, 0x99, 0xea, 0xda, 0xdf, 0x01, 0xac, 0x1e, 0x70, 0xca, 0x3c, 0x84, 0xa1, 0xea, 0x70,
-	0xd7, 0x66, 0x5e, 0x03, 0xe8, 0xa0, 0xb3, 0xd3, 0x57, 0xa3, 0xd3, 0x56, 0xe9, 0x10, 0x58, 0x19,
-	0x8a, 0xee, 0xc3, 0x2a, 0x71, 0x6d, 0x36, 0x6b, 0x94, 0x0a, 0xdb, 0x29, 0x88, 0xda, 0x50, 0xf3,
-	0xed, 0x20, 0x98, 0x73, 0xe1, 0x34, 0xca, 0x05, 0xc2, 0x06, 0x47, 0x4f, 0xa1, 0x26, 0x08, 0x7d,
-	0xc7, 0xbc, 0x09, 0x6f, 0x40, 0x1d, 0x74, 0xea, 0xbd, 0xdb, 0xc6, 0xb9, 0x6c, 0x0c, 0x8b, 0xd0,
-	0xa1, 0x37, 0xe1, 0x7d, 0x6d, 0xb1, 0x6a, 0x29, 0xcb, 0x55, 0x0b, 0x58, 0x35, 0x91, 0x42, 0xed,
-	0xaf, 0x00, 0x6a, 0xaf, 0xf3, 0x73, 0xae, 0x72, 0x3a, 0x80, 0xe5, 0x90, 0x39, 0x99, 0xcf, 0x5e,
-	0xb4, 0x6a, 0x95, 0x47, 0xc3, 0x41, 0x74, 0xda, 0x7a, 0xf0, 0x50, 0x67, 0x5e, 0x12, 0x80, 0x1e,
-	0x7a, 0xec, 0x7d, 0x48, 0xf4, 0xb4, 0x53, 0x13, 0x46, 0x84, 0x3e, 0xe1, 0xc2, 0xb5, 0xe5, 0x21,
-	0x38, 0x01, 0x15, 0x2b, 0x96, 0x6f, 0xf3, 0x47, 0xed, 0x6f, 0x00, 0xaa, 0xa3, 0x80, 0x88, 0xe1,
-	0xe0, 0x4a, 0x53, 0x2f, 0xff, 0xd3, 0xd4, 0xbe, 0x92, 0xda, 0xc2, 0x79, 0x1b, 0x0a, 0x9e, 0xf6,
-	0x95, 0xac, 0x11, 0x7d, 0x15, 0x56, 0xe2, 0x34, 0xdb, 0x5f, 0x00, 0xac, 0xf5, 0x67, 0xfc, 0x68,
-	0xba, 0x85, 0xb7, 0xeb, 0x09, 0xac, 0x01, 0x6b, 0xe3, 0xf8, 0x42, 0x92, 0xe6, 0xa5, 0x59, 0x79,
-	0xd9, 0xfb, 0x5d, 0x82, 0xda, 0x30, 0x1b, 0x0a, 0xb4, 0x0b, 0x35, 0x8b, 0x50, 0x16, 0x48, 0x22,
-	0x10, 0x2a, 0xf4, 0x3f, 0x79, 0x87, 0xcd, 0x9b, 0x05, 0x2c, 0x4e, 0x17, 0xf5, 0xe0, 0xce, 0xf3,
-	0x50, 0x1e, 0x73, 0xc1, 0x3e, 0x91, 0x6d, 0x35, 0xcf, 0x60, 0xfd, 0x0d, 0x91, 0x9b, 0x37, 0x73,
-	0xa7, 0xc0, 0xc8, 0xe1, 0xe6, 0x5d, 0x23, 0x9d, 0x41, 0x23, 0x9f, 0x2e, 0xe3, 0x45, 0x3c, 0x83,
-	0x68, 0x0f, 0xaa, 0x23, 0xdf, 0xb1, 0x25, 0x41, 0x17, 0x8f, 0xbe, 0x54, 0xf4, 0x18, 0x56, 0x93,
-	0xc4, 0x51, 0xf1, 0x59, 0x67, 0x5d, 0xb8, 0x54, 0xd6, 0x85, 0xe5, 0x57, 0x44, 0xa2, 0x5b, 0x17,
-	0x2e, 0x1a, 0x0e, 0xfe, 0x1d, 0x46, 0xe5, 0x80, 0x05, 0x5b, 0xf3, 0x3b, 0xe0, 0x11, 0xe8, 0xef,
-	0x2e, 0xce, 0xb0, 0xb2, 0x3c, 0xc3, 0xca, 0x22, 0xc2, 0x60, 0x19, 0x61, 0xf0, 0x2b, 0xc2, 0xe0,
-	0xf3, 0x1a, 0x2b, 0x27, 0x6b, 0xac, 0xfc, 0x58, 0x63, 0xb0, 0x5c, 0x63, 0xe5, 0xe7, 0x1a, 0x2b,
-	0x6f, 0x6b, 0xfe, 0x94, 0x9a, 0xb6, 0xcf, 0xc6, 0x6a, 0xe2, 0x72, 0xef, 0x4f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x7f, 0x5e, 0xf6, 0xcd, 0xce, 0x04, 0x00, 0x00,
+	0x13, 0xb7, 0x8b, 0x82, 0xe0, 0xc5, 0x52, 0x75, 0x03, 0x7b, 0x90, 0x48, 0x61, 0xf1, 0x22, 0xe9,
+	0x66, 0x9a, 0x1d, 0xda, 0x64, 0xe2, 0x64, 0x62, 0xd1, 0x5f, 0xa0, 0xb7, 0x1e, 0x3c, 0xf8, 0x03,
+	0xfc, 0x21, 0x1e, 0x7b, 0xec, 0xd1, 0x53, 0xd7, 0x4d, 0xff, 0x87, 0x48, 0xbe, 0xea, 0x86, 0xb5,
+	0xb4, 0x78, 0xca, 0xbc, 0xcf, 0x3c, 0xcf, 0xcc, 0x93, 0xe7, 0x9d, 0x17, 0xde, 0xa0, 0x36, 0xf1,
+	0x04, 0x15, 0x1f, 0x35, 0x9f, 0x33, 0xc1, 0x50, 0x9d, 0x8a, 0x70, 0x44, 0xb4, 0x30, 0x20, 0x3c,
+	0x68, 0xc2, 0xf8, 0x93, 0x6e, 0x34, 0xef, 0x39, 0x8c, 0x39, 0x53, 0xa2, 0x27, 0xd5, 0x28, 0x1c,
+	0xeb, 0xc4, 0xf5, 0x73, 0x55, 0xb3, 0xeb, 0x50, 0x71, 0x1e, 0x8e, 0xb4, 0x33, 0xe6, 0xea, 0x0e,
+	0x73, 0xd8, 0x5f, 0x56, 0x5c, 0x25, 0x45, 0xb2, 0xca, 0xe8, 0x4f, 0xae, 0xd0, 0xdd, 0x19, 0x15,
+	0x13, 0x36, 0xd3, 0x1d, 0xd6, 0x4d, 0x36, 0xbb, 0x1f, 0xac, 0x29, 0xb5, 0x2d, 0xc1, 0x78, 0xa0,
+	0x6f, 0x96, 0xa9, 0xae, 0xfd, 0x1d, 0xc0, 0xea, 0x09, 0x73, 0xa8, 0x87, 0x30, 0x94, 0x6d, 0xe6,
+	0x5a, 0xd4, 0x6b, 0x00, 0x15, 0x74, 0x0e, 0xfa, 0x72, 0x74, 0xd1, 0x2a, 0x9d, 0x02, 0x33, 0x43,
+	0xd1, 0x7d, 0x58, 0x25, 0xae, 0x45, 0xa7, 0x8d, 0x52, 0x61, 0x3b, 0x05, 0x51, 0x1b, 0x2a, 0xbe,
+	0x15, 0x04, 0x33, 0xc6, 0xed, 0x46, 0xb9, 0x40, 0xd8, 0xe0, 0xe8, 0x29, 0x54, 0x38, 0x71, 0xde,
+	0x51, 0x6f, 0xcc, 0x1a, 0x50, 0x05, 0x9d, 0x7a, 0xef, 0xb6, 0x76, 0x25, 0x1b, 0xcd, 0x24, 0x8e,
+	0xe1, 0x8d, 0x59, 0x5f, 0x59, 0xac, 0x5a, 0xd2, 0x72, 0xd5, 0x02, 0x66, 0x8d, 0xa7, 0x50, 0xfb,
+	0x2b, 0x80, 0xca, 0xeb, 0xfc, 0x9c, 0x5d, 0x4e, 0x07, 0xb0, 0x1c, 0x52, 0x3b, 0xf3, 0xd9, 0x8b,
+	0x56, 0xad, 0xf2, 0xd0, 0x18, 0x44, 0x17, 0xad, 0x07, 0x73, 0x50, 0x79, 0xa8, 0x52, 0x2f, 0xc9,
+	0x40, 0x0d, 0x3d, 0xfa, 0x3e, 0x24, 0x6a, 0xda, 0xac, 0x31, 0x25, 0x5c, 0x1d, 0x33, 0xee, 0x5a,
+	0xe2, 0x14, 0x98, 0xb1, 0x7c, 0x9f, 0x3f, 0x6a, 0x7f, 0x03, 0x50, 0x1e, 0x06, 0x84, 0x1b, 0x83,
+	0x9d, 0xa6, 0x5e, 0x6e, 0x33, 0xb5, 0x8f, 0xa3, 0x39, 0xa8, 0x1c, 0x4b, 0xa9, 0x2d, 0x9c, 0xb7,
+	0xa1, 0xe0, 0xe9, 0x58, 0xca, 0x1a, 0xd1, 0x97, 0x61, 0x25, 0x4e, 0xb3, 0xfd, 0x05, 0xc0, 0x5a,
+	0x7f, 0xca, 0xce, 0x26, 0xc6, 0xe0, 0xff, 0x03, 0x4b, 0x6e, 0xde, 0x6d, 0x30, 0x75, 0xd6, 0x80,
+	0xb5, 0x51, 0x7c, 0x21, 0x49, 0xf3, 0x52, 0xcc, 0xbc, 0xec, 0xfd, 0x2e, 0x41, 0xc5, 0xc8, 0x86,
+	0x02, 0x1d, 0x42, 0xc5, 0x24, 0x0e, 0x0d, 0x04, 0xe1, 0x08, 0x15, 0xfa, 0x9f, 0xbc, 0xc3, 0xe6,
+	0xcd, 0x02, 0x16, 0xa7, 0x8b, 0x7a, 0xf0, 0xe0, 0x79, 0x28, 0xce, 0x19, 0xa7, 0x9f, 0xc8, 0xbe,
+	0x9a, 0x67, 0xb0, 0xfe, 0x86, 0x88, 0xcd, 0x9b, 0xb9, 0x53, 0x60, 0xe4, 0x70, 0xf3, 0xae, 0x96,
+	0xce, 0xa0, 0x96, 0x4f, 0x97, 0xf6, 0x22, 0x9e, 0x41, 0x74, 0x04, 0xe5, 0xa1, 0x6f, 0x5b, 0x82,
+	0xa0, 0xeb, 0x47, 0x6f, 0x15, 0x3d, 0x86, 0xd5, 0x24, 0x71, 0x54, 0x7c, 0xd6, 0x59, 0x17, 0xb6,
+	0xca, 0xba, 0xb0, 0xfc, 0x8a, 0x08, 0x74, 0xeb, 0xda, 0x45, 0xc6, 0xe0, 0xdf, 0x61, 0x54, 0x4e,
+	0x68, 0xb0, 0x37, 0xbf, 0x03, 0x1e, 0x81, 0xfe, 0xe1, 0xe2, 0x12, 0x4b, 0xcb, 0x4b, 0x2c, 0x2d,
+	0x22, 0x0c, 0x96, 0x11, 0x06, 0xbf, 0x22, 0x0c, 0x3e, 0xaf, 0xb1, 0x34, 0x5f, 0x63, 0xe9, 0xc7,
+	0x1a, 0x83, 0xe5, 0x1a, 0x4b, 0x3f, 0xd7, 0x58, 0x7a, 0x5b, 0xf3, 0x27, 0x8e, 0x6e, 0xf9, 0x74,
+	0x24, 0x27, 0x2e, 0x8f, 0xfe, 0x0c, 0x00, 0x34, 0x46, 0x3c, 0x45, 0xce, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -320,30 +320,68 @@ const _ = grpc.SupportPackageIsVersion4
 type IdentityClient interface {
 	// Register регистрирует и возвращает информацию о пользователе.
 	//
-	// Если пользователь уже зарегистрирован, но пароль для него не установлен, то
-	// устанавливает новый пароль. Данный случай возникает, если до этого
-	// пользователь был зарегистрирован через внешнего провайдера.
+	// Если пользователь уже зарегистрирован через внешнего провайдера и пароль
+	// для него не установлен, то пароль не устанавливается: адрес мог быть не
+	// подтвержден провайдером. Вместо этого владельцу адреса отправляется письмо
+	// для сброса пароля, и пароль задается через Tokens.ResetPassword. Если до
+	// этого адрес не был подтвержден, то внешние провайдеры от пользователя
+	// отвязываются.
+	//
+	// Для закрытых доменов ответ не зависит от того, был ли пользователь
+	// зарегистрирован ранее: возвращаются только домен и email. Новому
+	// пользователю отправляется письмо для подтверждения почтового адреса, а уже
+	// зарегистрированному с паролем — письмо о попытке повторной регистрации.
+	//
+	// Пароль проверяется на соответствие политике паролей домена. При нарушении
+	// возвращается ошибка InvalidArgument, в деталях которой передаются
+	// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
 	//
 	// Возвращает ошибки:
 	//  - AlreadyExists - пользователь уже зарегистрирован и у него задан пароль
+	//  - FailedPrecondition - пользователь зарегистрирован без пароля: пароль
+	//    задается после подтверждения адреса через сброс пароля
 	//  - NotFound - пользователь заблокирован
-	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - InvalidArgument - неверный формат данных или пароль не соответствует политике
 	//  - Internal - внутренние ошибки
 	Register(ctx context.Context, in *Login, opts ...grpc.CallOption) (*User, error)
 	// Authorize авторизует пользователя по логину (email) и паролю. Возвращает
 	// информацию о пользователе в случае успешной авторизации. В противном случае
-	// возвращает ошибку.
+	// возвращает ошибку. Вместе с информацией о пользователе возвращаются токены
+	// созданной сессии.
+	//
+	// Если у пользователя включена двухфакторная авторизация, то возвращается
+	// ошибка FailedPrecondition, в деталях которой передается Challenge с токеном
+	// для завершения авторизации через TwoFactor.Authorize.
+	//
+	// Неудачные попытки авторизации подсчитываются для почтового адреса и
+	// IP-адреса клиента. После превышения ограничения авторизация временно
+	// блокируется и возвращается ошибка ResourceExhausted, в деталях которой
+	// передается RetryInfo со временем до окончания блокировки.
+	//
+	// Для закрытых доменов вместо NotFound и InvalidArgument возвращается
+	// единая ошибка Unauthenticated.
 	//
 	// Возвращает ошибки:
+	//  - Unauthenticated - неверный email или пароль (для закрытых доменов)
 	//  - NotFound - пользователь не зарегистрирован или блокирован
+	//  - FailedPrecondition - требуется второй фактор авторизации
 	//  - InvalidArgument - неверный пароль пользователя
+	//  - ResourceExhausted - слишком много неудачных попыток авторизации
 	//  - Internal - внутренние ошибки
 	Authorize(ctx context.Context, in *Login, opts ...grpc.CallOption) (*User, error)
 	// SetPassword заменяет пароль пользователя. Возвращает ошибку, если
-	// пользователь не зарегистрирован.
+	// пользователь не зарегистрирован. Все сессии пользователя при этом
+	// завершаются.
+	//
+	// Пароль проверяется на соответствие политике паролей домена. При нарушении
+	// возвращается ошибка InvalidArgument, в деталях которой передаются
+	// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
+	// Новый пароль так же не должен совпадать с последними паролями
+	// пользователя, если это задано политикой домена.
 	//
 	// Возвращает ошибки:
 	//  - NotFound - пользователь не зарегистрирован
+	//  - InvalidArgument - пароль не соответствует политике
 	//  - Internal - внутренние ошибки
 	SetPassword(ctx context.Context, in *Password, opts ...grpc.CallOption) (*types.Empty, error)
 	// Update обновляет информацию о пользователе. Возвращает ошибку,
@@ -358,7 +396,8 @@ type IdentityClient interface {
 	Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*types.Empty, error)
 	// Block используется для блокировки/разблокировки пользователя.
 	// Заблокированный пользователь продолжает оставаться зарегистрированных,
-	// но не может авторизоваться.
+	// но не может авторизоваться. При блокировке все сессии пользователя
+	// завершаются.
 	//
 	// Возвращает ошибки:
 	//  - NotFound - пользователь не зарегистрирован
@@ -474,30 +513,68 @@ func (x *identityListClient) Recv() (*User, error) {
 type IdentityServer interface {
 	// Register регистрирует и возвращает информацию о пользователе.
 	//
-	// Если пользователь уже зарегистрирован, но пароль для него не установлен, то
-	// устанавливает новый пароль. Данный случай возникает, если до этого
-	// пользователь был зарегистрирован через внешнего провайдера.
+	// Если пользователь уже зарегистрирован через внешнего провайдера и пароль
+	// для него не установлен, то пароль не устанавливается: адрес мог быть не
+	// подтвержден провайдером. Вместо этого владельцу адреса отправляется письмо
+	// для сброса пароля, и пароль задается через Tokens.ResetPassword. Если до
+	// этого адрес не был подтвержден, то внешние провайдеры от пользователя
+	// отвязываются.
+	//
+	// Для закрытых доменов ответ не зависит от того, был ли пользователь
+	// зарегистрирован ранее: возвращаются только домен и email. Новому
+	// пользователю отправляется письмо для подтверждения почтового адреса, а уже
+	// зарегистрированному с паролем — письмо о попытке повторной регистрации.
+	//
+	// Пароль проверяется на соответствие политике паролей домена. При нарушении
+	// возвращается ошибка InvalidArgument, в деталях которой передаются
+	// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
 	//
 	// Возвращает ошибки:
 	//  - AlreadyExists - пользователь уже зарегистрирован и у него задан пароль
+	//  - FailedPrecondition - пользователь зарегистрирован без пароля: пароль
+	//    задается после подтверждения адреса через сброс пароля
 	//  - NotFound - пользователь заблокирован
-	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - InvalidArgument - неверный формат данных или пароль не соответствует политике
 	//  - Internal - внутренние ошибки
 	Register(context.Context, *Login) (*User, error)
 	// Authorize авторизует пользователя по логину (email) и паролю. Возвращает
 	// информацию о пользователе в случае успешной авторизации. В противном случае
-	// возвращает ошибку.
+	// возвращает ошибку. Вместе с информацией о пользователе возвращаются токены
+	// созданной сессии.
+	//
+	// Если у пользователя включена двухфакторная авторизация, то возвращается
+	// ошибка FailedPrecondition, в деталях которой передается Challenge с токеном
+	// для завершения авторизации через TwoFactor.Authorize.
+	//
+	// Неудачные попытки авторизации подсчитываются для почтового адреса и
+	// IP-адреса клиента. После превышения ограничения авторизация временно
+	// блокируется и возвращается ошибка ResourceExhausted, в деталях которой
+	// передается RetryInfo со временем до окончания блокировки.
+	//
+	// Для закрытых доменов вместо NotFound и InvalidArgument возвращается
+	// единая ошибка Unauthenticated.
 	//
 	// Возвращает ошибки:
+	//  - Unauthenticated - неверный email или пароль (для закрытых доменов)
 	//  - NotFound - пользователь не зарегистрирован или блокирован
+	//  - FailedPrecondition - требуется второй фактор авторизации
 	//  - InvalidArgument - неверный пароль пользователя
+	//  - ResourceExhausted - слишком много неудачных попыток авторизации
 	//  - Internal - внутренние ошибки
 	Authorize(context.Context, *Login) (*User, error)
 	// SetPassword заменяет пароль пользователя. Возвращает ошибку, если
-	// пользователь не зарегистрирован.
+	// пользователь не зарегистрирован. Все сессии пользователя при этом
+	// завершаются.
+	//
+	// Пароль проверяется на соответствие политике паролей домена. При нарушении
+	// возвращается ошибка InvalidArgument, в деталях которой передаются
+	// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
+	// Новый пароль так же не должен совпадать с последними паролями
+	// пользователя, если это задано политикой домена.
 	//
 	// Возвращает ошибки:
 	//  - NotFound - пользователь не зарегистрирован
+	//  - InvalidArgument - пароль не соответствует политике
 	//  - Internal - внутренние ошибки
 	SetPassword(context.Context, *Password) (*types.Empty, error)
 	// Update обновляет информацию о пользователе. Возвращает ошибку,
@@ -512,7 +589,8 @@ type IdentityServer interface {
 	Update(context.Context, *User) (*types.Empty, error)
 	// Block используется для блокировки/разблокировки пользователя.
 	// Заблокированный пользователь продолжает оставаться зарегистрированных,
-	// но не может авторизоваться.
+	// но не может авторизоваться. При блокировке все сессии пользователя
+	// завершаются.
 	//
 	// Возвращает ошибки:
 	//  - NotFound - пользователь не зарегистрирован
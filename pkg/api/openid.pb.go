@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types "github.com/gogo/protobuf/types"
 	golang_proto "github.com/golang/protobuf/proto"
 	_ "github.com/mwitkow/go-proto-validators"
 	grpc "google.golang.org/grpc"
@@ -16,6 +18,7 @@ import (
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -23,6 +26,7 @@ var _ = proto.Marshal
 var _ = golang_proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -37,11 +41,13 @@ type Provider struct {
 	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
 	// уникальный идентификатор провайдера авторизации
 	// должен совпадать с названием, используемым при конфигурации сервиса
-	// сейчас из провайдеров поддерживается только "google"
+	// список поддерживаемых провайдеров возвращает ListProviders
 	Provider string `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
 	// url для возврата после авторизации
 	// данный url должен быть зарегистрирован и указан в списке допустимых
-	// на сервере провайдера авторизации, иначе будет возвращаться ошибка
+	// на сервере провайдера авторизации, иначе будет возвращаться ошибка;
+	// так же, если на сервисе заданы списки допустимых адресов для домена или
+	// провайдера, то адрес должен им соответствовать
 	RedirectURI string `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
 	// дополнительные необязательные параметры, используемые при авторизации
 	// например: login_hint, hd, display
@@ -128,9 +134,10 @@ var xxx_messageInfo_LoginURL proto.InternalMessageInfo
 
 // AuthCode заполняется ответом от сервера авторизации.
 //
-// На данный момент в качестве провайдера поддерживается только "google".
 // state и code возвращаются в обратном редиректе с сервера провайдера после
-// авторизации в виде именованных параметров url.
+// авторизации в виде именованных параметров url, а для провайдеров с
+// response_mode=form_post — в виде полей формы POST-запроса на адрес для
+// возврата.
 type AuthCode struct {
 	// домен сайта
 	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
@@ -141,6 +148,12 @@ type AuthCode struct {
 	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
 	// code из параметров URL ответа
 	Code string `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	// данные пользователя в формате JSON, которые провайдер передает только
+	// при первой авторизации (например, поле user от Apple)
+	User string `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	// идентификатор авторизованного пользователя; обязателен, если
+	// авторизация начата через Link, и должен совпадать с указанным в нем
+	UID string `protobuf:"bytes,6,opt,name=uid,proto3" json:"uid,omitempty"`
 }
 
 func (m *AuthCode) Reset()         { *m = AuthCode{} }
@@ -176,447 +189,4401 @@ func (m *AuthCode) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AuthCode proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*Provider)(nil), "itube.users.Provider")
-	golang_proto.RegisterType((*Provider)(nil), "itube.users.Provider")
-	proto.RegisterMapType((map[string]string)(nil), "itube.users.Provider.ParamsEntry")
-	golang_proto.RegisterMapType((map[string]string)(nil), "itube.users.Provider.ParamsEntry")
-	proto.RegisterType((*LoginURL)(nil), "itube.users.LoginURL")
-	golang_proto.RegisterType((*LoginURL)(nil), "itube.users.LoginURL")
-	proto.RegisterType((*AuthCode)(nil), "itube.users.AuthCode")
-	golang_proto.RegisterType((*AuthCode)(nil), "itube.users.AuthCode")
+// ProvidersRequest задает запрос списка провайдеров авторизации.
+type ProvidersRequest struct {
+	// домен сайта
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
 }
 
-func init() { proto.RegisterFile("openid.proto", fileDescriptor_341b5f7d56cf065a) }
-func init() { golang_proto.RegisterFile("openid.proto", fileDescriptor_341b5f7d56cf065a) }
-
-var fileDescriptor_341b5f7d56cf065a = []byte{
-	// 490 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0x4f, 0x6b, 0xdb, 0x4c,
-	0x10, 0xc6, 0xb5, 0xfe, 0x17, 0x65, 0x94, 0x17, 0xde, 0x2e, 0x09, 0x08, 0x51, 0x56, 0xae, 0x4f,
-	0xbe, 0x58, 0xa6, 0x2e, 0x29, 0x71, 0x6f, 0x75, 0xdb, 0x83, 0xa9, 0xa1, 0x61, 0xc1, 0x50, 0x7a,
-	0x09, 0xb2, 0xb5, 0x56, 0x16, 0xdb, 0x5a, 0xb1, 0x5a, 0x39, 0xa4, 0x9f, 0x20, 0xc7, 0x7e, 0x9d,
-	0xde, 0x7a, 0xf4, 0xd1, 0xc7, 0x9e, 0xdc, 0x46, 0xfe, 0x22, 0xc5, 0x2b, 0xd9, 0xd8, 0xa5, 0xd0,
-	0x4b, 0x4f, 0x9a, 0x79, 0xe6, 0xf7, 0x08, 0x3d, 0x33, 0x82, 0x33, 0x11, 0xb3, 0x88, 0x07, 0x5e,
-	0x2c, 0x85, 0x12, 0xd8, 0xe2, 0x2a, 0x1d, 0x31, 0x2f, 0x4d, 0x98, 0x4c, 0x1c, 0xd8, 0x3e, 0xf2,
-	0x81, 0xd3, 0x0a, 0xb9, 0xba, 0x4d, 0x47, 0xde, 0x58, 0xcc, 0xdb, 0xa1, 0x08, 0x45, 0x5b, 0xcb,
-	0xa3, 0x74, 0xa2, 0x3b, 0xdd, 0xe8, 0xaa, 0xc0, 0x5f, 0x1e, 0xe0, 0xf3, 0x3b, 0xae, 0xa6, 0xe2,
-	0xae, 0x1d, 0x8a, 0x96, 0x1e, 0xb6, 0x16, 0xfe, 0x8c, 0x07, 0xbe, 0x12, 0x32, 0x69, 0xef, 0xcb,
-	0xdc, 0xd7, 0xf8, 0x5a, 0x02, 0xf3, 0x5a, 0x8a, 0x05, 0x0f, 0x98, 0xc4, 0x04, 0x6a, 0x81, 0x98,
-	0xfb, 0x3c, 0xb2, 0x51, 0x1d, 0x35, 0x4f, 0x7b, 0xb5, 0xec, 0x87, 0x5b, 0xfa, 0x88, 0x68, 0xa1,
-	0xe2, 0x06, 0x98, 0x71, 0xc1, 0xda, 0xa5, 0x23, 0x62, 0xaf, 0xe3, 0x2b, 0x38, 0x93, 0x2c, 0xe0,
-	0x92, 0x8d, 0xd5, 0x4d, 0x2a, 0xb9, 0x5d, 0xd6, 0xdc, 0x45, 0xb6, 0x76, 0x2d, 0x5a, 0xe8, 0x43,
-	0xda, 0x2f, 0x6c, 0xd6, 0x0e, 0x1d, 0x4a, 0x8e, 0xbb, 0x50, 0x8b, 0x7d, 0xe9, 0xcf, 0x13, 0xbb,
-	0x52, 0x2f, 0x37, 0xad, 0xce, 0x33, 0xef, 0x60, 0x37, 0xde, 0xee, 0x23, 0xbd, 0x6b, 0xcd, 0xbc,
-	0x8b, 0x94, 0xbc, 0xa7, 0x85, 0x01, 0x77, 0xc1, 0x94, 0x2c, 0xbc, 0xe1, 0xd1, 0x44, 0xd8, 0x50,
-	0x47, 0x4d, 0xab, 0x73, 0x7e, 0x64, 0xa6, 0x2c, 0xec, 0x47, 0x13, 0xd1, 0x33, 0x97, 0x6b, 0xd7,
-	0x58, 0xad, 0x5d, 0x44, 0x4f, 0x64, 0x2e, 0x39, 0x5d, 0xb0, 0x0e, 0xde, 0x88, 0xff, 0x87, 0xf2,
-	0x94, 0xdd, 0xe7, 0xf9, 0xe9, 0xb6, 0xc4, 0xe7, 0x50, 0x5d, 0xf8, 0xb3, 0x94, 0xe5, 0x89, 0x69,
-	0xde, 0xbc, 0x2a, 0x5d, 0xa1, 0xc6, 0x7b, 0x30, 0x07, 0x22, 0xe4, 0xd1, 0x90, 0x0e, 0xfe, 0xba,
-	0x3a, 0x17, 0xca, 0xa9, 0x9c, 0x15, 0x5b, 0xfb, 0x2f, 0x5b, 0xbb, 0xe5, 0x21, 0x1d, 0x14, 0xcc,
-	0x76, 0xd2, 0x78, 0x40, 0x60, 0xbe, 0x4e, 0xd5, 0xed, 0x1b, 0x11, 0xb0, 0x7f, 0x72, 0x88, 0xa7,
-	0x50, 0x4d, 0x94, 0xaf, 0x58, 0x71, 0x81, 0x1d, 0x90, 0x8b, 0xd8, 0x81, 0xca, 0x58, 0x04, 0xcc,
-	0xae, 0x1c, 0x0d, 0xb5, 0xd6, 0x59, 0x40, 0xed, 0x43, 0xcc, 0xa2, 0xfe, 0x5b, 0x7c, 0x09, 0x55,
-	0x9d, 0x10, 0x5f, 0xfc, 0xf1, 0x16, 0xce, 0xb1, 0xbc, 0x5f, 0xc6, 0x25, 0x9c, 0x6e, 0xa3, 0x08,
-	0xc9, 0x3f, 0xb3, 0xdf, 0xac, 0xbb, 0x88, 0xce, 0x93, 0x23, 0x79, 0x98, 0x30, 0xd9, 0x7b, 0xbe,
-	0x7c, 0x24, 0xc6, 0xea, 0x91, 0x18, 0xcb, 0x8c, 0xa0, 0x55, 0x46, 0xd0, 0xcf, 0x8c, 0xa0, 0x87,
-	0x0d, 0x31, 0xbe, 0x6c, 0x88, 0xf1, 0x6d, 0x43, 0xd0, 0x6a, 0x43, 0x8c, 0xef, 0x1b, 0x62, 0x7c,
-	0x3a, 0x89, 0xa7, 0x61, 0xdb, 0x8f, 0xf9, 0xa8, 0xa6, 0xff, 0xe2, 0x17, 0xbf, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x00, 0xc3, 0x20, 0x1f, 0x55, 0x03, 0x00, 0x00,
+func (m *ProvidersRequest) Reset()         { *m = ProvidersRequest{} }
+func (m *ProvidersRequest) String() string { return proto.CompactTextString(m) }
+func (*ProvidersRequest) ProtoMessage()    {}
+func (*ProvidersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{3}
+}
+func (m *ProvidersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProvidersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProvidersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProvidersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProvidersRequest.Merge(m, src)
+}
+func (m *ProvidersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProvidersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProvidersRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_ProvidersRequest proto.InternalMessageInfo
 
-// OpenIDClient is the client API for OpenID service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type OpenIDClient interface {
-	// Login выдает URL для перехода на авторизацию к провайдеру.
-	//
-	// Возвращает InvalidArgument, если указан неподдерживаемый идентификатор
-	// провайдера авторизации.
-	Login(ctx context.Context, in *Provider, opts ...grpc.CallOption) (*LoginURL, error)
-	// Authorize проверяет авторизацию и возвращает информацию об
-	// авторизованном пользователе. Если пользователь не зарегистрирован,
-	// то происходит его автоматическая регистрация.
-	//
-	// Возвращает ошибки:
-	//  - NotFound - пользователь заблокирован
-	//  - InvalidArgument - неверный формат данных входящего запроса
-	//  - Internal - внутренние ошибки
-	Authorize(ctx context.Context, in *AuthCode, opts ...grpc.CallOption) (*User, error)
+// ProviderInfo описывает провайдера авторизации.
+type ProviderInfo struct {
+	// уникальный идентификатор провайдера авторизации, используемый в запросах
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// отображаемое название провайдера
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 }
 
-type openIDClient struct {
-	cc *grpc.ClientConn
+func (m *ProviderInfo) Reset()         { *m = ProviderInfo{} }
+func (m *ProviderInfo) String() string { return proto.CompactTextString(m) }
+func (*ProviderInfo) ProtoMessage()    {}
+func (*ProviderInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{4}
 }
-
-func NewOpenIDClient(cc *grpc.ClientConn) OpenIDClient {
-	return &openIDClient{cc}
+func (m *ProviderInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *openIDClient) Login(ctx context.Context, in *Provider, opts ...grpc.CallOption) (*LoginURL, error) {
-	out := new(LoginURL)
-	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Login", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ProviderInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProviderInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
 }
-
-func (c *openIDClient) Authorize(ctx context.Context, in *AuthCode, opts ...grpc.CallOption) (*User, error) {
-	out := new(User)
-	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Authorize", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ProviderInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProviderInfo.Merge(m, src)
 }
-
-// OpenIDServer is the server API for OpenID service.
-type OpenIDServer interface {
-	// Login выдает URL для перехода на авторизацию к провайдеру.
-	//
-	// Возвращает InvalidArgument, если указан неподдерживаемый идентификатор
-	// провайдера авторизации.
-	Login(context.Context, *Provider) (*LoginURL, error)
-	// Authorize проверяет авторизацию и возвращает информацию об
-	// авторизованном пользователе. Если пользователь не зарегистрирован,
-	// то происходит его автоматическая регистрация.
-	//
-	// Возвращает ошибки:
-	//  - NotFound - пользователь заблокирован
-	//  - InvalidArgument - неверный формат данных входящего запроса
-	//  - Internal - внутренние ошибки
-	Authorize(context.Context, *AuthCode) (*User, error)
+func (m *ProviderInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProviderInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProviderInfo.DiscardUnknown(m)
 }
 
-// UnimplementedOpenIDServer can be embedded to have forward compatible implementations.
-type UnimplementedOpenIDServer struct {
+var xxx_messageInfo_ProviderInfo proto.InternalMessageInfo
+
+// ProviderList возвращает список поддерживаемых провайдеров авторизации.
+type ProviderList struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// провайдеры авторизации
+	Providers []*ProviderInfo `protobuf:"bytes,2,rep,name=providers,proto3" json:"providers,omitempty"`
 }
 
-func (*UnimplementedOpenIDServer) Login(ctx context.Context, req *Provider) (*LoginURL, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+func (m *ProviderList) Reset()         { *m = ProviderList{} }
+func (m *ProviderList) String() string { return proto.CompactTextString(m) }
+func (*ProviderList) ProtoMessage()    {}
+func (*ProviderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{5}
 }
-func (*UnimplementedOpenIDServer) Authorize(ctx context.Context, req *AuthCode) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+func (m *ProviderList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func RegisterOpenIDServer(s *grpc.Server, srv OpenIDServer) {
-	s.RegisterService(&_OpenID_serviceDesc, srv)
+func (m *ProviderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProviderList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProviderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProviderList.Merge(m, src)
+}
+func (m *ProviderList) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProviderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProviderList.DiscardUnknown(m)
 }
 
-func _OpenID_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Provider)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(OpenIDServer).Login(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/itube.users.OpenID/Login",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OpenIDServer).Login(ctx, req.(*Provider))
-	}
-	return interceptor(ctx, in, info, handler)
+var xxx_messageInfo_ProviderList proto.InternalMessageInfo
+
+// IdentitiesRequest задает запрос списка привязанных провайдеров авторизации.
+type IdentitiesRequest struct {
+	// домен сайта
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
 }
 
-func _OpenID_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthCode)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(OpenIDServer).Authorize(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/itube.users.OpenID/Authorize",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OpenIDServer).Authorize(ctx, req.(*AuthCode))
+func (m *IdentitiesRequest) Reset()         { *m = IdentitiesRequest{} }
+func (m *IdentitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*IdentitiesRequest) ProtoMessage()    {}
+func (*IdentitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{6}
+}
+func (m *IdentitiesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IdentitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IdentitiesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *IdentitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentitiesRequest.Merge(m, src)
+}
+func (m *IdentitiesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *IdentitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentitiesRequest.DiscardUnknown(m)
 }
 
-var _OpenID_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "itube.users.OpenID",
-	HandlerType: (*OpenIDServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Login",
-			Handler:    _OpenID_Login_Handler,
-		},
-		{
-			MethodName: "Authorize",
-			Handler:    _OpenID_Authorize_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "openid.proto",
+var xxx_messageInfo_IdentitiesRequest proto.InternalMessageInfo
+
+// LinkedIdentity описывает привязку пользователя к провайдеру авторизации.
+type LinkedIdentity struct {
+	// уникальный идентификатор провайдера авторизации
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// идентификатор пользователя у провайдера
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// дата и время привязки
+	Created time.Time `protobuf:"bytes,3,opt,name=created,proto3,stdtime" json:"created"`
 }
 
-func (m *Provider) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LinkedIdentity) Reset()         { *m = LinkedIdentity{} }
+func (m *LinkedIdentity) String() string { return proto.CompactTextString(m) }
+func (*LinkedIdentity) ProtoMessage()    {}
+func (*LinkedIdentity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{7}
+}
+func (m *LinkedIdentity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LinkedIdentity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LinkedIdentity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *LinkedIdentity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LinkedIdentity.Merge(m, src)
+}
+func (m *LinkedIdentity) XXX_Size() int {
+	return m.Size()
+}
+func (m *LinkedIdentity) XXX_DiscardUnknown() {
+	xxx_messageInfo_LinkedIdentity.DiscardUnknown(m)
 }
 
-func (m *Provider) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_LinkedIdentity proto.InternalMessageInfo
+
+// IdentityList возвращает список привязанных провайдеров авторизации.
+type IdentityList struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// привязанные провайдеры авторизации
+	Identities []*LinkedIdentity `protobuf:"bytes,3,rep,name=identities,proto3" json:"identities,omitempty"`
 }
 
-func (m *Provider) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.RegInfo.MarshalToSizedBuffer(dAtA[:i])
+func (m *IdentityList) Reset()         { *m = IdentityList{} }
+func (m *IdentityList) String() string { return proto.CompactTextString(m) }
+func (*IdentityList) ProtoMessage()    {}
+func (*IdentityList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{8}
+}
+func (m *IdentityList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IdentityList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IdentityList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintOpenid(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0x52
-	if len(m.Params) > 0 {
-		for k := range m.Params {
-			v := m.Params[k]
-			baseI := i
-			i -= len(v)
-			copy(dAtA[i:], v)
-			i = encodeVarintOpenid(dAtA, i, uint64(len(v)))
-			i--
-			dAtA[i] = 0x12
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintOpenid(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintOpenid(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.RedirectURI) > 0 {
-		i -= len(m.RedirectURI)
-		copy(dAtA[i:], m.RedirectURI)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.RedirectURI)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Provider) > 0 {
-		i -= len(m.Provider)
-		copy(dAtA[i:], m.Provider)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Domain) > 0 {
-		i -= len(m.Domain)
-		copy(dAtA[i:], m.Domain)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
 }
-
-func (m *LoginURL) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *IdentityList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityList.Merge(m, src)
+}
+func (m *IdentityList) XXX_Size() int {
+	return m.Size()
+}
+func (m *IdentityList) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityList.DiscardUnknown(m)
 }
 
-func (m *LoginURL) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_IdentityList proto.InternalMessageInfo
+
+// LinkRequest задает запрос на привязку провайдера авторизации к
+// пользователю.
+type LinkRequest struct {
+	// домен сайта
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// уникальный идентификатор провайдера авторизации
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	// url для возврата после авторизации
+	RedirectURI string `protobuf:"bytes,4,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	// дополнительные необязательные параметры, используемые при авторизации
+	Params map[string]string `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (m *LoginURL) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.URL) > 0 {
-		i -= len(m.URL)
-		copy(dAtA[i:], m.URL)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.URL)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Domain) > 0 {
-		i -= len(m.Domain)
-		copy(dAtA[i:], m.Domain)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
-		i--
-		dAtA[i] = 0xa
+func (m *LinkRequest) Reset()         { *m = LinkRequest{} }
+func (m *LinkRequest) String() string { return proto.CompactTextString(m) }
+func (*LinkRequest) ProtoMessage()    {}
+func (*LinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{9}
+}
+func (m *LinkRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LinkRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *LinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LinkRequest.Merge(m, src)
+}
+func (m *LinkRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LinkRequest.DiscardUnknown(m)
 }
 
-func (m *AuthCode) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LinkRequest proto.InternalMessageInfo
+
+// UnlinkRequest задает запрос на отвязку провайдера авторизации от
+// пользователя.
+type UnlinkRequest struct {
+	// домен сайта
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// уникальный идентификатор провайдера авторизации
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+}
+
+func (m *UnlinkRequest) Reset()         { *m = UnlinkRequest{} }
+func (m *UnlinkRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlinkRequest) ProtoMessage()    {}
+func (*UnlinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{10}
+}
+func (m *UnlinkRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnlinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnlinkRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *UnlinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlinkRequest.Merge(m, src)
+}
+func (m *UnlinkRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnlinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlinkRequest.DiscardUnknown(m)
 }
 
-func (m *AuthCode) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_UnlinkRequest proto.InternalMessageInfo
+
+// LinkRequired возвращается в деталях ошибки OpenID.Authorize, если для
+// привязки провайдера к существующей учетной записи требуется подтверждение
+// ее владельца.
+type LinkRequired struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// токен запроса на привязку
+	Link string `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	// уникальный идентификатор провайдера авторизации
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	// email-адрес существующей учетной записи
+	Email string `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	// дата и время окончания действия запроса
+	Expires *time.Time `protobuf:"bytes,5,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
 }
 
-func (m *AuthCode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Code) > 0 {
-		i -= len(m.Code)
-		copy(dAtA[i:], m.Code)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Code)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.State) > 0 {
-		i -= len(m.State)
-		copy(dAtA[i:], m.State)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.State)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Provider) > 0 {
-		i -= len(m.Provider)
-		copy(dAtA[i:], m.Provider)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Domain) > 0 {
-		i -= len(m.Domain)
-		copy(dAtA[i:], m.Domain)
-		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
-		i--
-		dAtA[i] = 0xa
+func (m *LinkRequired) Reset()         { *m = LinkRequired{} }
+func (m *LinkRequired) String() string { return proto.CompactTextString(m) }
+func (*LinkRequired) ProtoMessage()    {}
+func (*LinkRequired) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{11}
+}
+func (m *LinkRequired) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LinkRequired) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LinkRequired.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *LinkRequired) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LinkRequired.Merge(m, src)
+}
+func (m *LinkRequired) XXX_Size() int {
+	return m.Size()
+}
+func (m *LinkRequired) XXX_DiscardUnknown() {
+	xxx_messageInfo_LinkRequired.DiscardUnknown(m)
 }
 
-func encodeVarintOpenid(dAtA []byte, offset int, v uint64) int {
-	offset -= sovOpenid(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+var xxx_messageInfo_LinkRequired proto.InternalMessageInfo
+
+// LinkConfirm используется для подтверждения привязки провайдера к
+// существующей учетной записи. Должен быть задан токен запроса с паролем
+// пользователя или токен из письма LINK.
+type LinkConfirm struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// токен запроса на привязку из LinkRequired; обязателен вместе с паролем
+	Link string `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	// пароль пользователя
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// токен из письма LINK
+	Token string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
 }
-func (m *Provider) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Domain)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
-	}
-	l = len(m.Provider)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
-	}
-	l = len(m.RedirectURI)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
-	}
-	if len(m.Params) > 0 {
-		for k, v := range m.Params {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovOpenid(uint64(len(k))) + 1 + len(v) + sovOpenid(uint64(len(v)))
-			n += mapEntrySize + 1 + sovOpenid(uint64(mapEntrySize))
+
+func (m *LinkConfirm) Reset()         { *m = LinkConfirm{} }
+func (m *LinkConfirm) String() string { return proto.CompactTextString(m) }
+func (*LinkConfirm) ProtoMessage()    {}
+func (*LinkConfirm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{12}
+}
+func (m *LinkConfirm) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LinkConfirm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LinkConfirm.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	l = m.RegInfo.Size()
-	n += 1 + l + sovOpenid(uint64(l))
-	return n
+}
+func (m *LinkConfirm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LinkConfirm.Merge(m, src)
+}
+func (m *LinkConfirm) XXX_Size() int {
+	return m.Size()
+}
+func (m *LinkConfirm) XXX_DiscardUnknown() {
+	xxx_messageInfo_LinkConfirm.DiscardUnknown(m)
 }
 
-func (m *LoginURL) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Domain)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
-	}
-	l = len(m.URL)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
-	}
-	return n
+var xxx_messageInfo_LinkConfirm proto.InternalMessageInfo
+
+// AccessTokenRequest задает запрос токена доступа к API провайдера.
+type AccessTokenRequest struct {
+	// домен сайта
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// уникальный идентификатор провайдера авторизации
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
 }
 
-func (m *AuthCode) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AccessTokenRequest) Reset()         { *m = AccessTokenRequest{} }
+func (m *AccessTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*AccessTokenRequest) ProtoMessage()    {}
+func (*AccessTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{13}
+}
+func (m *AccessTokenRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccessTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccessTokenRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.Domain)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
+}
+func (m *AccessTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessTokenRequest.Merge(m, src)
+}
+func (m *AccessTokenRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccessTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessTokenRequest proto.InternalMessageInfo
+
+// ProviderToken возвращает токен доступа к API провайдера.
+type ProviderToken struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор провайдера авторизации
+	Provider string `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	// токен доступа
+	AccessToken string `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// тип токена, например "Bearer"
+	TokenType string `protobuf:"bytes,4,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	// дата и время окончания действия токена; не задается для бессрочных
+	Expires *time.Time `protobuf:"bytes,5,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
+}
+
+func (m *ProviderToken) Reset()         { *m = ProviderToken{} }
+func (m *ProviderToken) String() string { return proto.CompactTextString(m) }
+func (*ProviderToken) ProtoMessage()    {}
+func (*ProviderToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_341b5f7d56cf065a, []int{14}
+}
+func (m *ProviderToken) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProviderToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProviderToken.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.Provider)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
+}
+func (m *ProviderToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProviderToken.Merge(m, src)
+}
+func (m *ProviderToken) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProviderToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProviderToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProviderToken proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Provider)(nil), "itube.users.Provider")
+	golang_proto.RegisterType((*Provider)(nil), "itube.users.Provider")
+	proto.RegisterMapType((map[string]string)(nil), "itube.users.Provider.ParamsEntry")
+	golang_proto.RegisterMapType((map[string]string)(nil), "itube.users.Provider.ParamsEntry")
+	proto.RegisterType((*LoginURL)(nil), "itube.users.LoginURL")
+	golang_proto.RegisterType((*LoginURL)(nil), "itube.users.LoginURL")
+	proto.RegisterType((*AuthCode)(nil), "itube.users.AuthCode")
+	golang_proto.RegisterType((*AuthCode)(nil), "itube.users.AuthCode")
+	proto.RegisterType((*ProvidersRequest)(nil), "itube.users.ProvidersRequest")
+	golang_proto.RegisterType((*ProvidersRequest)(nil), "itube.users.ProvidersRequest")
+	proto.RegisterType((*ProviderInfo)(nil), "itube.users.ProviderInfo")
+	golang_proto.RegisterType((*ProviderInfo)(nil), "itube.users.ProviderInfo")
+	proto.RegisterType((*ProviderList)(nil), "itube.users.ProviderList")
+	golang_proto.RegisterType((*ProviderList)(nil), "itube.users.ProviderList")
+	proto.RegisterType((*IdentitiesRequest)(nil), "itube.users.IdentitiesRequest")
+	golang_proto.RegisterType((*IdentitiesRequest)(nil), "itube.users.IdentitiesRequest")
+	proto.RegisterType((*LinkedIdentity)(nil), "itube.users.LinkedIdentity")
+	golang_proto.RegisterType((*LinkedIdentity)(nil), "itube.users.LinkedIdentity")
+	proto.RegisterType((*IdentityList)(nil), "itube.users.IdentityList")
+	golang_proto.RegisterType((*IdentityList)(nil), "itube.users.IdentityList")
+	proto.RegisterType((*LinkRequest)(nil), "itube.users.LinkRequest")
+	golang_proto.RegisterType((*LinkRequest)(nil), "itube.users.LinkRequest")
+	proto.RegisterMapType((map[string]string)(nil), "itube.users.LinkRequest.ParamsEntry")
+	golang_proto.RegisterMapType((map[string]string)(nil), "itube.users.LinkRequest.ParamsEntry")
+	proto.RegisterType((*UnlinkRequest)(nil), "itube.users.UnlinkRequest")
+	golang_proto.RegisterType((*UnlinkRequest)(nil), "itube.users.UnlinkRequest")
+	proto.RegisterType((*LinkRequired)(nil), "itube.users.LinkRequired")
+	golang_proto.RegisterType((*LinkRequired)(nil), "itube.users.LinkRequired")
+	proto.RegisterType((*LinkConfirm)(nil), "itube.users.LinkConfirm")
+	golang_proto.RegisterType((*LinkConfirm)(nil), "itube.users.LinkConfirm")
+	proto.RegisterType((*AccessTokenRequest)(nil), "itube.users.AccessTokenRequest")
+	golang_proto.RegisterType((*AccessTokenRequest)(nil), "itube.users.AccessTokenRequest")
+	proto.RegisterType((*ProviderToken)(nil), "itube.users.ProviderToken")
+	golang_proto.RegisterType((*ProviderToken)(nil), "itube.users.ProviderToken")
+}
+
+func init() { proto.RegisterFile("openid.proto", fileDescriptor_341b5f7d56cf065a) }
+func init() { golang_proto.RegisterFile("openid.proto", fileDescriptor_341b5f7d56cf065a) }
+
+var fileDescriptor_341b5f7d56cf065a = []byte{
+	// 1077 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcf, 0x6f, 0x1b, 0xc5,
+	0x17, 0xf7, 0xfa, 0x77, 0x9e, 0x9d, 0xaf, 0xda, 0x51, 0x5a, 0x6d, 0xb6, 0xdf, 0xae, 0xdd, 0x15,
+	0x12, 0x11, 0x52, 0x6c, 0x61, 0x54, 0x9a, 0x84, 0x0a, 0xa9, 0x69, 0x2a, 0x64, 0x88, 0x44, 0xb5,
+	0x8a, 0xa5, 0x8a, 0x4b, 0xb4, 0xf1, 0x8e, 0xdd, 0x21, 0xde, 0x9d, 0xed, 0xec, 0x6c, 0x82, 0x39,
+	0x70, 0x44, 0x70, 0xcb, 0x95, 0x0b, 0x67, 0xfe, 0x03, 0xc4, 0x0d, 0x6e, 0x11, 0xa7, 0x1c, 0x39,
+	0x25, 0xd4, 0xf9, 0x47, 0xd0, 0xce, 0xcc, 0xda, 0xbb, 0x89, 0x43, 0x22, 0x85, 0x43, 0x4f, 0x99,
+	0xf7, 0xcb, 0xfb, 0x3e, 0x9f, 0xcf, 0xcc, 0x7b, 0x81, 0x3a, 0x0d, 0xb0, 0x4f, 0xdc, 0x56, 0xc0,
+	0x28, 0xa7, 0xa8, 0x46, 0x78, 0xb4, 0x87, 0x5b, 0x51, 0x88, 0x59, 0x68, 0x40, 0xfc, 0x47, 0x06,
+	0x8c, 0x07, 0x43, 0x4a, 0x87, 0x23, 0xdc, 0x16, 0xd6, 0x5e, 0x34, 0x68, 0x63, 0x2f, 0xe0, 0x63,
+	0x15, 0x6c, 0x5c, 0x0c, 0x72, 0xe2, 0xe1, 0x90, 0x3b, 0x5e, 0xa0, 0x12, 0x56, 0x87, 0x84, 0xbf,
+	0x8e, 0xf6, 0x5a, 0x7d, 0xea, 0xb5, 0x87, 0x74, 0x48, 0x67, 0x99, 0xb1, 0x25, 0x0c, 0x71, 0x52,
+	0xe9, 0x1f, 0xa7, 0xd2, 0xbd, 0x43, 0xc2, 0xf7, 0xe9, 0x61, 0x7b, 0x48, 0x57, 0x45, 0x70, 0xf5,
+	0xc0, 0x19, 0x11, 0xd7, 0xe1, 0x94, 0x85, 0xed, 0xe9, 0x51, 0xd6, 0x59, 0xbf, 0xe5, 0xa1, 0xfa,
+	0x92, 0xd1, 0x03, 0xe2, 0x62, 0x86, 0x4c, 0x28, 0xbb, 0xd4, 0x73, 0x88, 0xaf, 0x6b, 0x4d, 0x6d,
+	0x65, 0x61, 0xb3, 0x3c, 0x39, 0x6b, 0xe4, 0x5f, 0x69, 0xb6, 0xf2, 0x22, 0x0b, 0xaa, 0x81, 0xca,
+	0xd5, 0xf3, 0x99, 0x8c, 0xa9, 0x1f, 0xad, 0x41, 0x9d, 0x61, 0x97, 0x30, 0xdc, 0xe7, 0xbb, 0x11,
+	0x23, 0x7a, 0x41, 0xe4, 0xdd, 0x9b, 0x9c, 0x36, 0x6a, 0xb6, 0xf2, 0xf7, 0xec, 0xae, 0x2a, 0xab,
+	0x25, 0xa9, 0x3d, 0x46, 0xd0, 0x3a, 0x94, 0x03, 0x87, 0x39, 0x5e, 0xa8, 0x17, 0x9b, 0x85, 0x95,
+	0x5a, 0xe7, 0x51, 0x2b, 0xc5, 0x6c, 0x2b, 0x69, 0xb2, 0xf5, 0x52, 0xe4, 0xbc, 0xf0, 0x39, 0x1b,
+	0xdb, 0xaa, 0x00, 0xad, 0x43, 0x95, 0xe1, 0xe1, 0x2e, 0xf1, 0x07, 0x54, 0x87, 0xa6, 0xb6, 0x52,
+	0xeb, 0x2c, 0x65, 0x8a, 0x6d, 0x3c, 0xec, 0xfa, 0x03, 0xba, 0x59, 0x3d, 0x3e, 0x6d, 0xe4, 0x4e,
+	0x4e, 0x1b, 0x9a, 0x5d, 0x61, 0xd2, 0x65, 0xac, 0x43, 0x2d, 0xf5, 0x8b, 0xe8, 0x0e, 0x14, 0xf6,
+	0xf1, 0x58, 0xe2, 0xb7, 0xe3, 0x23, 0x5a, 0x82, 0xd2, 0x81, 0x33, 0x8a, 0xb0, 0x44, 0x6c, 0x4b,
+	0x63, 0x23, 0xbf, 0xa6, 0x59, 0x5f, 0x40, 0x75, 0x9b, 0x0e, 0x89, 0xdf, 0xb3, 0xb7, 0xaf, 0xa5,
+	0xae, 0x01, 0x85, 0x88, 0x8d, 0x14, 0x6b, 0x8b, 0x93, 0xd3, 0x46, 0xa1, 0x67, 0x6f, 0xab, 0x9c,
+	0x38, 0x62, 0xfd, 0xaa, 0x41, 0xf5, 0x59, 0xc4, 0x5f, 0x3f, 0xa7, 0x2e, 0xfe, 0x4f, 0x84, 0xf8,
+	0x3f, 0x94, 0x42, 0xee, 0x70, 0xac, 0x17, 0x32, 0x09, 0xd2, 0x89, 0x0c, 0x28, 0xf6, 0xa9, 0x8b,
+	0xf5, 0x62, 0x26, 0x28, 0x7c, 0x08, 0x41, 0x31, 0xa6, 0x4d, 0x2f, 0x09, 0xc0, 0xe2, 0x8c, 0x96,
+	0xa1, 0x10, 0x11, 0x57, 0x2f, 0x8b, 0xf4, 0x8a, 0xe8, 0xbf, 0xbb, 0x65, 0xc7, 0x3e, 0xab, 0x03,
+	0x77, 0x12, 0x71, 0x42, 0x1b, 0xbf, 0x89, 0x70, 0xc8, 0xaf, 0x03, 0x60, 0xad, 0x41, 0x3d, 0xa9,
+	0x89, 0x55, 0x88, 0x3f, 0xe9, 0x3b, 0x1e, 0x56, 0xbc, 0x8b, 0x73, 0x4c, 0x3c, 0x27, 0x7c, 0x34,
+	0x25, 0x5e, 0x18, 0xd6, 0xee, 0xac, 0x72, 0x9b, 0x84, 0x1c, 0xdd, 0xcf, 0x7e, 0x69, 0x4a, 0xd1,
+	0x13, 0x58, 0x48, 0xa8, 0x08, 0xf5, 0xbc, 0xb8, 0x50, 0xcb, 0x73, 0x2f, 0x54, 0xfc, 0x7d, 0x7b,
+	0x96, 0x6b, 0x8d, 0xe1, 0x6e, 0xd7, 0xc5, 0x3e, 0x27, 0x9c, 0xe0, 0x9b, 0xe2, 0x41, 0x5b, 0x92,
+	0x1e, 0xa9, 0x45, 0x47, 0xd1, 0x33, 0x39, 0x6b, 0xbc, 0x7f, 0xa4, 0x15, 0x3f, 0x68, 0x12, 0x5f,
+	0x3c, 0xbb, 0x66, 0xe4, 0x93, 0x37, 0x11, 0x6e, 0x12, 0xf1, 0xf3, 0x03, 0x82, 0x59, 0x73, 0x40,
+	0x99, 0xe7, 0x70, 0x71, 0x07, 0x88, 0x6b, 0x7d, 0xaf, 0xc1, 0xff, 0xb6, 0x89, 0xbf, 0x8f, 0x5d,
+	0xd5, 0xc1, 0x18, 0x19, 0x29, 0xa5, 0x25, 0xc0, 0x99, 0xc2, 0x3a, 0x54, 0xc2, 0x68, 0xef, 0x6b,
+	0xdc, 0xe7, 0x8a, 0xa2, 0xc4, 0x44, 0x9f, 0x42, 0xa5, 0xcf, 0xb0, 0xc3, 0xb1, 0x2b, 0xd4, 0xaf,
+	0x75, 0x8c, 0x96, 0x9c, 0x37, 0xad, 0x64, 0x8a, 0xb4, 0x76, 0x92, 0x79, 0x23, 0x1f, 0xc5, 0xd1,
+	0x59, 0xfc, 0x28, 0x54, 0x91, 0xf5, 0x1d, 0xd4, 0x93, 0x0e, 0xfe, 0x95, 0xe4, 0xe5, 0x34, 0xec,
+	0xcc, 0xad, 0x40, 0x9f, 0x00, 0x90, 0x29, 0x8d, 0x7a, 0x41, 0x08, 0xf0, 0x20, 0x23, 0x40, 0x16,
+	0xa9, 0x9d, 0x4a, 0xb7, 0xfe, 0xcc, 0x43, 0x2d, 0x0e, 0xdf, 0x9a, 0xfe, 0x57, 0xda, 0x8d, 0x04,
+	0x90, 0x2d, 0xa7, 0x5f, 0x55, 0xe1, 0x86, 0xe3, 0xad, 0x78, 0xe3, 0xf1, 0xf6, 0x74, 0x3a, 0xde,
+	0x4a, 0x82, 0x8c, 0xf7, 0x2e, 0x91, 0xa1, 0xd0, 0xce, 0x9b, 0x70, 0xb7, 0x19, 0x53, 0x3f, 0x69,
+	0xb0, 0xd8, 0xf3, 0x47, 0xef, 0x22, 0x9d, 0xd6, 0x2f, 0x1a, 0xd4, 0x13, 0xe8, 0x84, 0x61, 0xf7,
+	0xca, 0x9b, 0x86, 0xa0, 0x18, 0x23, 0x50, 0xe8, 0xc4, 0x19, 0x19, 0x17, 0x3f, 0x90, 0xd2, 0x69,
+	0x09, 0x4a, 0xd8, 0x73, 0xc8, 0x48, 0x0a, 0x64, 0x4b, 0x03, 0x6d, 0x40, 0x05, 0x7f, 0x13, 0x10,
+	0x86, 0x43, 0xbd, 0x74, 0xed, 0xbb, 0x28, 0xca, 0x37, 0xa1, 0x0a, 0xac, 0x50, 0x5e, 0xc9, 0xe7,
+	0xd4, 0x1f, 0x10, 0xe6, 0x5d, 0xcb, 0xe1, 0x55, 0x0d, 0x3b, 0x61, 0x78, 0x48, 0x99, 0x3b, 0x6d,
+	0x58, 0xd9, 0x62, 0xda, 0xd1, 0x7d, 0xec, 0x27, 0x0d, 0x0b, 0xc3, 0xfa, 0x59, 0x03, 0xf4, 0xac,
+	0xdf, 0xc7, 0x61, 0xb8, 0x13, 0xdb, 0xef, 0x9e, 0x80, 0x7f, 0x68, 0xb0, 0x98, 0x4c, 0x52, 0xd1,
+	0xe2, 0x95, 0x0a, 0x1a, 0x17, 0x77, 0x56, 0x4a, 0xad, 0x47, 0x50, 0x77, 0x04, 0xca, 0x5d, 0xc9,
+	0x81, 0x24, 0xa7, 0xe6, 0xcc, 0x90, 0xa3, 0x87, 0x00, 0x22, 0xb6, 0xcb, 0xc7, 0x81, 0x5a, 0x5b,
+	0xf6, 0x82, 0xf0, 0xec, 0x8c, 0x03, 0x7c, 0x1b, 0x65, 0x3b, 0x3f, 0x16, 0xa1, 0xfc, 0x65, 0x80,
+	0xfd, 0xee, 0x16, 0x7a, 0x0c, 0x25, 0xb1, 0xd2, 0xd1, 0xbd, 0xb9, 0xbb, 0xc2, 0xc8, 0xba, 0xa7,
+	0xdb, 0xff, 0x31, 0x2c, 0xc4, 0xbb, 0x9b, 0x32, 0xf2, 0x2d, 0xbe, 0x50, 0x9a, 0xec, 0x74, 0xe3,
+	0x6e, 0xc6, 0xdd, 0x8b, 0x97, 0xea, 0x06, 0xd4, 0xd4, 0x75, 0x8a, 0x6f, 0x16, 0xd2, 0x2f, 0x4d,
+	0x04, 0x15, 0x9d, 0x57, 0xdb, 0x85, 0xc5, 0x78, 0x34, 0x4f, 0x37, 0x2f, 0x7a, 0x38, 0xb7, 0xe3,
+	0x64, 0x83, 0x19, 0xf3, 0x97, 0x9f, 0x98, 0xee, 0x9f, 0x01, 0xcc, 0x36, 0x1e, 0x32, 0x33, 0x89,
+	0x97, 0x56, 0xa1, 0xb1, 0x3c, 0x2f, 0x2e, 0xd7, 0xc4, 0x13, 0x28, 0x5e, 0x01, 0x24, 0x29, 0xbe,
+	0x82, 0xbf, 0xa7, 0x50, 0x96, 0x13, 0x0a, 0x19, 0x59, 0xa4, 0xe9, 0xb1, 0x65, 0xdc, 0xbf, 0x24,
+	0xe9, 0x8b, 0xf8, 0x3f, 0x6a, 0xf4, 0x39, 0xd4, 0x52, 0x6f, 0x04, 0x35, 0xb2, 0xfc, 0x5f, 0x7a,
+	0x3d, 0x86, 0x31, 0x97, 0x0a, 0x91, 0xb2, 0xf9, 0xe1, 0xf1, 0x5b, 0x33, 0x77, 0xf2, 0xd6, 0xcc,
+	0x1d, 0x4f, 0x4c, 0xed, 0x64, 0x62, 0x6a, 0x7f, 0x4f, 0x4c, 0xed, 0x87, 0x73, 0x33, 0x77, 0x74,
+	0x6e, 0xe6, 0x7e, 0x3f, 0x37, 0xb5, 0x93, 0x73, 0x33, 0xf7, 0xd7, 0xb9, 0x99, 0xfb, 0xaa, 0x12,
+	0xec, 0x0f, 0xdb, 0x4e, 0x40, 0xf6, 0xca, 0xa2, 0x9d, 0x8f, 0xfe, 0x19, 0x00, 0xc3, 0x3b, 0x6b,
+	0x9b, 0x17, 0x0c, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OpenIDClient is the client API for OpenID service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OpenIDClient interface {
+	// Login выдает URL для перехода на авторизацию к провайдеру.
+	//
+	// Возвращает InvalidArgument, если указан неподдерживаемый идентификатор
+	// провайдера авторизации, адрес для возврата не входит в список
+	// допустимых для домена или провайдера или дополнительные параметры
+	// переопределяют формируемые провайдером (redirect_uri, state и т.д.).
+	Login(ctx context.Context, in *Provider, opts ...grpc.CallOption) (*LoginURL, error)
+	// Authorize проверяет авторизацию и возвращает информацию об
+	// авторизованном пользователе. Если пользователь не зарегистрирован,
+	// то происходит его автоматическая регистрация. Вместе с информацией о
+	// пользователе возвращаются токены созданной сессии.
+	//
+	// Адрес для возврата, использованный при начале авторизации, повторно
+	// проверяется по спискам допустимых адресов домена и провайдера.
+	//
+	// При каждой авторизации запрашивается актуальный профиль пользователя,
+	// который сохраняется для привязки к провайдеру, а выбранные в настройках
+	// провайдера данные профиля переносятся в свойства пользователя.
+	//
+	// Данные из user, которые провайдер передает только при первой
+	// авторизации, дополняют профиль и всегда переносятся в свойства
+	// пользователя, т.к. повторно они не передаются.
+	//
+	// Если авторизация была начата через Link, то провайдер привязывается к
+	// указанному в ней пользователю. Идентификатор авторизованного пользователя
+	// при этом должен быть передан в uid и совпадать с указанным в Link: иначе
+	// чужую ссылку на привязку можно было бы подсунуть жертве и привязать ее
+	// учетную запись провайдера к своему пользователю.
+	//
+	// Если провайдер не подтвердил email-адрес, а пользователь с таким адресом
+	// уже зарегистрирован, то автоматическая привязка не выполняется и
+	// возвращается ошибка FailedPrecondition, в деталях которой передается
+	// LinkRequired с токеном запроса на привязку. Владельцу адреса при этом
+	// отправляется письмо LINK с токеном для подтверждения. Привязка
+	// завершается через ConfirmLink: паролем с токеном запроса или токеном из
+	// письма.
+	//
+	// Для доменов из списка Private ошибка FailedPrecondition в этом случае
+	// возвращается без деталей. Так же без деталей она возвращается и при
+	// регистрации нового пользователя с неподтвержденным адресом, которому
+	// отправляется письмо EMAIL для подтверждения адреса: так по ответу нельзя
+	// определить, зарегистрирован ли адрес.
+	//
+	// Возвращает ошибки:
+	//  - AlreadyExists - пользователь провайдера привязан к другому
+	//    пользователю (при привязке через Link)
+	//  - PermissionDenied - привязка через Link начата другим пользователем
+	//  - NotFound - пользователь заблокирован
+	//  - FailedPrecondition - требуется подтверждение привязки или второй
+	//    фактор авторизации
+	//  - InvalidArgument - неверный формат данных входящего запроса или
+	//    недопустимый адрес для возврата
+	//  - Internal - внутренние ошибки
+	Authorize(ctx context.Context, in *AuthCode, opts ...grpc.CallOption) (*User, error)
+	// ConfirmLink подтверждает привязку внешнего провайдера к существующей
+	// учетной записи паролем пользователя или токеном из письма LINK и
+	// возвращает информацию о пользователе вместе с токенами созданной сессии.
+	// Пароль проверяется вместе с токеном запроса из LinkRequired, а токен из
+	// письма сам указывает на запрос и подтверждает email-адрес.
+	//
+	// Если у пользователя включена двухфакторная авторизация, то после
+	// привязки возвращается ошибка FailedPrecondition с Challenge для
+	// завершения авторизации через TwoFactor.Authorize.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь заблокирован
+	//  - FailedPrecondition - время жизни запроса истекло или требуется
+	//    второй фактор авторизации
+	//  - InvalidArgument - неверный токен, пароль или формат данных
+	//  - ResourceExhausted - слишком много неудачных попыток авторизации
+	//  - Internal - внутренние ошибки
+	ConfirmLink(ctx context.Context, in *LinkConfirm, opts ...grpc.CallOption) (*User, error)
+	// ListProviders возвращает список поддерживаемых провайдеров авторизации,
+	// упорядоченный по названию. Используется для отображения кнопок входа.
+	ListProviders(ctx context.Context, in *ProvidersRequest, opts ...grpc.CallOption) (*ProviderList, error)
+	// Identities возвращает список внешних провайдеров авторизации,
+	// привязанных к пользователю.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Identities(ctx context.Context, in *IdentitiesRequest, opts ...grpc.CallOption) (*IdentityList, error)
+	// Link выдает URL для перехода на авторизацию к провайдеру, чтобы привязать
+	// его к уже авторизованному пользователю. Идентификатор пользователя
+	// сохраняется в состоянии авторизации, и после возврата от провайдера
+	// Authorize вместо авторизации привязывает провайдера к этому пользователю
+	// и возвращает информацию о нем без создания новой сессии. Для завершения
+	// привязки в Authorize нужно передать uid того же авторизованного
+	// пользователя.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь не зарегистрирован или заблокирован
+	//  - InvalidArgument - неподдерживаемый провайдер, неверный формат данных,
+	//    недопустимый адрес для возврата или зарезервированные параметры
+	//  - Internal - внутренние ошибки
+	Link(ctx context.Context, in *LinkRequest, opts ...grpc.CallOption) (*LoginURL, error)
+	// Unlink отвязывает внешнего провайдера авторизации от пользователя.
+	// Отвязать провайдера нельзя, если у пользователя не задан пароль и нет
+	// других привязанных провайдеров.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - провайдер не привязан к пользователю
+	//  - FailedPrecondition - это единственный способ авторизации пользователя
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Unlink(ctx context.Context, in *UnlinkRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// AccessToken возвращает действующий токен доступа к API провайдера от
+	// имени пользователя. Если время действия сохраненного токена истекло, то
+	// он обновляется у провайдера с помощью токена обновления.
+	//
+	// Токены сохраняются при авторизации только для провайдеров, для которых
+	// это разрешено, и если на сервисе задан ключ шифрования.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - токены для пользователя и провайдера не сохранены
+	//  - FailedPrecondition - сохранение токенов не включено или токен
+	//    доступа не удалось обновить
+	//  - InvalidArgument - неподдерживаемый провайдер или неверный формат
+	//    данных входящего запроса
+	//  - Internal - внутренние ошибки
+	AccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*ProviderToken, error)
+}
+
+type openIDClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOpenIDClient(cc *grpc.ClientConn) OpenIDClient {
+	return &openIDClient{cc}
+}
+
+func (c *openIDClient) Login(ctx context.Context, in *Provider, opts ...grpc.CallOption) (*LoginURL, error) {
+	out := new(LoginURL)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.State)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
+	return out, nil
+}
+
+func (c *openIDClient) Authorize(ctx context.Context, in *AuthCode, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Authorize", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Code)
-	if l > 0 {
-		n += 1 + l + sovOpenid(uint64(l))
+	return out, nil
+}
+
+func (c *openIDClient) ConfirmLink(ctx context.Context, in *LinkConfirm, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/ConfirmLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openIDClient) ListProviders(ctx context.Context, in *ProvidersRequest, opts ...grpc.CallOption) (*ProviderList, error) {
+	out := new(ProviderList)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/ListProviders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openIDClient) Identities(ctx context.Context, in *IdentitiesRequest, opts ...grpc.CallOption) (*IdentityList, error) {
+	out := new(IdentityList)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Identities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openIDClient) Link(ctx context.Context, in *LinkRequest, opts ...grpc.CallOption) (*LoginURL, error) {
+	out := new(LoginURL)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Link", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openIDClient) Unlink(ctx context.Context, in *UnlinkRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/Unlink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openIDClient) AccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*ProviderToken, error) {
+	out := new(ProviderToken)
+	err := c.cc.Invoke(ctx, "/itube.users.OpenID/AccessToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OpenIDServer is the server API for OpenID service.
+type OpenIDServer interface {
+	// Login выдает URL для перехода на авторизацию к провайдеру.
+	//
+	// Возвращает InvalidArgument, если указан неподдерживаемый идентификатор
+	// провайдера авторизации, адрес для возврата не входит в список
+	// допустимых для домена или провайдера или дополнительные параметры
+	// переопределяют формируемые провайдером (redirect_uri, state и т.д.).
+	Login(context.Context, *Provider) (*LoginURL, error)
+	// Authorize проверяет авторизацию и возвращает информацию об
+	// авторизованном пользователе. Если пользователь не зарегистрирован,
+	// то происходит его автоматическая регистрация. Вместе с информацией о
+	// пользователе возвращаются токены созданной сессии.
+	//
+	// Адрес для возврата, использованный при начале авторизации, повторно
+	// проверяется по спискам допустимых адресов домена и провайдера.
+	//
+	// При каждой авторизации запрашивается актуальный профиль пользователя,
+	// который сохраняется для привязки к провайдеру, а выбранные в настройках
+	// провайдера данные профиля переносятся в свойства пользователя.
+	//
+	// Данные из user, которые провайдер передает только при первой
+	// авторизации, дополняют профиль и всегда переносятся в свойства
+	// пользователя, т.к. повторно они не передаются.
+	//
+	// Если авторизация была начата через Link, то провайдер привязывается к
+	// указанному в ней пользователю. Идентификатор авторизованного пользователя
+	// при этом должен быть передан в uid и совпадать с указанным в Link: иначе
+	// чужую ссылку на привязку можно было бы подсунуть жертве и привязать ее
+	// учетную запись провайдера к своему пользователю.
+	//
+	// Если провайдер не подтвердил email-адрес, а пользователь с таким адресом
+	// уже зарегистрирован, то автоматическая привязка не выполняется и
+	// возвращается ошибка FailedPrecondition, в деталях которой передается
+	// LinkRequired с токеном запроса на привязку. Владельцу адреса при этом
+	// отправляется письмо LINK с токеном для подтверждения. Привязка
+	// завершается через ConfirmLink: паролем с токеном запроса или токеном из
+	// письма.
+	//
+	// Для доменов из списка Private ошибка FailedPrecondition в этом случае
+	// возвращается без деталей. Так же без деталей она возвращается и при
+	// регистрации нового пользователя с неподтвержденным адресом, которому
+	// отправляется письмо EMAIL для подтверждения адреса: так по ответу нельзя
+	// определить, зарегистрирован ли адрес.
+	//
+	// Возвращает ошибки:
+	//  - AlreadyExists - пользователь провайдера привязан к другому
+	//    пользователю (при привязке через Link)
+	//  - PermissionDenied - привязка через Link начата другим пользователем
+	//  - NotFound - пользователь заблокирован
+	//  - FailedPrecondition - требуется подтверждение привязки или второй
+	//    фактор авторизации
+	//  - InvalidArgument - неверный формат данных входящего запроса или
+	//    недопустимый адрес для возврата
+	//  - Internal - внутренние ошибки
+	Authorize(context.Context, *AuthCode) (*User, error)
+	// ConfirmLink подтверждает привязку внешнего провайдера к существующей
+	// учетной записи паролем пользователя или токеном из письма LINK и
+	// возвращает информацию о пользователе вместе с токенами созданной сессии.
+	// Пароль проверяется вместе с токеном запроса из LinkRequired, а токен из
+	// письма сам указывает на запрос и подтверждает email-адрес.
+	//
+	// Если у пользователя включена двухфакторная авторизация, то после
+	// привязки возвращается ошибка FailedPrecondition с Challenge для
+	// завершения авторизации через TwoFactor.Authorize.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь заблокирован
+	//  - FailedPrecondition - время жизни запроса истекло или требуется
+	//    второй фактор авторизации
+	//  - InvalidArgument - неверный токен, пароль или формат данных
+	//  - ResourceExhausted - слишком много неудачных попыток авторизации
+	//  - Internal - внутренние ошибки
+	ConfirmLink(context.Context, *LinkConfirm) (*User, error)
+	// ListProviders возвращает список поддерживаемых провайдеров авторизации,
+	// упорядоченный по названию. Используется для отображения кнопок входа.
+	ListProviders(context.Context, *ProvidersRequest) (*ProviderList, error)
+	// Identities возвращает список внешних провайдеров авторизации,
+	// привязанных к пользователю.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Identities(context.Context, *IdentitiesRequest) (*IdentityList, error)
+	// Link выдает URL для перехода на авторизацию к провайдеру, чтобы привязать
+	// его к уже авторизованному пользователю. Идентификатор пользователя
+	// сохраняется в состоянии авторизации, и после возврата от провайдера
+	// Authorize вместо авторизации привязывает провайдера к этому пользователю
+	// и возвращает информацию о нем без создания новой сессии. Для завершения
+	// привязки в Authorize нужно передать uid того же авторизованного
+	// пользователя.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь не зарегистрирован или заблокирован
+	//  - InvalidArgument - неподдерживаемый провайдер, неверный формат данных,
+	//    недопустимый адрес для возврата или зарезервированные параметры
+	//  - Internal - внутренние ошибки
+	Link(context.Context, *LinkRequest) (*LoginURL, error)
+	// Unlink отвязывает внешнего провайдера авторизации от пользователя.
+	// Отвязать провайдера нельзя, если у пользователя не задан пароль и нет
+	// других привязанных провайдеров.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - провайдер не привязан к пользователю
+	//  - FailedPrecondition - это единственный способ авторизации пользователя
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Unlink(context.Context, *UnlinkRequest) (*types.Empty, error)
+	// AccessToken возвращает действующий токен доступа к API провайдера от
+	// имени пользователя. Если время действия сохраненного токена истекло, то
+	// он обновляется у провайдера с помощью токена обновления.
+	//
+	// Токены сохраняются при авторизации только для провайдеров, для которых
+	// это разрешено, и если на сервисе задан ключ шифрования.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - токены для пользователя и провайдера не сохранены
+	//  - FailedPrecondition - сохранение токенов не включено или токен
+	//    доступа не удалось обновить
+	//  - InvalidArgument - неподдерживаемый провайдер или неверный формат
+	//    данных входящего запроса
+	//  - Internal - внутренние ошибки
+	AccessToken(context.Context, *AccessTokenRequest) (*ProviderToken, error)
+}
+
+// UnimplementedOpenIDServer can be embedded to have forward compatible implementations.
+type UnimplementedOpenIDServer struct {
+}
+
+func (*UnimplementedOpenIDServer) Login(ctx context.Context, req *Provider) (*LoginURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedOpenIDServer) Authorize(ctx context.Context, req *AuthCode) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (*UnimplementedOpenIDServer) ConfirmLink(ctx context.Context, req *LinkConfirm) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmLink not implemented")
+}
+func (*UnimplementedOpenIDServer) ListProviders(ctx context.Context, req *ProvidersRequest) (*ProviderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (*UnimplementedOpenIDServer) Identities(ctx context.Context, req *IdentitiesRequest) (*IdentityList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Identities not implemented")
+}
+func (*UnimplementedOpenIDServer) Link(ctx context.Context, req *LinkRequest) (*LoginURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Link not implemented")
+}
+func (*UnimplementedOpenIDServer) Unlink(ctx context.Context, req *UnlinkRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlink not implemented")
+}
+func (*UnimplementedOpenIDServer) AccessToken(ctx context.Context, req *AccessTokenRequest) (*ProviderToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessToken not implemented")
+}
+
+func RegisterOpenIDServer(s *grpc.Server, srv OpenIDServer) {
+	s.RegisterService(&_OpenID_serviceDesc, srv)
+}
+
+func _OpenID_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Provider)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).Login(ctx, req.(*Provider))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/Authorize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).Authorize(ctx, req.(*AuthCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_ConfirmLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkConfirm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).ConfirmLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/ConfirmLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).ConfirmLink(ctx, req.(*LinkConfirm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/ListProviders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).ListProviders(ctx, req.(*ProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_Identities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).Identities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/Identities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).Identities(ctx, req.(*IdentitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_Link_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).Link(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/Link",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).Link(ctx, req.(*LinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_Unlink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).Unlink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/Unlink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).Unlink(ctx, req.(*UnlinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenID_AccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenIDServer).AccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.OpenID/AccessToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenIDServer).AccessToken(ctx, req.(*AccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OpenID_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "itube.users.OpenID",
+	HandlerType: (*OpenIDServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _OpenID_Login_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _OpenID_Authorize_Handler,
+		},
+		{
+			MethodName: "ConfirmLink",
+			Handler:    _OpenID_ConfirmLink_Handler,
+		},
+		{
+			MethodName: "ListProviders",
+			Handler:    _OpenID_ListProviders_Handler,
+		},
+		{
+			MethodName: "Identities",
+			Handler:    _OpenID_Identities_Handler,
+		},
+		{
+			MethodName: "Link",
+			Handler:    _OpenID_Link_Handler,
+		},
+		{
+			MethodName: "Unlink",
+			Handler:    _OpenID_Unlink_Handler,
+		},
+		{
+			MethodName: "AccessToken",
+			Handler:    _OpenID_AccessToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "openid.proto",
+}
+
+func (m *Provider) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Provider) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Provider) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.RegInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOpenid(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x52
+	if len(m.Params) > 0 {
+		for k := range m.Params {
+			v := m.Params[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintOpenid(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintOpenid(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintOpenid(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.RedirectURI) > 0 {
+		i -= len(m.RedirectURI)
+		copy(dAtA[i:], m.RedirectURI)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.RedirectURI)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LoginURL) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoginURL) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoginURL) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.URL) > 0 {
+		i -= len(m.URL)
+		copy(dAtA[i:], m.URL)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.URL)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuthCode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthCode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthCode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.User) > 0 {
+		i -= len(m.User)
+		copy(dAtA[i:], m.User)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.User)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Code) > 0 {
+		i -= len(m.Code)
+		copy(dAtA[i:], m.Code)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Code)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProvidersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvidersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProvidersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProviderInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProviderInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProviderInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProviderList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProviderList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProviderList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Providers) > 0 {
+		for iNdEx := len(m.Providers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Providers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOpenid(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IdentitiesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IdentitiesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IdentitiesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LinkedIdentity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LinkedIdentity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LinkedIdentity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintOpenid(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Subject) > 0 {
+		i -= len(m.Subject)
+		copy(dAtA[i:], m.Subject)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Subject)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IdentityList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IdentityList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IdentityList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Identities) > 0 {
+		for iNdEx := len(m.Identities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Identities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOpenid(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LinkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LinkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Params) > 0 {
+		for k := range m.Params {
+			v := m.Params[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintOpenid(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintOpenid(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintOpenid(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.RedirectURI) > 0 {
+		i -= len(m.RedirectURI)
+		copy(dAtA[i:], m.RedirectURI)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.RedirectURI)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UnlinkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnlinkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnlinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LinkRequired) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LinkRequired) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LinkRequired) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err3 != nil {
+			return 0, err3
+		}
+		i -= n3
+		i = encodeVarintOpenid(dAtA, i, uint64(n3))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Email) > 0 {
+		i -= len(m.Email)
+		copy(dAtA[i:], m.Email)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Email)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Link) > 0 {
+		i -= len(m.Link)
+		copy(dAtA[i:], m.Link)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Link)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LinkConfirm) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LinkConfirm) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LinkConfirm) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Password) > 0 {
+		i -= len(m.Password)
+		copy(dAtA[i:], m.Password)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Password)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Link) > 0 {
+		i -= len(m.Link)
+		copy(dAtA[i:], m.Link)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Link)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccessTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProviderToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProviderToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProviderToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintOpenid(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.TokenType) > 0 {
+		i -= len(m.TokenType)
+		copy(dAtA[i:], m.TokenType)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.TokenType)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.AccessToken) > 0 {
+		i -= len(m.AccessToken)
+		copy(dAtA[i:], m.AccessToken)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.AccessToken)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintOpenid(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOpenid(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOpenid(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Provider) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.RedirectURI)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	if len(m.Params) > 0 {
+		for k, v := range m.Params {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovOpenid(uint64(len(k))) + 1 + len(v) + sovOpenid(uint64(len(v)))
+			n += mapEntrySize + 1 + sovOpenid(uint64(mapEntrySize))
+		}
+	}
+	l = m.RegInfo.Size()
+	n += 1 + l + sovOpenid(uint64(l))
+	return n
+}
+
+func (m *LoginURL) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *AuthCode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.User)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *ProvidersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *ProviderInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *ProviderList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	if len(m.Providers) > 0 {
+		for _, e := range m.Providers {
+			l = e.Size()
+			n += 1 + l + sovOpenid(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IdentitiesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *LinkedIdentity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovOpenid(uint64(l))
+	return n
+}
+
+func (m *IdentityList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	if len(m.Identities) > 0 {
+		for _, e := range m.Identities {
+			l = e.Size()
+			n += 1 + l + sovOpenid(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LinkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.RedirectURI)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	if len(m.Params) > 0 {
+		for k, v := range m.Params {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovOpenid(uint64(len(k))) + 1 + len(v) + sovOpenid(uint64(len(v)))
+			n += mapEntrySize + 1 + sovOpenid(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *UnlinkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *LinkRequired) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Link)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *LinkConfirm) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Link)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *AccessTokenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func (m *ProviderToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.AccessToken)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	l = len(m.TokenType)
+	if l > 0 {
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 1 + l + sovOpenid(uint64(l))
+	}
+	return n
+}
+
+func sovOpenid(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOpenid(x uint64) (n int) {
+	return sovOpenid(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Provider) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Provider: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Provider: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedirectURI", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RedirectURI = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Params == nil {
+				m.Params = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOpenid
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOpenid
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOpenid
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipOpenid(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Params[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RegInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LoginURL) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoginURL: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoginURL: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuthCode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthCode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthCode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProvidersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProvidersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProvidersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProviderInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProviderInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProviderInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProviderList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProviderList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProviderList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Providers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Providers = append(m.Providers, &ProviderInfo{})
+			if err := m.Providers[len(m.Providers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IdentitiesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IdentitiesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IdentitiesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LinkedIdentity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LinkedIdentity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LinkedIdentity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IdentityList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IdentityList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IdentityList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identities = append(m.Identities, &LinkedIdentity{})
+			if err := m.Identities[len(m.Identities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LinkRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LinkRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedirectURI", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RedirectURI = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Params == nil {
+				m.Params = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOpenid
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOpenid
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOpenid
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipOpenid(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthOpenid
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Params[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnlinkRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnlinkRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnlinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LinkRequired) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOpenid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LinkRequired: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LinkRequired: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Link", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Link = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOpenid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovOpenid(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozOpenid(x uint64) (n int) {
-	return sovOpenid(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Provider) Unmarshal(dAtA []byte) error {
+func (m *LinkConfirm) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -639,10 +4606,10 @@ func (m *Provider) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Provider: wiretype end group for non-group")
+			return fmt.Errorf("proto: LinkConfirm: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Provider: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LinkConfirm: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -679,7 +4646,7 @@ func (m *Provider) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Link", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -707,11 +4674,11 @@ func (m *Provider) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Provider = string(dAtA[iNdEx:postIndex])
+			m.Link = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RedirectURI", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -739,140 +4706,13 @@ func (m *Provider) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RedirectURI = string(dAtA[iNdEx:postIndex])
+			m.Password = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOpenid
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthOpenid
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOpenid
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Params == nil {
-				m.Params = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowOpenid
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowOpenid
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthOpenid
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthOpenid
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowOpenid
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthOpenid
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue < 0 {
-						return ErrInvalidLengthOpenid
-					}
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipOpenid(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthOpenid
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.Params[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOpenid
@@ -882,24 +4722,23 @@ func (m *Provider) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOpenid
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthOpenid
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RegInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -925,7 +4764,7 @@ func (m *Provider) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LoginURL) Unmarshal(dAtA []byte) error {
+func (m *AccessTokenRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -948,10 +4787,10 @@ func (m *LoginURL) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LoginURL: wiretype end group for non-group")
+			return fmt.Errorf("proto: AccessTokenRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LoginURL: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AccessTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -988,7 +4827,7 @@ func (m *LoginURL) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1016,7 +4855,39 @@ func (m *LoginURL) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.URL = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1042,7 +4913,7 @@ func (m *LoginURL) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AuthCode) Unmarshal(dAtA []byte) error {
+func (m *ProviderToken) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1065,10 +4936,10 @@ func (m *AuthCode) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AuthCode: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProviderToken: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AuthCode: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProviderToken: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1137,7 +5008,7 @@ func (m *AuthCode) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1165,11 +5036,11 @@ func (m *AuthCode) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.State = string(dAtA[iNdEx:postIndex])
+			m.AccessToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1197,7 +5068,43 @@ func (m *AuthCode) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Code = string(dAtA[iNdEx:postIndex])
+			m.TokenType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOpenid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOpenid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
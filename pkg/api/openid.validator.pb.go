@@ -8,9 +8,11 @@ import (
 	math "math"
 	proto "github.com/gogo/protobuf/proto"
 	golang_proto "github.com/golang/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	_ "github.com/mwitkow/go-proto-validators"
 	time "time"
+	regexp "regexp"
 	github_com_mwitkow_go_proto_validators "github.com/mwitkow/go-proto-validators"
 )
 
@@ -61,3 +63,133 @@ func (this *AuthCode) Validate() error {
 	}
 	return nil
 }
+func (this *ProvidersRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	return nil
+}
+func (this *ProviderInfo) Validate() error {
+	return nil
+}
+func (this *ProviderList) Validate() error {
+	for _, item := range this.Providers {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Providers", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_IdentitiesRequest_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *IdentitiesRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_IdentitiesRequest_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	return nil
+}
+func (this *LinkedIdentity) Validate() error {
+	if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(&(this.Created)); err != nil {
+		return github_com_mwitkow_go_proto_validators.FieldError("Created", err)
+	}
+	return nil
+}
+func (this *IdentityList) Validate() error {
+	for _, item := range this.Identities {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Identities", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _regex_LinkRequest_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *LinkRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_LinkRequest_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.Provider == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Provider", fmt.Errorf(`value '%v' must not be an empty string`, this.Provider))
+	}
+	if this.RedirectURI == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("RedirectURI", fmt.Errorf(`value '%v' must not be an empty string`, this.RedirectURI))
+	}
+	// Validation of proto3 map<> fields is unsupported.
+	return nil
+}
+
+var _regex_UnlinkRequest_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *UnlinkRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_UnlinkRequest_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.Provider == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Provider", fmt.Errorf(`value '%v' must not be an empty string`, this.Provider))
+	}
+	return nil
+}
+func (this *LinkRequired) Validate() error {
+	if this.Expires != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Expires); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Expires", err)
+		}
+	}
+	return nil
+}
+func (this *LinkConfirm) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	return nil
+}
+
+var _regex_AccessTokenRequest_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *AccessTokenRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_AccessTokenRequest_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.Provider == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Provider", fmt.Errorf(`value '%v' must not be an empty string`, this.Provider))
+	}
+	return nil
+}
+func (this *ProviderToken) Validate() error {
+	if this.Expires != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Expires); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Expires", err)
+		}
+	}
+	return nil
+}
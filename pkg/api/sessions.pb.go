@@ -0,0 +1,2658 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: sessions.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types "github.com/gogo/protobuf/types"
+	golang_proto "github.com/golang/protobuf/proto"
+	_ "github.com/mwitkow/go-proto-validators"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = golang_proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RefreshToken используется для обновления или завершения сессии.
+type RefreshToken struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// токен обновления сессии
+	RefreshToken string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+}
+
+func (m *RefreshToken) Reset()         { *m = RefreshToken{} }
+func (m *RefreshToken) String() string { return proto.CompactTextString(m) }
+func (*RefreshToken) ProtoMessage()    {}
+func (*RefreshToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{0}
+}
+func (m *RefreshToken) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RefreshToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RefreshToken.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RefreshToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshToken.Merge(m, src)
+}
+func (m *RefreshToken) XXX_Size() int {
+	return m.Size()
+}
+func (m *RefreshToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefreshToken proto.InternalMessageInfo
+
+// UserSessions используется для получения списка или завершения всех сессий
+// пользователя.
+type UserSessions struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (m *UserSessions) Reset()         { *m = UserSessions{} }
+func (m *UserSessions) String() string { return proto.CompactTextString(m) }
+func (*UserSessions) ProtoMessage()    {}
+func (*UserSessions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{1}
+}
+func (m *UserSessions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UserSessions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UserSessions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UserSessions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserSessions.Merge(m, src)
+}
+func (m *UserSessions) XXX_Size() int {
+	return m.Size()
+}
+func (m *UserSessions) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserSessions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserSessions proto.InternalMessageInfo
+
+// SessionID используется для завершения сессии пользователя.
+type SessionID struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// уникальный идентификатор сессии
+	ID string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *SessionID) Reset()         { *m = SessionID{} }
+func (m *SessionID) String() string { return proto.CompactTextString(m) }
+func (*SessionID) ProtoMessage()    {}
+func (*SessionID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{2}
+}
+func (m *SessionID) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionID.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionID.Merge(m, src)
+}
+func (m *SessionID) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionID) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionID proto.InternalMessageInfo
+
+// SessionList описывает список сессий пользователя.
+type SessionList struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// список сессий
+	Sessions []*SessionInfo `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (m *SessionList) Reset()         { *m = SessionList{} }
+func (m *SessionList) String() string { return proto.CompactTextString(m) }
+func (*SessionList) ProtoMessage()    {}
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{3}
+}
+func (m *SessionList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionList.Merge(m, src)
+}
+func (m *SessionList) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionList proto.InternalMessageInfo
+
+// SessionInfo описывает информацию о сессии пользователя.
+type SessionInfo struct {
+	// уникальный идентификатор сессии
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// домен, для которого была произведена авторизация
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// провайдер авторизации (пустой для авторизации по паролю или токену)
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	// IP-адрес клиента
+	IP string `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	// информация о приложении клиента
+	UserAgent string `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// дата и время создания сессии
+	Created *time.Time `protobuf:"bytes,6,opt,name=created,proto3,stdtime" json:"created,omitempty"`
+	// дата и время последнего обновления сессии
+	Used *time.Time `protobuf:"bytes,7,opt,name=used,proto3,stdtime" json:"used,omitempty"`
+	// дата и время окончания действия сессии
+	Expires *time.Time `protobuf:"bytes,8,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
+}
+
+func (m *SessionInfo) Reset()         { *m = SessionInfo{} }
+func (m *SessionInfo) String() string { return proto.CompactTextString(m) }
+func (*SessionInfo) ProtoMessage()    {}
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{4}
+}
+func (m *SessionInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionInfo.Merge(m, src)
+}
+func (m *SessionInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionInfo proto.InternalMessageInfo
+
+// KeySet описывает список открытых ключей в формате JWKS.
+type KeySet struct {
+	// список ключей
+	Keys []*PublicKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (m *KeySet) Reset()         { *m = KeySet{} }
+func (m *KeySet) String() string { return proto.CompactTextString(m) }
+func (*KeySet) ProtoMessage()    {}
+func (*KeySet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{5}
+}
+func (m *KeySet) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeySet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeySet.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeySet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeySet.Merge(m, src)
+}
+func (m *KeySet) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeySet) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeySet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeySet proto.InternalMessageInfo
+
+// PublicKey описывает открытый ключ в формате JWK (RFC 7517). В зависимости
+// от типа ключа заполняются только соответствующие ему поля.
+type PublicKey struct {
+	// идентификатор ключа
+	KeyID string `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	// тип ключа: "RSA" или "EC"
+	KeyType string `protobuf:"bytes,2,opt,name=kty,proto3" json:"kty,omitempty"`
+	// алгоритм подписи
+	Algorithm string `protobuf:"bytes,3,opt,name=alg,proto3" json:"alg,omitempty"`
+	// назначение ключа (всегда "sig")
+	Use string `protobuf:"bytes,4,opt,name=use,proto3" json:"use,omitempty"`
+	// модуль ключа RSA
+	N string `protobuf:"bytes,5,opt,name=n,proto3" json:"n,omitempty"`
+	// экспонента ключа RSA
+	E string `protobuf:"bytes,6,opt,name=e,proto3" json:"e,omitempty"`
+	// название кривой ключа EC
+	Curve string `protobuf:"bytes,7,opt,name=crv,proto3" json:"crv,omitempty"`
+	// координата X ключа EC
+	X string `protobuf:"bytes,8,opt,name=x,proto3" json:"x,omitempty"`
+	// координата Y ключа EC
+	Y string `protobuf:"bytes,9,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (m *PublicKey) Reset()         { *m = PublicKey{} }
+func (m *PublicKey) String() string { return proto.CompactTextString(m) }
+func (*PublicKey) ProtoMessage()    {}
+func (*PublicKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0475a55364240b58, []int{6}
+}
+func (m *PublicKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublicKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublicKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PublicKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublicKey.Merge(m, src)
+}
+func (m *PublicKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublicKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublicKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublicKey proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*RefreshToken)(nil), "itube.users.RefreshToken")
+	golang_proto.RegisterType((*RefreshToken)(nil), "itube.users.RefreshToken")
+	proto.RegisterType((*UserSessions)(nil), "itube.users.UserSessions")
+	golang_proto.RegisterType((*UserSessions)(nil), "itube.users.UserSessions")
+	proto.RegisterType((*SessionID)(nil), "itube.users.SessionID")
+	golang_proto.RegisterType((*SessionID)(nil), "itube.users.SessionID")
+	proto.RegisterType((*SessionList)(nil), "itube.users.SessionList")
+	golang_proto.RegisterType((*SessionList)(nil), "itube.users.SessionList")
+	proto.RegisterType((*SessionInfo)(nil), "itube.users.SessionInfo")
+	golang_proto.RegisterType((*SessionInfo)(nil), "itube.users.SessionInfo")
+	proto.RegisterType((*KeySet)(nil), "itube.users.KeySet")
+	golang_proto.RegisterType((*KeySet)(nil), "itube.users.KeySet")
+	proto.RegisterType((*PublicKey)(nil), "itube.users.PublicKey")
+	golang_proto.RegisterType((*PublicKey)(nil), "itube.users.PublicKey")
+}
+
+func init() { proto.RegisterFile("sessions.proto", fileDescriptor_0475a55364240b58) }
+func init() { golang_proto.RegisterFile("sessions.proto", fileDescriptor_0475a55364240b58) }
+
+var fileDescriptor_0475a55364240b58 = []byte{
+	// 809 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0x4d, 0x6f, 0xdb, 0x46,
+	0x10, 0xd5, 0x52, 0xb2, 0x3e, 0x46, 0x4e, 0xd1, 0x6e, 0x00, 0x81, 0x51, 0x10, 0x52, 0xd5, 0xa5,
+	0x86, 0x0b, 0x4b, 0xa8, 0x6b, 0xf4, 0x2b, 0x40, 0x01, 0xab, 0xca, 0xc1, 0x70, 0x50, 0x04, 0x1b,
+	0x07, 0x70, 0x9b, 0x43, 0x40, 0x99, 0x23, 0x7a, 0x21, 0x89, 0xcb, 0x92, 0x4b, 0xc5, 0xfc, 0x07,
+	0x3d, 0xfa, 0xcf, 0xf4, 0x5a, 0xf4, 0x98, 0xa3, 0x8f, 0x3d, 0xc9, 0x0d, 0x75, 0xec, 0xad, 0xbf,
+	0xa0, 0xd8, 0x15, 0x45, 0x49, 0x75, 0x9c, 0xfa, 0x94, 0x13, 0xb9, 0x33, 0xef, 0xbd, 0x79, 0x1c,
+	0xce, 0x2c, 0x7c, 0x14, 0x61, 0x14, 0x71, 0xe1, 0x47, 0x9d, 0x20, 0x14, 0x52, 0xd0, 0x3a, 0x97,
+	0xf1, 0x00, 0x3b, 0x71, 0x84, 0x61, 0xd4, 0x04, 0xf5, 0x58, 0x24, 0x9a, 0x0f, 0x3d, 0x21, 0xbc,
+	0x31, 0x76, 0xf5, 0x69, 0x10, 0x0f, 0xbb, 0x38, 0x09, 0x64, 0x92, 0x25, 0xed, 0xff, 0x26, 0x25,
+	0x9f, 0x60, 0x24, 0x9d, 0x49, 0x90, 0x01, 0xf6, 0x3c, 0x2e, 0xcf, 0xe3, 0x41, 0xe7, 0x4c, 0x4c,
+	0xba, 0x9e, 0xf0, 0xc4, 0x0a, 0xa9, 0x4e, 0xfa, 0xa0, 0xdf, 0x32, 0xf8, 0x57, 0x6b, 0xf0, 0xc9,
+	0x6b, 0x2e, 0x47, 0xe2, 0x75, 0xd7, 0x13, 0x7b, 0x3a, 0xb9, 0x37, 0x75, 0xc6, 0xdc, 0x75, 0xa4,
+	0x08, 0xa3, 0x6e, 0xfe, 0xba, 0xe0, 0xb5, 0x5f, 0xc2, 0x36, 0xc3, 0x61, 0x88, 0xd1, 0xf9, 0x89,
+	0x18, 0xa1, 0x4f, 0x2d, 0x28, 0xbb, 0x62, 0xe2, 0x70, 0xdf, 0x24, 0x2d, 0xb2, 0x53, 0xeb, 0x95,
+	0xd3, 0x6b, 0xdb, 0x38, 0x25, 0x2c, 0x8b, 0xd2, 0xcf, 0xe1, 0x5e, 0xb8, 0xc0, 0xbf, 0x92, 0x8a,
+	0x60, 0x1a, 0x1b, 0xb0, 0xed, 0x70, 0x4d, 0xac, 0x2d, 0x61, 0xfb, 0x45, 0x84, 0xe1, 0xf3, 0xac,
+	0x61, 0xff, 0x2b, 0xde, 0x87, 0x62, 0xcc, 0xdd, 0x4c, 0x72, 0x3f, 0x9d, 0xd9, 0xc5, 0x17, 0x47,
+	0xfd, 0xf4, 0xda, 0xfe, 0x6c, 0xb7, 0xc5, 0x7d, 0xed, 0xbc, 0x15, 0xfb, 0xfc, 0x97, 0x18, 0x5b,
+	0xdc, 0x45, 0x5f, 0xf2, 0x21, 0xc7, 0xb0, 0x35, 0x14, 0xe1, 0xc4, 0x91, 0xa7, 0xe4, 0x92, 0x94,
+	0x98, 0xa2, 0xb7, 0x7f, 0x23, 0x50, 0xcb, 0x4a, 0x1e, 0xf5, 0x3f, 0x4c, 0x4d, 0xda, 0x03, 0x83,
+	0xbb, 0x66, 0x31, 0x17, 0x31, 0xb4, 0xc6, 0x8e, 0x46, 0xec, 0x7e, 0xba, 0x14, 0xca, 0x26, 0xe7,
+	0xa6, 0x12, 0x33, 0xb8, 0xdb, 0x7e, 0x09, 0xf5, 0xcc, 0xf6, 0x53, 0x1e, 0x49, 0xda, 0xd8, 0x34,
+	0x9e, 0x1b, 0x3e, 0x80, 0xea, 0x72, 0x02, 0x4d, 0xa3, 0x55, 0xdc, 0xa9, 0xef, 0x9b, 0x9d, 0xb5,
+	0x11, 0xec, 0x2c, 0x3f, 0xdd, 0x1f, 0x0a, 0x96, 0x23, 0xdb, 0xbf, 0x1b, 0x50, 0x5f, 0xcb, 0xd0,
+	0x86, 0x36, 0xbc, 0x6c, 0x89, 0x36, 0xac, 0x4c, 0xac, 0x55, 0x35, 0x36, 0xaa, 0x36, 0xa1, 0x1a,
+	0x84, 0x62, 0xca, 0x5d, 0x0c, 0x17, 0x9f, 0xc9, 0xf2, 0xb3, 0xd6, 0x0a, 0xcc, 0xd2, 0x9a, 0xd6,
+	0x33, 0x66, 0xf0, 0x80, 0x3e, 0x02, 0xbd, 0x0e, 0xaf, 0x1c, 0x0f, 0x7d, 0x69, 0x6e, 0x69, 0x56,
+	0x4d, 0x45, 0x0e, 0x55, 0x80, 0x7e, 0x07, 0x95, 0xb3, 0x10, 0x1d, 0x89, 0xae, 0x59, 0x6e, 0x91,
+	0x9d, 0xfa, 0x7e, 0xb3, 0xb3, 0x58, 0x8a, 0xce, 0x72, 0xd4, 0x3b, 0x27, 0xcb, 0xa5, 0xe8, 0x95,
+	0x2e, 0xaf, 0x6d, 0xc2, 0x96, 0x04, 0x7a, 0x00, 0xa5, 0x38, 0x42, 0xd7, 0xac, 0xdc, 0x91, 0xa8,
+	0xd1, 0xaa, 0x22, 0x5e, 0x04, 0x3c, 0xc4, 0xc8, 0xac, 0xde, 0xb5, 0x62, 0x46, 0x68, 0x1f, 0x40,
+	0xf9, 0x18, 0x93, 0xe7, 0x28, 0xe9, 0x2e, 0x94, 0x46, 0x98, 0x44, 0x26, 0xd1, 0xcd, 0x6f, 0x6c,
+	0x34, 0xff, 0x59, 0x3c, 0x18, 0xf3, 0xb3, 0x63, 0x4c, 0x98, 0xc6, 0xb4, 0xff, 0x26, 0x50, 0xcb,
+	0x63, 0xf4, 0x21, 0x14, 0x47, 0x79, 0xd7, 0x6b, 0xe9, 0xcc, 0xde, 0x3a, 0xc6, 0xe4, 0xa8, 0xcf,
+	0x54, 0x94, 0x3e, 0x82, 0xe2, 0x48, 0x26, 0xd9, 0x20, 0xd6, 0xd3, 0x99, 0x5d, 0x39, 0xc6, 0xe4,
+	0x24, 0x09, 0x90, 0xa9, 0x38, 0xb5, 0xa1, 0xe8, 0x8c, 0xbd, 0x6c, 0xc4, 0xee, 0xa5, 0x33, 0xbb,
+	0x76, 0x38, 0xf6, 0x44, 0xc8, 0xe5, 0xf9, 0x84, 0xa9, 0x0c, 0xfd, 0x18, 0x8a, 0x71, 0x84, 0x8b,
+	0xdf, 0xc0, 0xd4, 0x2b, 0xbd, 0x0f, 0xc4, 0x5f, 0xb4, 0xbd, 0xb7, 0x95, 0xce, 0x6c, 0xf2, 0x23,
+	0x23, 0xbe, 0x0a, 0xa2, 0x59, 0x5e, 0x05, 0x9f, 0x30, 0x82, 0xca, 0xd8, 0x59, 0x38, 0x35, 0x2b,
+	0x2b, 0x63, 0x3f, 0xc4, 0xe1, 0x14, 0x99, 0x8a, 0x2a, 0xc6, 0x85, 0x59, 0x5d, 0x31, 0x4e, 0x19,
+	0xb9, 0x50, 0xc1, 0xc4, 0xac, 0xad, 0x82, 0x3f, 0x31, 0x92, 0xec, 0xff, 0x63, 0x40, 0x35, 0x5f,
+	0xf6, 0xaf, 0xa1, 0x92, 0xdd, 0x2c, 0xf4, 0xc1, 0x46, 0x8f, 0xd6, 0xef, 0x9b, 0xe6, 0x27, 0x1b,
+	0x29, 0x75, 0x5b, 0xd0, 0xc7, 0x50, 0x7e, 0x2a, 0x3c, 0x11, 0xcb, 0xf7, 0xf1, 0x1a, 0x37, 0xfe,
+	0xdc, 0x13, 0x75, 0xbb, 0xd2, 0x6f, 0x01, 0xf2, 0x7e, 0x47, 0xf4, 0x16, 0x54, 0xf3, 0xfe, 0x86,
+	0x70, 0xf6, 0x5f, 0x1f, 0x43, 0x49, 0x2f, 0xde, 0x83, 0x1b, 0x96, 0x96, 0xdf, 0xd4, 0x7c, 0xe7,
+	0xa6, 0x69, 0xd2, 0x37, 0x50, 0x66, 0x38, 0x15, 0x23, 0xa4, 0x8d, 0x77, 0x6e, 0x63, 0xff, 0x56,
+	0xc7, 0xdf, 0x43, 0x6d, 0xc1, 0x3c, 0x1c, 0x8f, 0xdf, 0x57, 0xfb, 0x16, 0x7e, 0xef, 0x8b, 0x37,
+	0x6f, 0xad, 0xc2, 0xd5, 0x5b, 0xab, 0xf0, 0x26, 0xb5, 0xc8, 0x55, 0x6a, 0x91, 0xbf, 0x52, 0x8b,
+	0xfc, 0x3a, 0xb7, 0x0a, 0x97, 0x73, 0xab, 0xf0, 0xc7, 0xdc, 0x22, 0x57, 0x73, 0xab, 0xf0, 0xe7,
+	0xdc, 0x2a, 0xfc, 0x5c, 0x09, 0x46, 0x5e, 0xd7, 0x09, 0xf8, 0xa0, 0xac, 0x25, 0xbe, 0xfc, 0x77,
+	0x00, 0xde, 0xdd, 0x18, 0x42, 0xcb, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SessionsClient is the client API for Sessions service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SessionsClient interface {
+	// Refresh проверяет токен обновления и возвращает актуальную информацию
+	// о пользователе с новой парой токенов доступа и обновления. Старый токен
+	// обновления после этого становится недействительным. Токен принимается
+	// только для сессии домена из запроса.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь заблокирован
+	//  - InvalidArgument - неверный или устаревший токен обновления или токен
+	//    сессии другого домена
+	//  - Internal - внутренние ошибки
+	Refresh(ctx context.Context, in *RefreshToken, opts ...grpc.CallOption) (*User, error)
+	// Logout завершает сессию пользователя. Токен обновления после этого
+	// становится недействительным. Уже выданные токены доступа продолжают
+	// действовать до окончания их времени жизни.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный токен обновления
+	//  - Internal - внутренние ошибки
+	Logout(ctx context.Context, in *RefreshToken, opts ...grpc.CallOption) (*types.Empty, error)
+	// PublicKeys возвращает список открытых ключей для проверки подписи
+	// токенов доступа в формате JWKS (RFC 7517).
+	PublicKeys(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*KeySet, error)
+	// List возвращает список действующих сессий пользователя, начиная с
+	// последней использованной.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	List(ctx context.Context, in *UserSessions, opts ...grpc.CallOption) (*SessionList, error)
+	// Revoke завершает указанную сессию пользователя.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - сессия не найдена
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Revoke(ctx context.Context, in *SessionID, opts ...grpc.CallOption) (*types.Empty, error)
+	// RevokeAll завершает все сессии пользователя.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	RevokeAll(ctx context.Context, in *UserSessions, opts ...grpc.CallOption) (*types.Empty, error)
+}
+
+type sessionsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSessionsClient(cc *grpc.ClientConn) SessionsClient {
+	return &sessionsClient{cc}
+}
+
+func (c *sessionsClient) Refresh(ctx context.Context, in *RefreshToken, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/itube.users.Sessions/Refresh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) Logout(ctx context.Context, in *RefreshToken, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/itube.users.Sessions/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) PublicKeys(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*KeySet, error) {
+	out := new(KeySet)
+	err := c.cc.Invoke(ctx, "/itube.users.Sessions/PublicKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) List(ctx context.Context, in *UserSessions, opts ...grpc.CallOption) (*SessionList, error) {
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, "/itube.users.Sessions/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) Revoke(ctx context.Context, in *SessionID, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/itube.users.Sessions/Revoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) RevokeAll(ctx context.Context, in *UserSessions, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/itube.users.Sessions/RevokeAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SessionsServer is the server API for Sessions service.
+type SessionsServer interface {
+	// Refresh проверяет токен обновления и возвращает актуальную информацию
+	// о пользователе с новой парой токенов доступа и обновления. Старый токен
+	// обновления после этого становится недействительным. Токен принимается
+	// только для сессии домена из запроса.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь заблокирован
+	//  - InvalidArgument - неверный или устаревший токен обновления или токен
+	//    сессии другого домена
+	//  - Internal - внутренние ошибки
+	Refresh(context.Context, *RefreshToken) (*User, error)
+	// Logout завершает сессию пользователя. Токен обновления после этого
+	// становится недействительным. Уже выданные токены доступа продолжают
+	// действовать до окончания их времени жизни.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный токен обновления
+	//  - Internal - внутренние ошибки
+	Logout(context.Context, *RefreshToken) (*types.Empty, error)
+	// PublicKeys возвращает список открытых ключей для проверки подписи
+	// токенов доступа в формате JWKS (RFC 7517).
+	PublicKeys(context.Context, *types.Empty) (*KeySet, error)
+	// List возвращает список действующих сессий пользователя, начиная с
+	// последней использованной.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	List(context.Context, *UserSessions) (*SessionList, error)
+	// Revoke завершает указанную сессию пользователя.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - сессия не найдена
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Revoke(context.Context, *SessionID) (*types.Empty, error)
+	// RevokeAll завершает все сессии пользователя.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	RevokeAll(context.Context, *UserSessions) (*types.Empty, error)
+}
+
+// UnimplementedSessionsServer can be embedded to have forward compatible implementations.
+type UnimplementedSessionsServer struct {
+}
+
+func (*UnimplementedSessionsServer) Refresh(ctx context.Context, req *RefreshToken) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (*UnimplementedSessionsServer) Logout(ctx context.Context, req *RefreshToken) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (*UnimplementedSessionsServer) PublicKeys(ctx context.Context, req *types.Empty) (*KeySet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublicKeys not implemented")
+}
+func (*UnimplementedSessionsServer) List(ctx context.Context, req *UserSessions) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedSessionsServer) Revoke(ctx context.Context, req *SessionID) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (*UnimplementedSessionsServer) RevokeAll(ctx context.Context, req *UserSessions) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAll not implemented")
+}
+
+func RegisterSessionsServer(s *grpc.Server, srv SessionsServer) {
+	s.RegisterService(&_Sessions_serviceDesc, srv)
+}
+
+func _Sessions_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshToken)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Sessions/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).Refresh(ctx, req.(*RefreshToken))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshToken)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Sessions/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).Logout(ctx, req.(*RefreshToken))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_PublicKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).PublicKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Sessions/PublicKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).PublicKeys(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSessions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Sessions/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).List(ctx, req.(*UserSessions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Sessions/Revoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).Revoke(ctx, req.(*SessionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_RevokeAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSessions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).RevokeAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Sessions/RevokeAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).RevokeAll(ctx, req.(*UserSessions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Sessions_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "itube.users.Sessions",
+	HandlerType: (*SessionsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Refresh",
+			Handler:    _Sessions_Refresh_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Sessions_Logout_Handler,
+		},
+		{
+			MethodName: "PublicKeys",
+			Handler:    _Sessions_PublicKeys_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Sessions_List_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _Sessions_Revoke_Handler,
+		},
+		{
+			MethodName: "RevokeAll",
+			Handler:    _Sessions_RevokeAll_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sessions.proto",
+}
+
+func (m *RefreshToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RefreshToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RefreshToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RefreshToken) > 0 {
+		i -= len(m.RefreshToken)
+		copy(dAtA[i:], m.RefreshToken)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.RefreshToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserSessions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserSessions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserSessions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionID) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sessions) > 0 {
+		for iNdEx := len(m.Sessions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sessions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSessions(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintSessions(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Used != nil {
+		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Used, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Used):])
+		if err2 != nil {
+			return 0, err2
+		}
+		i -= n2
+		i = encodeVarintSessions(dAtA, i, uint64(n2))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Created != nil {
+		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created):])
+		if err3 != nil {
+			return 0, err3
+		}
+		i -= n3
+		i = encodeVarintSessions(dAtA, i, uint64(n3))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.UserAgent) > 0 {
+		i -= len(m.UserAgent)
+		copy(dAtA[i:], m.UserAgent)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.UserAgent)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.IP) > 0 {
+		i -= len(m.IP)
+		copy(dAtA[i:], m.IP)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.IP)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Provider) > 0 {
+		i -= len(m.Provider)
+		copy(dAtA[i:], m.Provider)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Provider)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeySet) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeySet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeySet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Keys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSessions(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PublicKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublicKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PublicKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Y) > 0 {
+		i -= len(m.Y)
+		copy(dAtA[i:], m.Y)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Y)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.X) > 0 {
+		i -= len(m.X)
+		copy(dAtA[i:], m.X)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.X)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Curve) > 0 {
+		i -= len(m.Curve)
+		copy(dAtA[i:], m.Curve)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Curve)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.E) > 0 {
+		i -= len(m.E)
+		copy(dAtA[i:], m.E)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.E)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.N) > 0 {
+		i -= len(m.N)
+		copy(dAtA[i:], m.N)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.N)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Use) > 0 {
+		i -= len(m.Use)
+		copy(dAtA[i:], m.Use)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Use)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Algorithm) > 0 {
+		i -= len(m.Algorithm)
+		copy(dAtA[i:], m.Algorithm)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.Algorithm)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.KeyType) > 0 {
+		i -= len(m.KeyType)
+		copy(dAtA[i:], m.KeyType)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.KeyType)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.KeyID) > 0 {
+		i -= len(m.KeyID)
+		copy(dAtA[i:], m.KeyID)
+		i = encodeVarintSessions(dAtA, i, uint64(len(m.KeyID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSessions(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSessions(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RefreshToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.RefreshToken)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	return n
+}
+
+func (m *UserSessions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	return n
+}
+
+func (m *SessionID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	return n
+}
+
+func (m *SessionList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	if len(m.Sessions) > 0 {
+		for _, e := range m.Sessions {
+			l = e.Size()
+			n += 1 + l + sovSessions(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SessionInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.Provider)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.IP)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.UserAgent)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	if m.Created != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created)
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	if m.Used != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Used)
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	return n
+}
+
+func (m *KeySet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, e := range m.Keys {
+			l = e.Size()
+			n += 1 + l + sovSessions(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PublicKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.KeyID)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.KeyType)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.Algorithm)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.Use)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.N)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.E)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.Curve)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.X)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	l = len(m.Y)
+	if l > 0 {
+		n += 1 + l + sovSessions(uint64(l))
+	}
+	return n
+}
+
+func sovSessions(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSessions(x uint64) (n int) {
+	return sovSessions(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RefreshToken) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RefreshToken: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RefreshToken: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UserSessions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UserSessions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UserSessions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sessions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sessions = append(m.Sessions, &SessionInfo{})
+			if err := m.Sessions[len(m.Sessions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provider", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provider = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserAgent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserAgent = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Used", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Used == nil {
+				m.Used = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Used, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeySet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeySet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeySet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, &PublicKey{})
+			if err := m.Keys[len(m.Keys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PublicKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublicKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublicKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Algorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Algorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Use", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Use = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field N", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.N = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field E", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.E = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Curve", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Curve = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field X", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.X = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Y", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSessions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Y = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSessions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthSessions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSessions(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSessions
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSessions
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSessions
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSessions
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSessions
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSessions        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSessions          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSessions = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,112 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: sessions.proto
+
+package api
+
+import (
+	fmt "fmt"
+	math "math"
+	proto "github.com/gogo/protobuf/proto"
+	golang_proto "github.com/golang/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	_ "github.com/mwitkow/go-proto-validators"
+	time "time"
+	regexp "regexp"
+	github_com_mwitkow_go_proto_validators "github.com/mwitkow/go-proto-validators"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = golang_proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+func (this *RefreshToken) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if this.RefreshToken == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("RefreshToken", fmt.Errorf(`value '%v' must not be an empty string`, this.RefreshToken))
+	}
+	return nil
+}
+
+var _regex_UserSessions_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *UserSessions) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_UserSessions_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	return nil
+}
+
+var _regex_SessionID_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+var _regex_SessionID_ID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *SessionID) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_SessionID_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if !_regex_SessionID_ID.MatchString(this.ID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("ID", fmt.Errorf(`invalid session identifier format`))
+	}
+	if this.ID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("ID", fmt.Errorf(`invalid session identifier format`))
+	}
+	return nil
+}
+func (this *SessionList) Validate() error {
+	for _, item := range this.Sessions {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Sessions", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *SessionInfo) Validate() error {
+	if this.Created != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Created); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Created", err)
+		}
+	}
+	if this.Used != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Used); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Used", err)
+		}
+	}
+	if this.Expires != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Expires); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Expires", err)
+		}
+	}
+	return nil
+}
+func (this *KeySet) Validate() error {
+	for _, item := range this.Keys {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Keys", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *PublicKey) Validate() error {
+	return nil
+}
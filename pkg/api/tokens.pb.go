@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	golang_proto "github.com/golang/protobuf/proto"
 	_ "github.com/mwitkow/go-proto-validators"
 	grpc "google.golang.org/grpc"
@@ -16,6 +18,7 @@ import (
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -23,6 +26,7 @@ var _ = proto.Marshal
 var _ = golang_proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -36,16 +40,26 @@ type TokenType int32
 const (
 	EMAIL    TokenType = 0
 	PASSWORD TokenType = 1
+	// уведомление о попытке повторной регистрации; такие токены создаются
+	// только сервисом и не могут быть проверены
+	REGISTERED TokenType = 2
+	// подтверждение привязки внешнего провайдера авторизации к учетной
+	// записи; такие токены создаются и проверяются только сервисом OpenID
+	LINK TokenType = 3
 )
 
 var TokenType_name = map[int32]string{
 	0: "EMAIL",
 	1: "PASSWORD",
+	2: "REGISTERED",
+	3: "LINK",
 }
 
 var TokenType_value = map[string]int32{
-	"EMAIL":    0,
-	"PASSWORD": 1,
+	"EMAIL":      0,
+	"PASSWORD":   1,
+	"REGISTERED": 2,
+	"LINK":       3,
 }
 
 func (x TokenType) String() string {
@@ -104,8 +118,8 @@ var xxx_messageInfo_VerifyRequest proto.InternalMessageInfo
 
 // TokenInfo описывает данные для проверки почтового адреса или сброса пароля
 // по токену. Физически токен не привязан к домену и, чисто теоретически,
-// может быть подтвержден на любом сайте. Тип проверки является чисто
-// информационным и не влияет на уникальность токена.
+// может быть подтвержден на любом сайте. Тип проверки должен совпадать с
+// типом, с которым токен был сгенерирован.
 type TokenInfo struct {
 	// домен
 	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
@@ -148,6 +162,269 @@ func (m *TokenInfo) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TokenInfo proto.InternalMessageInfo
 
+// PasswordReset описывает данные для замены пароля пользователя по токену
+// сброса пароля.
+type PasswordReset struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// полученный токен для сброса пароля
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// новый пароль пользователя
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (m *PasswordReset) Reset()         { *m = PasswordReset{} }
+func (m *PasswordReset) String() string { return proto.CompactTextString(m) }
+func (*PasswordReset) ProtoMessage()    {}
+func (*PasswordReset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7213d78cc820f18a, []int{2}
+}
+func (m *PasswordReset) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PasswordReset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PasswordReset.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PasswordReset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PasswordReset.Merge(m, src)
+}
+func (m *PasswordReset) XXX_Size() int {
+	return m.Size()
+}
+func (m *PasswordReset) XXX_DiscardUnknown() {
+	xxx_messageInfo_PasswordReset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PasswordReset proto.InternalMessageInfo
+
+// OutboxRequest описывает запрос списка неотправленных писем домена.
+type OutboxRequest struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// возвращать только письма, попытки отправки которых прекращены
+	Failed bool `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (m *OutboxRequest) Reset()         { *m = OutboxRequest{} }
+func (m *OutboxRequest) String() string { return proto.CompactTextString(m) }
+func (*OutboxRequest) ProtoMessage()    {}
+func (*OutboxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7213d78cc820f18a, []int{3}
+}
+func (m *OutboxRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OutboxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OutboxRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OutboxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutboxRequest.Merge(m, src)
+}
+func (m *OutboxRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *OutboxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutboxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutboxRequest proto.InternalMessageInfo
+
+// OutboxMessage описывает неотправленное письмо с токеном.
+type OutboxMessage struct {
+	// почтовый адрес получателя
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// тип токена
+	Type TokenType `protobuf:"varint,2,opt,name=type,proto3,enum=itube.users.TokenType" json:"type,omitempty"`
+	// количество неудачных попыток отправки
+	Attempts int32 `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// дата и время следующей попытки отправки
+	NextAttempt *time.Time `protobuf:"bytes,4,opt,name=next_attempt,json=nextAttempt,proto3,stdtime" json:"next_attempt,omitempty"`
+	// ошибка последней попытки отправки
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// флаг, что попытки отправки прекращены
+	Failed bool `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	// дата и время создания токена
+	Created *time.Time `protobuf:"bytes,7,opt,name=created,proto3,stdtime" json:"created,omitempty"`
+}
+
+func (m *OutboxMessage) Reset()         { *m = OutboxMessage{} }
+func (m *OutboxMessage) String() string { return proto.CompactTextString(m) }
+func (*OutboxMessage) ProtoMessage()    {}
+func (*OutboxMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7213d78cc820f18a, []int{4}
+}
+func (m *OutboxMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OutboxMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OutboxMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OutboxMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutboxMessage.Merge(m, src)
+}
+func (m *OutboxMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *OutboxMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutboxMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutboxMessage proto.InternalMessageInfo
+
+// OutboxList описывает список неотправленных писем домена.
+type OutboxList struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// список писем
+	Messages []*OutboxMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *OutboxList) Reset()         { *m = OutboxList{} }
+func (m *OutboxList) String() string { return proto.CompactTextString(m) }
+func (*OutboxList) ProtoMessage()    {}
+func (*OutboxList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7213d78cc820f18a, []int{5}
+}
+func (m *OutboxList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OutboxList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OutboxList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OutboxList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutboxList.Merge(m, src)
+}
+func (m *OutboxList) XXX_Size() int {
+	return m.Size()
+}
+func (m *OutboxList) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutboxList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutboxList proto.InternalMessageInfo
+
+// RequeueRequest описывает запрос на возврат в очередь писем, попытки
+// отправки которых прекращены.
+type RequeueRequest struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// почтовый адрес получателя; если не указан, то в очередь возвращаются
+	// все такие письма домена
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// тип токена; учитывается только вместе с email
+	Type TokenType `protobuf:"varint,3,opt,name=type,proto3,enum=itube.users.TokenType" json:"type,omitempty"`
+}
+
+func (m *RequeueRequest) Reset()         { *m = RequeueRequest{} }
+func (m *RequeueRequest) String() string { return proto.CompactTextString(m) }
+func (*RequeueRequest) ProtoMessage()    {}
+func (*RequeueRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7213d78cc820f18a, []int{6}
+}
+func (m *RequeueRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequeueRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequeueRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequeueRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequeueRequest.Merge(m, src)
+}
+func (m *RequeueRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequeueRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequeueRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequeueRequest proto.InternalMessageInfo
+
+// RequeueResult описывает результат возврата писем в очередь.
+type RequeueResult struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// количество возвращенных в очередь писем
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *RequeueResult) Reset()         { *m = RequeueResult{} }
+func (m *RequeueResult) String() string { return proto.CompactTextString(m) }
+func (*RequeueResult) ProtoMessage()    {}
+func (*RequeueResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7213d78cc820f18a, []int{7}
+}
+func (m *RequeueResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequeueResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequeueResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequeueResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequeueResult.Merge(m, src)
+}
+func (m *RequeueResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequeueResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequeueResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequeueResult proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("itube.users.TokenType", TokenType_name, TokenType_value)
 	golang_proto.RegisterEnum("itube.users.TokenType", TokenType_name, TokenType_value)
@@ -155,36 +432,68 @@ func init() {
 	golang_proto.RegisterType((*VerifyRequest)(nil), "itube.users.VerifyRequest")
 	proto.RegisterType((*TokenInfo)(nil), "itube.users.TokenInfo")
 	golang_proto.RegisterType((*TokenInfo)(nil), "itube.users.TokenInfo")
+	proto.RegisterType((*PasswordReset)(nil), "itube.users.PasswordReset")
+	golang_proto.RegisterType((*PasswordReset)(nil), "itube.users.PasswordReset")
+	proto.RegisterType((*OutboxRequest)(nil), "itube.users.OutboxRequest")
+	golang_proto.RegisterType((*OutboxRequest)(nil), "itube.users.OutboxRequest")
+	proto.RegisterType((*OutboxMessage)(nil), "itube.users.OutboxMessage")
+	golang_proto.RegisterType((*OutboxMessage)(nil), "itube.users.OutboxMessage")
+	proto.RegisterType((*OutboxList)(nil), "itube.users.OutboxList")
+	golang_proto.RegisterType((*OutboxList)(nil), "itube.users.OutboxList")
+	proto.RegisterType((*RequeueRequest)(nil), "itube.users.RequeueRequest")
+	golang_proto.RegisterType((*RequeueRequest)(nil), "itube.users.RequeueRequest")
+	proto.RegisterType((*RequeueResult)(nil), "itube.users.RequeueResult")
+	golang_proto.RegisterType((*RequeueResult)(nil), "itube.users.RequeueResult")
 }
 
 func init() { proto.RegisterFile("tokens.proto", fileDescriptor_7213d78cc820f18a) }
 func init() { golang_proto.RegisterFile("tokens.proto", fileDescriptor_7213d78cc820f18a) }
 
 var fileDescriptor_7213d78cc820f18a = []byte{
-	// 363 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0x9b, 0xe9, 0xea, 0x16, 0xa7, 0xcc, 0x1c, 0x64, 0x14, 0x89, 0x63, 0x78, 0x18, 0x83,
-	0xb5, 0xb8, 0x81, 0x47, 0x61, 0x43, 0x91, 0x81, 0xa2, 0x74, 0xf3, 0x0f, 0xde, 0x5a, 0x97, 0xd5,
-	0xb0, 0xb5, 0xa9, 0x6d, 0xea, 0xe8, 0xc1, 0xbb, 0x47, 0x3f, 0x92, 0xc7, 0x1d, 0x77, 0xf4, 0xa6,
-	0x6b, 0xbf, 0x88, 0x2c, 0x1d, 0xb2, 0x8a, 0x82, 0x9e, 0xf2, 0xbe, 0xbf, 0xf7, 0x49, 0x1e, 0x9e,
-	0x37, 0xb0, 0xc0, 0xd9, 0x90, 0x38, 0xbe, 0xea, 0x7a, 0x8c, 0x33, 0xb4, 0x4e, 0x79, 0x60, 0x12,
-	0x35, 0xf0, 0x89, 0xe7, 0x2b, 0x70, 0x7e, 0x24, 0x03, 0xa5, 0x6e, 0x51, 0x7e, 0x1f, 0x98, 0xea,
-	0x1d, 0xb3, 0x35, 0x8b, 0x59, 0x4c, 0x13, 0xd8, 0x0c, 0x06, 0xa2, 0x13, 0x8d, 0xa8, 0x16, 0xf2,
-	0x83, 0x25, 0xb9, 0x3d, 0xa6, 0x7c, 0xc8, 0xc6, 0x9a, 0xc5, 0xea, 0x62, 0x58, 0x7f, 0x34, 0x46,
-	0xb4, 0x6f, 0x70, 0xe6, 0xf9, 0xda, 0x57, 0x99, 0xdc, 0xab, 0x84, 0x70, 0xe3, 0x8a, 0x78, 0x74,
-	0x10, 0xea, 0xe4, 0x21, 0x20, 0x3e, 0x47, 0x18, 0xca, 0x7d, 0x66, 0x1b, 0xd4, 0x29, 0x81, 0x32,
-	0xa8, 0xe6, 0xdb, 0x72, 0xf4, 0xbe, 0x9b, 0xb9, 0x01, 0xfa, 0x82, 0xa2, 0x1d, 0x98, 0x25, 0xb6,
-	0x41, 0x47, 0xa5, 0x4c, 0x6a, 0x9c, 0x40, 0x54, 0x83, 0xab, 0x3c, 0x74, 0x49, 0x69, 0xa5, 0x0c,
-	0xaa, 0x9b, 0x8d, 0x6d, 0x75, 0x29, 0x9d, 0xda, 0x9b, 0xe7, 0xee, 0x85, 0x2e, 0xd1, 0x85, 0xa6,
-	0x12, 0xc0, 0xbc, 0x40, 0x1d, 0x67, 0xc0, 0xfe, 0x62, 0x2b, 0xf6, 0xf6, 0xdd, 0x56, 0xc0, 0xff,
-	0xd8, 0xd6, 0xf6, 0x16, 0xb6, 0x73, 0x84, 0xf2, 0x30, 0x7b, 0x7c, 0xd6, 0xea, 0x9c, 0x16, 0x25,
-	0x54, 0x80, 0xb9, 0x8b, 0x56, 0xb7, 0x7b, 0x7d, 0xae, 0x1f, 0x15, 0x41, 0xe3, 0x09, 0xca, 0x42,
-	0xe5, 0xa3, 0x43, 0x98, 0x3b, 0x21, 0x0e, 0xf1, 0x0c, 0x4e, 0x90, 0x92, 0x7a, 0x39, 0xb5, 0x38,
-	0xe5, 0x07, 0x57, 0x91, 0xac, 0x09, 0xe5, 0x44, 0x88, 0x7e, 0x51, 0x28, 0x5b, 0x29, 0x7e, 0xe9,
-	0x13, 0xaf, 0xbd, 0x3f, 0x99, 0x61, 0x69, 0x3a, 0xc3, 0xd2, 0x24, 0xc2, 0x60, 0x1a, 0x61, 0xf0,
-	0x11, 0x61, 0xf0, 0x1c, 0x63, 0xe9, 0x25, 0xc6, 0xd2, 0x6b, 0x8c, 0xc1, 0x34, 0xc6, 0xd2, 0x5b,
-	0x8c, 0xa5, 0xdb, 0x35, 0x77, 0x68, 0x69, 0x86, 0x4b, 0x4d, 0x59, 0x7c, 0x68, 0xf3, 0x33, 0x00,
-	0x00, 0xff, 0xff, 0x2d, 0x3c, 0x8b, 0xe4, 0x60, 0x02, 0x00, 0x00,
+	// 686 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcd, 0x4e, 0xdb, 0x40,
+	0x10, 0x8e, 0x9d, 0xc4, 0x71, 0x06, 0x82, 0xd2, 0x15, 0xa2, 0x96, 0xdb, 0x9a, 0xc8, 0x27, 0x84,
+	0x84, 0xa3, 0x82, 0xc4, 0xa1, 0x12, 0x48, 0xa4, 0x44, 0x28, 0x2a, 0x14, 0x64, 0xd2, 0x1f, 0x55,
+	0x95, 0x90, 0x43, 0x36, 0xae, 0x45, 0x9c, 0x35, 0xde, 0x75, 0x21, 0x6f, 0xd0, 0x23, 0x2f, 0xd1,
+	0xf7, 0xe0, 0xc8, 0x91, 0x63, 0x6f, 0x94, 0xe4, 0x45, 0x2a, 0xaf, 0x7f, 0x1a, 0xa3, 0xa0, 0xa6,
+	0xed, 0x29, 0x9e, 0x99, 0x6f, 0xe6, 0xdb, 0xf9, 0x66, 0x26, 0x30, 0xcf, 0xc8, 0x19, 0x1e, 0x50,
+	0xc3, 0xf3, 0x09, 0x23, 0x68, 0xce, 0x61, 0x41, 0x07, 0x1b, 0x01, 0xc5, 0x3e, 0x55, 0x21, 0xfc,
+	0x89, 0x02, 0xea, 0xb2, 0x4d, 0x88, 0xdd, 0xc7, 0x75, 0x6e, 0x75, 0x82, 0x5e, 0x9d, 0x39, 0x2e,
+	0xa6, 0xcc, 0x72, 0xbd, 0x18, 0xb0, 0x66, 0x3b, 0xec, 0x4b, 0xd0, 0x31, 0x4e, 0x89, 0x5b, 0xb7,
+	0x89, 0x4d, 0x7e, 0x23, 0x43, 0x8b, 0x1b, 0xfc, 0x2b, 0x86, 0x6f, 0x4e, 0xc0, 0xdd, 0x0b, 0x87,
+	0x9d, 0x91, 0x8b, 0xba, 0x4d, 0xd6, 0x78, 0x70, 0xed, 0xab, 0xd5, 0x77, 0xba, 0x16, 0x23, 0x3e,
+	0xad, 0xa7, 0x9f, 0x51, 0x9e, 0x3e, 0x84, 0xca, 0x7b, 0xec, 0x3b, 0xbd, 0xa1, 0x89, 0xcf, 0x03,
+	0x4c, 0x19, 0xd2, 0x40, 0xea, 0x12, 0xd7, 0x72, 0x06, 0x8a, 0x50, 0x13, 0x56, 0xca, 0x0d, 0x69,
+	0x74, 0xb7, 0x2c, 0x7e, 0x14, 0xcc, 0xd8, 0x8b, 0x9e, 0x43, 0x11, 0xbb, 0x96, 0xd3, 0x57, 0xc4,
+	0x4c, 0x38, 0x72, 0xa2, 0x55, 0x28, 0xb0, 0xa1, 0x87, 0x95, 0x7c, 0x4d, 0x58, 0x59, 0x58, 0x5f,
+	0x32, 0x26, 0xda, 0x37, 0xda, 0xa1, 0x30, 0xed, 0xa1, 0x87, 0x4d, 0x8e, 0xd1, 0x03, 0x28, 0x73,
+	0x57, 0x6b, 0xd0, 0x23, 0xb3, 0xd0, 0x72, 0x61, 0x1f, 0xd2, 0x72, 0xe7, 0x5f, 0xd1, 0x9e, 0x43,
+	0xe5, 0xc8, 0xa2, 0xf4, 0x82, 0xf8, 0x5d, 0x13, 0x53, 0xcc, 0xfe, 0x93, 0x5a, 0x07, 0xd9, 0x8b,
+	0xcb, 0x29, 0xf9, 0x0c, 0x20, 0xf5, 0xeb, 0x7b, 0x50, 0x39, 0x0c, 0x58, 0x87, 0x5c, 0xce, 0x2a,
+	0xf2, 0x12, 0x48, 0x3d, 0xcb, 0xe9, 0xe3, 0x2e, 0xe7, 0x94, 0xcd, 0xd8, 0xd2, 0xbf, 0x8b, 0x49,
+	0xa5, 0x03, 0x4c, 0xa9, 0x65, 0x63, 0xb4, 0x98, 0x8c, 0x83, 0x17, 0x7a, 0x38, 0x06, 0xf1, 0xcf,
+	0x7a, 0x20, 0x15, 0x64, 0x8b, 0x31, 0xec, 0x7a, 0x8c, 0xf2, 0x06, 0x8a, 0x66, 0x6a, 0xa3, 0xd7,
+	0x30, 0x3f, 0xc0, 0x97, 0xec, 0x24, 0x76, 0x28, 0x85, 0x9a, 0xb0, 0x32, 0xb7, 0xae, 0x1a, 0xd1,
+	0xf2, 0x1a, 0xc9, 0x4a, 0x1a, 0xed, 0x64, 0x79, 0x1b, 0x85, 0xab, 0xbb, 0x65, 0xc1, 0x9c, 0x0b,
+	0xb3, 0x76, 0xa2, 0x24, 0xf4, 0x02, 0xa0, 0x6f, 0x51, 0x76, 0x82, 0x7d, 0x9f, 0xf8, 0x4a, 0x91,
+	0xbf, 0xb3, 0x1c, 0x7a, 0x9a, 0xa1, 0x63, 0xa2, 0x57, 0x69, 0xb2, 0x57, 0xf4, 0x0a, 0x4a, 0xa7,
+	0x3e, 0xb6, 0x18, 0xee, 0x2a, 0xa5, 0x19, 0x69, 0x93, 0x04, 0xfd, 0x33, 0x40, 0x24, 0xd3, 0xbe,
+	0x43, 0x59, 0xc8, 0x30, 0xa9, 0x76, 0xaa, 0xf2, 0x26, 0xc8, 0x6e, 0x24, 0x23, 0x55, 0xc4, 0x5a,
+	0x9e, 0x53, 0x4c, 0x2a, 0x95, 0x51, 0xda, 0x4c, 0xb1, 0xba, 0x0f, 0x0b, 0x7c, 0x90, 0x01, 0x9e,
+	0x75, 0x9e, 0x8b, 0x99, 0xa3, 0xf9, 0x97, 0x63, 0xd9, 0x82, 0x4a, 0xca, 0x49, 0x83, 0xfe, 0xe3,
+	0x4d, 0x2d, 0x42, 0xf1, 0x94, 0x04, 0x03, 0xc6, 0xa9, 0xf2, 0x66, 0x64, 0xac, 0x6e, 0x43, 0x39,
+	0xad, 0x88, 0xca, 0x50, 0x6c, 0x1e, 0xec, 0xb4, 0xf6, 0xab, 0x39, 0x34, 0x0f, 0xf2, 0xd1, 0xce,
+	0xf1, 0xf1, 0x87, 0x43, 0x73, 0xb7, 0x2a, 0xa0, 0x05, 0x00, 0xb3, 0xb9, 0xd7, 0x3a, 0x6e, 0x37,
+	0xcd, 0xe6, 0x6e, 0x55, 0x44, 0x32, 0x14, 0xf6, 0x5b, 0x6f, 0xdf, 0x54, 0xf3, 0xeb, 0xd7, 0x22,
+	0x48, 0xbc, 0x00, 0x45, 0xdb, 0x20, 0xef, 0xe1, 0x01, 0xf6, 0x2d, 0x86, 0x51, 0x56, 0xaf, 0xcc,
+	0x1f, 0x89, 0x3a, 0xa5, 0x1f, 0x7e, 0xe9, 0x1b, 0x20, 0x45, 0x40, 0xf4, 0x08, 0x42, 0x7d, 0x92,
+	0xf1, 0xbf, 0xa3, 0xd8, 0x47, 0xdb, 0x61, 0xfb, 0x14, 0xb3, 0xe4, 0x72, 0x1f, 0x30, 0x67, 0x0e,
+	0x7a, 0x5a, 0xfe, 0x16, 0x48, 0xd1, 0x34, 0xd1, 0xb4, 0x11, 0x27, 0x4f, 0x7e, 0x3a, 0x25, 0xc6,
+	0x37, 0xa8, 0x01, 0xa5, 0x58, 0x7d, 0xf4, 0x2c, 0x83, 0xc9, 0xee, 0x81, 0xaa, 0x4e, 0x0f, 0x86,
+	0x03, 0x6b, 0xbc, 0xbc, 0xb9, 0xd7, 0x72, 0xb7, 0xf7, 0x5a, 0xee, 0x66, 0xa4, 0x09, 0xb7, 0x23,
+	0x4d, 0xf8, 0x39, 0xd2, 0x84, 0x6f, 0x63, 0x2d, 0x77, 0x35, 0xd6, 0x72, 0xd7, 0x63, 0x4d, 0xb8,
+	0x1d, 0x6b, 0xb9, 0x1f, 0x63, 0x2d, 0xf7, 0xa9, 0xe4, 0x9d, 0xd9, 0x75, 0xcb, 0x73, 0x3a, 0x12,
+	0xdf, 0xf4, 0x8d, 0x5f, 0x03, 0x00, 0xb1, 0x95, 0xbf, 0xd0, 0x54, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -203,24 +512,77 @@ type TokensClient interface {
 	// сброса пароля. При вызове сервер отправляет соответствующее письмо
 	// на email адрес пользователя с токеном для верификации.
 	// Повторный вызов с теми же значениями параметров заменяет токен на новый,
-	// а действие старого отменяет.
+	// а действие старого отменяет. Токены типа REGISTERED и LINK не
+	// поддерживаются.
 	//
 	// Возвращает ошибки:
 	//  - InvalidArgument - неверный формат данных входящего запроса
 	//  - Internal - внутренние ошибки
 	Generate(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*TokenInfo, error)
 	// Verify проверяет токен и возвращает зарегистрированного пользователя.
-	// Если токен неверен, то возвращается ошибка NotFound. После проверки
+	// Если токен неверен, то возвращается ошибка InvalidArgument. После проверки
 	// токен автоматически удаляется и повторное его использование невозможно.
 	//
 	// Так же автоматически подтверждает почтовый адрес, через который был
-	// отправлен данный токен.
+	// отправлен данный токен. Вместе с информацией о пользователе возвращаются
+	// токены созданной сессии.
+	//
+	// Если у пользователя включена двухфакторная авторизация, то сессия не
+	// создается, а возвращается ошибка FailedPrecondition, в деталях которой
+	// передается Challenge с токеном для завершения авторизации через
+	// TwoFactor.Authorize: доступа к почте для входа недостаточно.
+	//
+	// Тип токена в запросе должен совпадать с типом, с которым он был
+	// сгенерирован, иначе токен считается неверным. Токены типа REGISTERED и LINK
+	// не поддерживаются.
 	//
 	// Возвращает ошибки:
 	//  - NotFound - пользователь не зарегистрирован
+	//  - FailedPrecondition - время жизни токена истекло или требуется второй
+	//    фактор авторизации
 	//  - InvalidArgument - неверный формат данных входящего запроса
 	//  - Internal - внутренние ошибки
 	Verify(ctx context.Context, in *TokenInfo, opts ...grpc.CallOption) (*User, error)
+	// ResetPassword проверяет токен для сброса пароля и устанавливает новый
+	// пароль пользователя. Проверка токена, его удаление и замена пароля
+	// выполняются атомарно. Токены для подтверждения почтового адреса
+	// не принимаются.
+	//
+	// Так же автоматически подтверждает почтовый адрес, через который был
+	// отправлен данный токен. Все сессии пользователя при этом завершаются.
+	//
+	// Пароль проверяется на соответствие политике паролей домена. При нарушении
+	// возвращается ошибка InvalidArgument, в деталях которой передаются
+	// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
+	// Новый пароль так же не должен совпадать с последними паролями
+	// пользователя, если это задано политикой домена.
+	// Токен при этом остается действительным.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь не зарегистрирован или заблокирован
+	//  - FailedPrecondition - время жизни токена истекло
+	//  - InvalidArgument - неверный токен, формат данных или пароль не
+	//    соответствует политике
+	//  - Internal - внутренние ошибки
+	ResetPassword(ctx context.Context, in *PasswordReset, opts ...grpc.CallOption) (*User, error)
+	// Outbox возвращает список неотправленных писем с токенами домена: как
+	// ожидающих повторной попытки отправки, так и тех, попытки отправки
+	// которых прекращены из-за постоянной ошибки или исчерпания попыток.
+	// Сами токены не возвращаются.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Outbox(ctx context.Context, in *OutboxRequest, opts ...grpc.CallOption) (*OutboxList, error)
+	// Requeue возвращает в очередь на отправку письмо с токеном, попытки
+	// отправки которого прекращены, и сбрасывает счетчик попыток. Если email не
+	// указан, то в очередь возвращаются все такие письма домена. Токен при
+	// этом не меняется.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Requeue(ctx context.Context, in *RequeueRequest, opts ...grpc.CallOption) (*RequeueResult, error)
 }
 
 type tokensClient struct {
@@ -249,30 +611,110 @@ func (c *tokensClient) Verify(ctx context.Context, in *TokenInfo, opts ...grpc.C
 	return out, nil
 }
 
+func (c *tokensClient) ResetPassword(ctx context.Context, in *PasswordReset, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/itube.users.Tokens/ResetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokensClient) Outbox(ctx context.Context, in *OutboxRequest, opts ...grpc.CallOption) (*OutboxList, error) {
+	out := new(OutboxList)
+	err := c.cc.Invoke(ctx, "/itube.users.Tokens/Outbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokensClient) Requeue(ctx context.Context, in *RequeueRequest, opts ...grpc.CallOption) (*RequeueResult, error) {
+	out := new(RequeueResult)
+	err := c.cc.Invoke(ctx, "/itube.users.Tokens/Requeue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TokensServer is the server API for Tokens service.
 type TokensServer interface {
 	// Generate создает запрос для проверки адреса email пользователя или
 	// сброса пароля. При вызове сервер отправляет соответствующее письмо
 	// на email адрес пользователя с токеном для верификации.
 	// Повторный вызов с теми же значениями параметров заменяет токен на новый,
-	// а действие старого отменяет.
+	// а действие старого отменяет. Токены типа REGISTERED и LINK не
+	// поддерживаются.
 	//
 	// Возвращает ошибки:
 	//  - InvalidArgument - неверный формат данных входящего запроса
 	//  - Internal - внутренние ошибки
 	Generate(context.Context, *VerifyRequest) (*TokenInfo, error)
 	// Verify проверяет токен и возвращает зарегистрированного пользователя.
-	// Если токен неверен, то возвращается ошибка NotFound. После проверки
+	// Если токен неверен, то возвращается ошибка InvalidArgument. После проверки
 	// токен автоматически удаляется и повторное его использование невозможно.
 	//
 	// Так же автоматически подтверждает почтовый адрес, через который был
-	// отправлен данный токен.
+	// отправлен данный токен. Вместе с информацией о пользователе возвращаются
+	// токены созданной сессии.
+	//
+	// Если у пользователя включена двухфакторная авторизация, то сессия не
+	// создается, а возвращается ошибка FailedPrecondition, в деталях которой
+	// передается Challenge с токеном для завершения авторизации через
+	// TwoFactor.Authorize: доступа к почте для входа недостаточно.
+	//
+	// Тип токена в запросе должен совпадать с типом, с которым он был
+	// сгенерирован, иначе токен считается неверным. Токены типа REGISTERED и LINK
+	// не поддерживаются.
 	//
 	// Возвращает ошибки:
 	//  - NotFound - пользователь не зарегистрирован
+	//  - FailedPrecondition - время жизни токена истекло или требуется второй
+	//    фактор авторизации
 	//  - InvalidArgument - неверный формат данных входящего запроса
 	//  - Internal - внутренние ошибки
 	Verify(context.Context, *TokenInfo) (*User, error)
+	// ResetPassword проверяет токен для сброса пароля и устанавливает новый
+	// пароль пользователя. Проверка токена, его удаление и замена пароля
+	// выполняются атомарно. Токены для подтверждения почтового адреса
+	// не принимаются.
+	//
+	// Так же автоматически подтверждает почтовый адрес, через который был
+	// отправлен данный токен. Все сессии пользователя при этом завершаются.
+	//
+	// Пароль проверяется на соответствие политике паролей домена. При нарушении
+	// возвращается ошибка InvalidArgument, в деталях которой передаются
+	// BadRequest со списком нарушений и ErrorInfo с кодами нарушенных правил.
+	// Новый пароль так же не должен совпадать с последними паролями
+	// пользователя, если это задано политикой домена.
+	// Токен при этом остается действительным.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь не зарегистрирован или заблокирован
+	//  - FailedPrecondition - время жизни токена истекло
+	//  - InvalidArgument - неверный токен, формат данных или пароль не
+	//    соответствует политике
+	//  - Internal - внутренние ошибки
+	ResetPassword(context.Context, *PasswordReset) (*User, error)
+	// Outbox возвращает список неотправленных писем с токенами домена: как
+	// ожидающих повторной попытки отправки, так и тех, попытки отправки
+	// которых прекращены из-за постоянной ошибки или исчерпания попыток.
+	// Сами токены не возвращаются.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Outbox(context.Context, *OutboxRequest) (*OutboxList, error)
+	// Requeue возвращает в очередь на отправку письмо с токеном, попытки
+	// отправки которого прекращены, и сбрасывает счетчик попыток. Если email не
+	// указан, то в очередь возвращаются все такие письма домена. Токен при
+	// этом не меняется.
+	//
+	// Возвращает ошибки:
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Requeue(context.Context, *RequeueRequest) (*RequeueResult, error)
 }
 
 // UnimplementedTokensServer can be embedded to have forward compatible implementations.
@@ -285,6 +727,15 @@ func (*UnimplementedTokensServer) Generate(ctx context.Context, req *VerifyReque
 func (*UnimplementedTokensServer) Verify(ctx context.Context, req *TokenInfo) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
 }
+func (*UnimplementedTokensServer) ResetPassword(ctx context.Context, req *PasswordReset) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (*UnimplementedTokensServer) Outbox(ctx context.Context, req *OutboxRequest) (*OutboxList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Outbox not implemented")
+}
+func (*UnimplementedTokensServer) Requeue(ctx context.Context, req *RequeueRequest) (*RequeueResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Requeue not implemented")
+}
 
 func RegisterTokensServer(s *grpc.Server, srv TokensServer) {
 	s.RegisterService(&_Tokens_serviceDesc, srv)
@@ -326,6 +777,60 @@ func _Tokens_Verify_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Tokens_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordReset)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokensServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Tokens/ResetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokensServer).ResetPassword(ctx, req.(*PasswordReset))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tokens_Outbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokensServer).Outbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Tokens/Outbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokensServer).Outbox(ctx, req.(*OutboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tokens_Requeue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TokensServer).Requeue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.Tokens/Requeue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokensServer).Requeue(ctx, req.(*RequeueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Tokens_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "itube.users.Tokens",
 	HandlerType: (*TokensServer)(nil),
@@ -338,6 +843,18 @@ var _Tokens_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Verify",
 			Handler:    _Tokens_Verify_Handler,
 		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _Tokens_ResetPassword_Handler,
+		},
+		{
+			MethodName: "Outbox",
+			Handler:    _Tokens_Outbox_Handler,
+		},
+		{
+			MethodName: "Requeue",
+			Handler:    _Tokens_Requeue_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tokens.proto",
@@ -427,20 +944,302 @@ func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTokens(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTokens(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *PasswordReset) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *VerifyRequest) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *PasswordReset) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PasswordReset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Password) > 0 {
+		i -= len(m.Password)
+		copy(dAtA[i:], m.Password)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Password)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OutboxRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OutboxRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OutboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Failed {
+		i--
+		if m.Failed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OutboxMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OutboxMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OutboxMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Created != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintTokens(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Failed {
+		i--
+		if m.Failed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.LastError) > 0 {
+		i -= len(m.LastError)
+		copy(dAtA[i:], m.LastError)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.LastError)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.NextAttempt != nil {
+		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.NextAttempt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.NextAttempt):])
+		if err2 != nil {
+			return 0, err2
+		}
+		i -= n2
+		i = encodeVarintTokens(dAtA, i, uint64(n2))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Attempts != 0 {
+		i = encodeVarintTokens(dAtA, i, uint64(m.Attempts))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Type != 0 {
+		i = encodeVarintTokens(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Email) > 0 {
+		i -= len(m.Email)
+		copy(dAtA[i:], m.Email)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Email)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OutboxList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OutboxList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OutboxList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTokens(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequeueRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequeueRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequeueRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		i = encodeVarintTokens(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Email) > 0 {
+		i -= len(m.Email)
+		copy(dAtA[i:], m.Email)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Email)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequeueResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequeueResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequeueResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintTokens(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTokens(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTokens(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTokens(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *VerifyRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -455,36 +1254,935 @@ func (m *VerifyRequest) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovTokens(uint64(m.Type))
 	}
-	return n
-}
+	return n
+}
+
+func (m *TokenInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTokens(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *PasswordReset) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	return n
+}
+
+func (m *OutboxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if m.Failed {
+		n += 2
+	}
+	return n
+}
+
+func (m *OutboxMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTokens(uint64(m.Type))
+	}
+	if m.Attempts != 0 {
+		n += 1 + sovTokens(uint64(m.Attempts))
+	}
+	if m.NextAttempt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.NextAttempt)
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	l = len(m.LastError)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if m.Failed {
+		n += 2
+	}
+	if m.Created != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Created)
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	return n
+}
+
+func (m *OutboxList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovTokens(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequeueRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTokens(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *RequeueResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTokens(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovTokens(uint64(m.Count))
+	}
+	return n
+}
+
+func sovTokens(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTokens(x uint64) (n int) {
+	return sovTokens(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTokens
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= TokenType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTokens(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTokens
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= TokenType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTokens(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PasswordReset) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTokens
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PasswordReset: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PasswordReset: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTokens(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OutboxRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTokens
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OutboxRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OutboxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Failed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTokens(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OutboxMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTokens
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OutboxMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OutboxMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= TokenType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextAttempt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NextAttempt == nil {
+				m.NextAttempt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.NextAttempt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Failed = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTokens(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func (m *TokenInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Domain)
-	if l > 0 {
-		n += 1 + l + sovTokens(uint64(l))
-	}
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovTokens(uint64(l))
-	}
-	if m.Type != 0 {
-		n += 1 + sovTokens(uint64(m.Type))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovTokens(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTokens(x uint64) (n int) {
-	return sovTokens(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
+func (m *OutboxList) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -507,10 +2205,10 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: OutboxList: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OutboxList: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -547,9 +2245,9 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTokens
@@ -559,43 +2257,26 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTokens
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTokens
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTokens
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= TokenType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Messages = append(m.Messages, &OutboxMessage{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTokens(dAtA[iNdEx:])
@@ -620,7 +2301,7 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TokenInfo) Unmarshal(dAtA []byte) error {
+func (m *RequeueRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -643,10 +2324,10 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequeueRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequeueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -683,7 +2364,7 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -711,7 +2392,7 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Email = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
@@ -756,6 +2437,110 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RequeueResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTokens
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequeueResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequeueResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTokens
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTokens
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTokens(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTokens
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTokens(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -10,6 +10,7 @@ import (
 	golang_proto "github.com/golang/protobuf/proto"
 	_ "github.com/gogo/protobuf/gogoproto"
 	_ "github.com/mwitkow/go-proto-validators"
+	_ "github.com/gogo/protobuf/types"
 	time "time"
 	github_com_mwitkow_go_proto_validators "github.com/mwitkow/go-proto-validators"
 )
@@ -39,3 +40,53 @@ func (this *TokenInfo) Validate() error {
 	}
 	return nil
 }
+func (this *PasswordReset) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if this.Token == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Token", fmt.Errorf(`value '%v' must not be an empty string`, this.Token))
+	}
+	if this.Password == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Password", fmt.Errorf(`value '%v' must not be an empty string`, this.Password))
+	}
+	return nil
+}
+func (this *OutboxRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	return nil
+}
+func (this *OutboxMessage) Validate() error {
+	if this.NextAttempt != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.NextAttempt); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("NextAttempt", err)
+		}
+	}
+	if this.Created != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Created); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Created", err)
+		}
+	}
+	return nil
+}
+func (this *OutboxList) Validate() error {
+	for _, item := range this.Messages {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Messages", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *RequeueRequest) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	return nil
+}
+func (this *RequeueResult) Validate() error {
+	return nil
+}
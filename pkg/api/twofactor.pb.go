@@ -0,0 +1,1946 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: twofactor.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types "github.com/gogo/protobuf/types"
+	golang_proto "github.com/golang/protobuf/proto"
+	_ "github.com/mwitkow/go-proto-validators"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = golang_proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Challenge возвращается в деталях ошибки Identity.Authorize, если для
+// завершения авторизации требуется второй фактор.
+type Challenge struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// токен запроса второго фактора
+	Challenge string `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// дата и время окончания действия запроса
+	Expires *time.Time `protobuf:"bytes,3,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
+}
+
+func (m *Challenge) Reset()         { *m = Challenge{} }
+func (m *Challenge) String() string { return proto.CompactTextString(m) }
+func (*Challenge) ProtoMessage()    {}
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_741eda89cdd4d3ba, []int{0}
+}
+func (m *Challenge) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Challenge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Challenge.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Challenge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Challenge.Merge(m, src)
+}
+func (m *Challenge) XXX_Size() int {
+	return m.Size()
+}
+func (m *Challenge) XXX_DiscardUnknown() {
+	xxx_messageInfo_Challenge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Challenge proto.InternalMessageInfo
+
+// SecondFactor используется для завершения двухфакторной авторизации.
+type SecondFactor struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// токен запроса второго фактора из Challenge
+	Challenge string `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// код из приложения или код восстановления
+	Code string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (m *SecondFactor) Reset()         { *m = SecondFactor{} }
+func (m *SecondFactor) String() string { return proto.CompactTextString(m) }
+func (*SecondFactor) ProtoMessage()    {}
+func (*SecondFactor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_741eda89cdd4d3ba, []int{1}
+}
+func (m *SecondFactor) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SecondFactor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SecondFactor.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SecondFactor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecondFactor.Merge(m, src)
+}
+func (m *SecondFactor) XXX_Size() int {
+	return m.Size()
+}
+func (m *SecondFactor) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecondFactor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecondFactor proto.InternalMessageInfo
+
+// TwoFactorUser используется для генерации ключа двухфакторной авторизации.
+type TwoFactorUser struct {
+	// домен (используется в качестве названия сервиса в приложении)
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (m *TwoFactorUser) Reset()         { *m = TwoFactorUser{} }
+func (m *TwoFactorUser) String() string { return proto.CompactTextString(m) }
+func (*TwoFactorUser) ProtoMessage()    {}
+func (*TwoFactorUser) Descriptor() ([]byte, []int) {
+	return fileDescriptor_741eda89cdd4d3ba, []int{2}
+}
+func (m *TwoFactorUser) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TwoFactorUser) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TwoFactorUser.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TwoFactorUser) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TwoFactorUser.Merge(m, src)
+}
+func (m *TwoFactorUser) XXX_Size() int {
+	return m.Size()
+}
+func (m *TwoFactorUser) XXX_DiscardUnknown() {
+	xxx_messageInfo_TwoFactorUser.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TwoFactorUser proto.InternalMessageInfo
+
+// TwoFactorCode используется для подтверждения или отключения двухфакторной
+// авторизации.
+type TwoFactorCode struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// уникальный идентификатор пользователя
+	UID string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// код из приложения или код восстановления
+	Code string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (m *TwoFactorCode) Reset()         { *m = TwoFactorCode{} }
+func (m *TwoFactorCode) String() string { return proto.CompactTextString(m) }
+func (*TwoFactorCode) ProtoMessage()    {}
+func (*TwoFactorCode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_741eda89cdd4d3ba, []int{3}
+}
+func (m *TwoFactorCode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TwoFactorCode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TwoFactorCode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TwoFactorCode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TwoFactorCode.Merge(m, src)
+}
+func (m *TwoFactorCode) XXX_Size() int {
+	return m.Size()
+}
+func (m *TwoFactorCode) XXX_DiscardUnknown() {
+	xxx_messageInfo_TwoFactorCode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TwoFactorCode proto.InternalMessageInfo
+
+// TOTPSecret описывает секретный ключ двухфакторной авторизации.
+type TOTPSecret struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// секретный ключ (base32) для ручного ввода в приложение
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// ссылка otpauth:// для добавления ключа в приложение через QR-код
+	URI string `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (m *TOTPSecret) Reset()         { *m = TOTPSecret{} }
+func (m *TOTPSecret) String() string { return proto.CompactTextString(m) }
+func (*TOTPSecret) ProtoMessage()    {}
+func (*TOTPSecret) Descriptor() ([]byte, []int) {
+	return fileDescriptor_741eda89cdd4d3ba, []int{4}
+}
+func (m *TOTPSecret) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TOTPSecret) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TOTPSecret.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TOTPSecret) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TOTPSecret.Merge(m, src)
+}
+func (m *TOTPSecret) XXX_Size() int {
+	return m.Size()
+}
+func (m *TOTPSecret) XXX_DiscardUnknown() {
+	xxx_messageInfo_TOTPSecret.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TOTPSecret proto.InternalMessageInfo
+
+// RecoveryCodes описывает список одноразовых кодов восстановления.
+type RecoveryCodes struct {
+	// домен
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// коды восстановления
+	Codes []string `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+}
+
+func (m *RecoveryCodes) Reset()         { *m = RecoveryCodes{} }
+func (m *RecoveryCodes) String() string { return proto.CompactTextString(m) }
+func (*RecoveryCodes) ProtoMessage()    {}
+func (*RecoveryCodes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_741eda89cdd4d3ba, []int{5}
+}
+func (m *RecoveryCodes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecoveryCodes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecoveryCodes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecoveryCodes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryCodes.Merge(m, src)
+}
+func (m *RecoveryCodes) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecoveryCodes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryCodes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryCodes proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Challenge)(nil), "itube.users.Challenge")
+	golang_proto.RegisterType((*Challenge)(nil), "itube.users.Challenge")
+	proto.RegisterType((*SecondFactor)(nil), "itube.users.SecondFactor")
+	golang_proto.RegisterType((*SecondFactor)(nil), "itube.users.SecondFactor")
+	proto.RegisterType((*TwoFactorUser)(nil), "itube.users.TwoFactorUser")
+	golang_proto.RegisterType((*TwoFactorUser)(nil), "itube.users.TwoFactorUser")
+	proto.RegisterType((*TwoFactorCode)(nil), "itube.users.TwoFactorCode")
+	golang_proto.RegisterType((*TwoFactorCode)(nil), "itube.users.TwoFactorCode")
+	proto.RegisterType((*TOTPSecret)(nil), "itube.users.TOTPSecret")
+	golang_proto.RegisterType((*TOTPSecret)(nil), "itube.users.TOTPSecret")
+	proto.RegisterType((*RecoveryCodes)(nil), "itube.users.RecoveryCodes")
+	golang_proto.RegisterType((*RecoveryCodes)(nil), "itube.users.RecoveryCodes")
+}
+
+func init() { proto.RegisterFile("twofactor.proto", fileDescriptor_741eda89cdd4d3ba) }
+func init() { golang_proto.RegisterFile("twofactor.proto", fileDescriptor_741eda89cdd4d3ba) }
+
+var fileDescriptor_741eda89cdd4d3ba = []byte{
+	// 575 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x4f, 0x6f, 0xd3, 0x4e,
+	0x10, 0xf5, 0xc6, 0xfd, 0x25, 0xf2, 0xf6, 0x57, 0x21, 0x2c, 0x54, 0x52, 0x83, 0x36, 0x56, 0x84,
+	0x44, 0x84, 0x14, 0x5b, 0x04, 0x09, 0x09, 0xa4, 0x1c, 0x9a, 0xa4, 0x48, 0x3d, 0x81, 0xdc, 0x54,
+	0x54, 0xdc, 0x1c, 0x7b, 0xe3, 0xac, 0x6a, 0x7b, 0xcd, 0x7a, 0xdd, 0x50, 0x24, 0xce, 0x70, 0x0c,
+	0xdf, 0x88, 0x63, 0x8f, 0x39, 0x72, 0x22, 0xd4, 0xf9, 0x22, 0xc8, 0x1b, 0xe7, 0x8f, 0xa9, 0xd2,
+	0x4a, 0x9c, 0xec, 0xd9, 0xf7, 0x66, 0xde, 0x9b, 0x99, 0x5d, 0x78, 0x8f, 0x8f, 0xe9, 0xd0, 0x76,
+	0x38, 0x65, 0x46, 0xc4, 0x28, 0xa7, 0xea, 0x2e, 0xe1, 0xc9, 0x00, 0x1b, 0x49, 0x8c, 0x59, 0xac,
+	0xc1, 0xec, 0xb3, 0x00, 0xb4, 0x47, 0x1e, 0xa5, 0x9e, 0x8f, 0x4d, 0x11, 0x0d, 0x92, 0xa1, 0x89,
+	0x83, 0x88, 0x5f, 0xe6, 0x60, 0xed, 0x6f, 0x90, 0x93, 0x00, 0xc7, 0xdc, 0x0e, 0xa2, 0x9c, 0xd0,
+	0xf4, 0x08, 0x1f, 0x25, 0x03, 0xc3, 0xa1, 0x81, 0xe9, 0x51, 0x8f, 0xae, 0x99, 0x59, 0x24, 0x02,
+	0xf1, 0x97, 0xd3, 0x5f, 0x6e, 0xd0, 0x83, 0x31, 0xe1, 0xe7, 0x74, 0x6c, 0x7a, 0xb4, 0x29, 0xc0,
+	0xe6, 0x85, 0xed, 0x13, 0xd7, 0xe6, 0x94, 0xc5, 0xe6, 0xea, 0x77, 0x91, 0x57, 0xff, 0x02, 0x95,
+	0xee, 0xc8, 0xf6, 0x7d, 0x1c, 0x7a, 0x58, 0xdd, 0x87, 0x65, 0x97, 0x06, 0x36, 0x09, 0xab, 0x40,
+	0x07, 0x0d, 0xc5, 0xca, 0x23, 0xf5, 0x31, 0x54, 0x9c, 0x25, 0xa9, 0x5a, 0x12, 0xd0, 0xfa, 0x40,
+	0x7d, 0x0d, 0x2b, 0xf8, 0x53, 0x44, 0x18, 0x8e, 0xab, 0xb2, 0x0e, 0x1a, 0xbb, 0x2d, 0xcd, 0x58,
+	0x34, 0x67, 0x2c, 0x2d, 0x1b, 0xfd, 0x65, 0x73, 0x9d, 0x9d, 0xc9, 0xac, 0x06, 0xac, 0x65, 0x42,
+	0x3d, 0x82, 0xff, 0x9f, 0x60, 0x87, 0x86, 0xee, 0x1b, 0x31, 0x52, 0x15, 0x15, 0x1d, 0x74, 0xca,
+	0xe9, 0xac, 0x56, 0x3a, 0x03, 0x2b, 0x27, 0x4f, 0x6e, 0x38, 0x59, 0x51, 0x36, 0x1c, 0x69, 0x70,
+	0xc7, 0xa1, 0x2e, 0xae, 0xca, 0x05, 0x82, 0x38, 0xab, 0x27, 0x70, 0xaf, 0x3f, 0xa6, 0x0b, 0xb9,
+	0xd3, 0x18, 0xdf, 0x2d, 0xd9, 0x83, 0x72, 0x42, 0xdc, 0x5c, 0xac, 0x95, 0xfe, 0xaa, 0xc9, 0xa7,
+	0xc7, 0xbd, 0x74, 0x56, 0x7b, 0x7a, 0x06, 0x26, 0x60, 0xe7, 0x99, 0x4e, 0x42, 0x31, 0x53, 0x3d,
+	0x09, 0xc9, 0xc7, 0x04, 0xeb, 0xc4, 0xc5, 0x21, 0x27, 0x43, 0x82, 0x99, 0x3e, 0xa4, 0x2c, 0xb0,
+	0xb9, 0x95, 0xa5, 0xd7, 0xbf, 0x83, 0x0d, 0xdd, 0x2e, 0x75, 0xf1, 0xbf, 0xeb, 0xde, 0x2d, 0x29,
+	0x9c, 0x09, 0xdd, 0x5b, 0x47, 0xf1, 0x1e, 0xc2, 0xfe, 0xdb, 0xfe, 0xbb, 0x13, 0xec, 0x30, 0xcc,
+	0xb7, 0x2e, 0x7f, 0x1f, 0x96, 0x63, 0xc1, 0xc8, 0x37, 0x9f, 0x47, 0xea, 0x01, 0x94, 0x13, 0x46,
+	0xf2, 0xc2, 0x15, 0xe1, 0xcf, 0x3a, 0xb6, 0xb2, 0xb3, 0x7a, 0x1b, 0xee, 0x59, 0xd8, 0xa1, 0x17,
+	0x98, 0x5d, 0x66, 0xad, 0xc6, 0x5b, 0x6b, 0x3f, 0x80, 0xff, 0x65, 0x4e, 0xe2, 0x6a, 0x49, 0x97,
+	0x1b, 0x8a, 0xb5, 0x08, 0x5a, 0x5f, 0x4b, 0x50, 0x59, 0xcd, 0x4a, 0x6d, 0xc3, 0xf2, 0x51, 0xc8,
+	0xa8, 0xef, 0xab, 0x9a, 0xb1, 0xf1, 0xd4, 0x8c, 0xc2, 0x16, 0xb5, 0x87, 0x45, 0x6c, 0xdd, 0xd6,
+	0x21, 0xac, 0x74, 0x69, 0x38, 0x24, 0x2c, 0xd8, 0x96, 0x9f, 0x59, 0xd4, 0x8a, 0x58, 0xd1, 0x7d,
+	0x1b, 0x56, 0x7a, 0x24, 0xb6, 0x07, 0x3e, 0xbe, 0xb5, 0xc4, 0xfe, 0x8d, 0x6b, 0x7f, 0x94, 0x3d,
+	0x78, 0xf5, 0x15, 0x54, 0x0e, 0x13, 0x3e, 0xa2, 0x8c, 0x7c, 0xc6, 0xea, 0x41, 0xa1, 0xc0, 0xe6,
+	0xdd, 0xd7, 0xee, 0x17, 0xa0, 0xac, 0xab, 0xce, 0xf3, 0xab, 0x6b, 0x24, 0x4d, 0xaf, 0x91, 0x74,
+	0x95, 0x22, 0x30, 0x4d, 0x11, 0xf8, 0x9d, 0x22, 0xf0, 0x6d, 0x8e, 0xa4, 0xc9, 0x1c, 0x49, 0x3f,
+	0xe6, 0x08, 0x4c, 0xe7, 0x48, 0xfa, 0x39, 0x47, 0xd2, 0x87, 0x4a, 0x74, 0xee, 0x99, 0x76, 0x44,
+	0x06, 0x65, 0xa1, 0xfe, 0xe2, 0xcf, 0x00, 0x5d, 0x06, 0x2c, 0x45, 0xa8, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TwoFactorClient is the client API for TwoFactor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TwoFactorClient interface {
+	// Enroll генерирует новый секретный ключ для двухфакторной авторизации.
+	// Двухфакторная авторизация включается только после подтверждения ключа
+	// с помощью Confirm. Повторный вызов до подтверждения заменяет ключ на
+	// новый.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь не зарегистрирован или заблокирован
+	//  - FailedPrecondition - двухфакторная авторизация уже включена
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Enroll(ctx context.Context, in *TwoFactorUser, opts ...grpc.CallOption) (*TOTPSecret, error)
+	// Confirm проверяет код из приложения и включает двухфакторную
+	// авторизацию. Возвращает список одноразовых кодов восстановления,
+	// которые можно использовать вместо кода из приложения.
+	//
+	// Возвращает ошибки:
+	//  - FailedPrecondition - ключ не сгенерирован или авторизация уже включена
+	//  - InvalidArgument - неверный код или формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Confirm(ctx context.Context, in *TwoFactorCode, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	// Disable проверяет код из приложения или код восстановления и отключает
+	// двухфакторную авторизацию.
+	//
+	// Неверные коды подсчитываются для пользователя. После превышения
+	// ограничения проверка временно блокируется и возвращается ошибка
+	// ResourceExhausted, в деталях которой передается RetryInfo со временем до
+	// окончания блокировки.
+	//
+	// Возвращает ошибки:
+	//  - FailedPrecondition - двухфакторная авторизация не включена
+	//  - InvalidArgument - неверный код или формат данных входящего запроса
+	//  - ResourceExhausted - слишком много неверных кодов
+	//  - Internal - внутренние ошибки
+	Disable(ctx context.Context, in *TwoFactorCode, opts ...grpc.CallOption) (*types.Empty, error)
+	// Authorize завершает авторизацию пользователя по коду из приложения или
+	// коду восстановления. Возвращает информацию о пользователе и токены
+	// созданной сессии.
+	//
+	// Количество попыток ввода кода для одного запроса ограничено, после чего
+	// необходимо заново авторизоваться по паролю. Кроме того, неверные коды
+	// подсчитываются для пользователя по всем запросам: после превышения
+	// ограничения проверка временно блокируется и возвращается ошибка
+	// ResourceExhausted, в деталях которой передается RetryInfo со временем до
+	// окончания блокировки. Счетчик неудачных попыток авторизации по паролю
+	// сбрасывается только после успешной проверки кода.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь заблокирован
+	//  - FailedPrecondition - время жизни запроса истекло
+	//  - InvalidArgument - неверный код, запрос или формат данных
+	//  - ResourceExhausted - слишком много неверных кодов
+	//  - Internal - внутренние ошибки
+	Authorize(ctx context.Context, in *SecondFactor, opts ...grpc.CallOption) (*User, error)
+}
+
+type twoFactorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTwoFactorClient(cc *grpc.ClientConn) TwoFactorClient {
+	return &twoFactorClient{cc}
+}
+
+func (c *twoFactorClient) Enroll(ctx context.Context, in *TwoFactorUser, opts ...grpc.CallOption) (*TOTPSecret, error) {
+	out := new(TOTPSecret)
+	err := c.cc.Invoke(ctx, "/itube.users.TwoFactor/Enroll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorClient) Confirm(ctx context.Context, in *TwoFactorCode, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, "/itube.users.TwoFactor/Confirm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorClient) Disable(ctx context.Context, in *TwoFactorCode, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/itube.users.TwoFactor/Disable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twoFactorClient) Authorize(ctx context.Context, in *SecondFactor, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/itube.users.TwoFactor/Authorize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TwoFactorServer is the server API for TwoFactor service.
+type TwoFactorServer interface {
+	// Enroll генерирует новый секретный ключ для двухфакторной авторизации.
+	// Двухфакторная авторизация включается только после подтверждения ключа
+	// с помощью Confirm. Повторный вызов до подтверждения заменяет ключ на
+	// новый.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь не зарегистрирован или заблокирован
+	//  - FailedPrecondition - двухфакторная авторизация уже включена
+	//  - InvalidArgument - неверный формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Enroll(context.Context, *TwoFactorUser) (*TOTPSecret, error)
+	// Confirm проверяет код из приложения и включает двухфакторную
+	// авторизацию. Возвращает список одноразовых кодов восстановления,
+	// которые можно использовать вместо кода из приложения.
+	//
+	// Возвращает ошибки:
+	//  - FailedPrecondition - ключ не сгенерирован или авторизация уже включена
+	//  - InvalidArgument - неверный код или формат данных входящего запроса
+	//  - Internal - внутренние ошибки
+	Confirm(context.Context, *TwoFactorCode) (*RecoveryCodes, error)
+	// Disable проверяет код из приложения или код восстановления и отключает
+	// двухфакторную авторизацию.
+	//
+	// Неверные коды подсчитываются для пользователя. После превышения
+	// ограничения проверка временно блокируется и возвращается ошибка
+	// ResourceExhausted, в деталях которой передается RetryInfo со временем до
+	// окончания блокировки.
+	//
+	// Возвращает ошибки:
+	//  - FailedPrecondition - двухфакторная авторизация не включена
+	//  - InvalidArgument - неверный код или формат данных входящего запроса
+	//  - ResourceExhausted - слишком много неверных кодов
+	//  - Internal - внутренние ошибки
+	Disable(context.Context, *TwoFactorCode) (*types.Empty, error)
+	// Authorize завершает авторизацию пользователя по коду из приложения или
+	// коду восстановления. Возвращает информацию о пользователе и токены
+	// созданной сессии.
+	//
+	// Количество попыток ввода кода для одного запроса ограничено, после чего
+	// необходимо заново авторизоваться по паролю. Кроме того, неверные коды
+	// подсчитываются для пользователя по всем запросам: после превышения
+	// ограничения проверка временно блокируется и возвращается ошибка
+	// ResourceExhausted, в деталях которой передается RetryInfo со временем до
+	// окончания блокировки. Счетчик неудачных попыток авторизации по паролю
+	// сбрасывается только после успешной проверки кода.
+	//
+	// Возвращает ошибки:
+	//  - NotFound - пользователь заблокирован
+	//  - FailedPrecondition - время жизни запроса истекло
+	//  - InvalidArgument - неверный код, запрос или формат данных
+	//  - ResourceExhausted - слишком много неверных кодов
+	//  - Internal - внутренние ошибки
+	Authorize(context.Context, *SecondFactor) (*User, error)
+}
+
+// UnimplementedTwoFactorServer can be embedded to have forward compatible implementations.
+type UnimplementedTwoFactorServer struct {
+}
+
+func (*UnimplementedTwoFactorServer) Enroll(ctx context.Context, req *TwoFactorUser) (*TOTPSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enroll not implemented")
+}
+func (*UnimplementedTwoFactorServer) Confirm(ctx context.Context, req *TwoFactorCode) (*RecoveryCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Confirm not implemented")
+}
+func (*UnimplementedTwoFactorServer) Disable(ctx context.Context, req *TwoFactorCode) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disable not implemented")
+}
+func (*UnimplementedTwoFactorServer) Authorize(ctx context.Context, req *SecondFactor) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+
+func RegisterTwoFactorServer(s *grpc.Server, srv TwoFactorServer) {
+	s.RegisterService(&_TwoFactor_serviceDesc, srv)
+}
+
+func _TwoFactor_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.TwoFactor/Enroll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServer).Enroll(ctx, req.(*TwoFactorUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactor_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.TwoFactor/Confirm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServer).Confirm(ctx, req.(*TwoFactorCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactor_Disable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServer).Disable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.TwoFactor/Disable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServer).Disable(ctx, req.(*TwoFactorCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TwoFactor_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecondFactor)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwoFactorServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itube.users.TwoFactor/Authorize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwoFactorServer).Authorize(ctx, req.(*SecondFactor))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TwoFactor_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "itube.users.TwoFactor",
+	HandlerType: (*TwoFactorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Enroll",
+			Handler:    _TwoFactor_Enroll_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _TwoFactor_Confirm_Handler,
+		},
+		{
+			MethodName: "Disable",
+			Handler:    _TwoFactor_Disable_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _TwoFactor_Authorize_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "twofactor.proto",
+}
+
+func (m *Challenge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Challenge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Challenge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Expires != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintTwofactor(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Challenge) > 0 {
+		i -= len(m.Challenge)
+		copy(dAtA[i:], m.Challenge)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Challenge)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SecondFactor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SecondFactor) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SecondFactor) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Code) > 0 {
+		i -= len(m.Code)
+		copy(dAtA[i:], m.Code)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Code)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Challenge) > 0 {
+		i -= len(m.Challenge)
+		copy(dAtA[i:], m.Challenge)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Challenge)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TwoFactorUser) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TwoFactorUser) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TwoFactorUser) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TwoFactorCode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TwoFactorCode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TwoFactorCode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Code) > 0 {
+		i -= len(m.Code)
+		copy(dAtA[i:], m.Code)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Code)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TOTPSecret) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TOTPSecret) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TOTPSecret) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.URI) > 0 {
+		i -= len(m.URI)
+		copy(dAtA[i:], m.URI)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.URI)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Secret) > 0 {
+		i -= len(m.Secret)
+		copy(dAtA[i:], m.Secret)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Secret)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecoveryCodes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecoveryCodes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecoveryCodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Codes) > 0 {
+		for iNdEx := len(m.Codes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Codes[iNdEx])
+			copy(dAtA[i:], m.Codes[iNdEx])
+			i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Codes[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Domain) > 0 {
+		i -= len(m.Domain)
+		copy(dAtA[i:], m.Domain)
+		i = encodeVarintTwofactor(dAtA, i, uint64(len(m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTwofactor(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTwofactor(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Challenge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.Challenge)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	return n
+}
+
+func (m *SecondFactor) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.Challenge)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	return n
+}
+
+func (m *TwoFactorUser) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	return n
+}
+
+func (m *TwoFactorCode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	return n
+}
+
+func (m *TOTPSecret) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	l = len(m.URI)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	return n
+}
+
+func (m *RecoveryCodes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovTwofactor(uint64(l))
+	}
+	if len(m.Codes) > 0 {
+		for _, s := range m.Codes {
+			l = len(s)
+			n += 1 + l + sovTwofactor(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovTwofactor(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTwofactor(x uint64) (n int) {
+	return sovTwofactor(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Challenge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Challenge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Challenge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Challenge", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Challenge = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwofactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SecondFactor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SecondFactor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SecondFactor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Challenge", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Challenge = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwofactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TwoFactorUser) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TwoFactorUser: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TwoFactorUser: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwofactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TwoFactorCode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TwoFactorCode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TwoFactorCode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwofactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TOTPSecret) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TOTPSecret: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TOTPSecret: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URI", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URI = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwofactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecoveryCodes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecoveryCodes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecoveryCodes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codes = append(m.Codes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwofactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTwofactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTwofactor(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTwofactor
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTwofactor
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTwofactor
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTwofactor
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTwofactor
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTwofactor        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTwofactor          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTwofactor = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,84 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: twofactor.proto
+
+package api
+
+import (
+	fmt "fmt"
+	math "math"
+	proto "github.com/gogo/protobuf/proto"
+	golang_proto "github.com/golang/protobuf/proto"
+	_ "github.com/mwitkow/go-proto-validators"
+	_ "github.com/gogo/protobuf/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	time "time"
+	regexp "regexp"
+	github_com_mwitkow_go_proto_validators "github.com/mwitkow/go-proto-validators"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = golang_proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+func (this *Challenge) Validate() error {
+	if this.Expires != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Expires); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Expires", err)
+		}
+	}
+	return nil
+}
+func (this *SecondFactor) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if this.Challenge == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Challenge", fmt.Errorf(`value '%v' must not be an empty string`, this.Challenge))
+	}
+	if this.Code == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Code", fmt.Errorf(`value '%v' must not be an empty string`, this.Code))
+	}
+	return nil
+}
+
+var _regex_TwoFactorUser_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *TwoFactorUser) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_TwoFactorUser_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	return nil
+}
+
+var _regex_TwoFactorCode_UID = regexp.MustCompile(`^([a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[4][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12})?$`)
+
+func (this *TwoFactorCode) Validate() error {
+	if this.Domain == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Domain", fmt.Errorf(`value '%v' must not be an empty string`, this.Domain))
+	}
+	if !_regex_TwoFactorCode_UID.MatchString(this.UID) {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.UID == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("UID", fmt.Errorf(`invalid unique identifier format`))
+	}
+	if this.Code == "" {
+		return github_com_mwitkow_go_proto_validators.FieldError("Code", fmt.Errorf(`value '%v' must not be an empty string`, this.Code))
+	}
+	return nil
+}
+func (this *TOTPSecret) Validate() error {
+	return nil
+}
+func (this *RecoveryCodes) Validate() error {
+	return nil
+}
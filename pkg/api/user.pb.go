@@ -35,6 +35,10 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 // При обновлении domain, verified и updated игнорируются. При авторизации
 // или запросе информации о пользователе в поле domain возвращается тоже
 // значение, что и было в запросе.
+//
+// При успешной авторизации в поле session возвращается информация о созданной
+// сессии пользователя с токенами доступа и обновления. Во всех остальных
+// случаях это поле не заполняется.
 type User struct {
 	// домен (возвращает тот, который был указан в запросе)
 	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
@@ -48,6 +52,8 @@ type User struct {
 	Updated *time.Time `protobuf:"bytes,5,opt,name=updated,proto3,stdtime" json:"updated,omitempty"`
 	// расширенные свойства
 	Properties *types.Struct `protobuf:"bytes,10,opt,name=properties,proto3" json:"properties,omitempty"`
+	// сессия пользователя (только при авторизации)
+	Session *Session `protobuf:"bytes,11,opt,name=session,proto3" json:"session,omitempty"`
 }
 
 func (m *User) Reset()         { *m = User{} }
@@ -83,6 +89,60 @@ func (m *User) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_User proto.InternalMessageInfo
 
+// Session описывает токены сессии авторизованного пользователя.
+//
+// Токен доступа является подписанным JWT и может проверяться другими
+// сервисами самостоятельно с помощью открытых ключей, получаемых через
+// Sessions.PublicKeys. Токен обновления используется для получения новой
+// пары токенов через Sessions.Refresh и является одноразовым.
+type Session struct {
+	// уникальный идентификатор сессии
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// токен доступа (JWT)
+	AccessToken string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// тип токена доступа (всегда "Bearer")
+	TokenType string `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	// дата и время окончания действия токена доступа
+	Expires *time.Time `protobuf:"bytes,4,opt,name=expires,proto3,stdtime" json:"expires,omitempty"`
+	// токен обновления сессии
+	RefreshToken string `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	// дата и время окончания действия токена обновления
+	RefreshExpires *time.Time `protobuf:"bytes,6,opt,name=refresh_expires,json=refreshExpires,proto3,stdtime" json:"refresh_expires,omitempty"`
+}
+
+func (m *Session) Reset()         { *m = Session{} }
+func (m *Session) String() string { return proto.CompactTextString(m) }
+func (*Session) ProtoMessage()    {}
+func (*Session) Descriptor() ([]byte, []int) {
+	return fileDescriptor_116e343673f7ffaf, []int{1}
+}
+func (m *Session) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Session) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Session.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Session) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Session.Merge(m, src)
+}
+func (m *Session) XXX_Size() int {
+	return m.Size()
+}
+func (m *Session) XXX_DiscardUnknown() {
+	xxx_messageInfo_Session.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Session proto.InternalMessageInfo
+
 // RegInfo описывает дополнительную информацию, используемую при регистрации.
 type RegInfo struct {
 	// точка перехода
@@ -96,7 +156,7 @@ func (m *RegInfo) Reset()         { *m = RegInfo{} }
 func (m *RegInfo) String() string { return proto.CompactTextString(m) }
 func (*RegInfo) ProtoMessage()    {}
 func (*RegInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_116e343673f7ffaf, []int{1}
+	return fileDescriptor_116e343673f7ffaf, []int{2}
 }
 func (m *RegInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -128,6 +188,8 @@ var xxx_messageInfo_RegInfo proto.InternalMessageInfo
 func init() {
 	proto.RegisterType((*User)(nil), "itube.users.User")
 	golang_proto.RegisterType((*User)(nil), "itube.users.User")
+	proto.RegisterType((*Session)(nil), "itube.users.Session")
+	golang_proto.RegisterType((*Session)(nil), "itube.users.Session")
 	proto.RegisterType((*RegInfo)(nil), "itube.users.RegInfo")
 	golang_proto.RegisterType((*RegInfo)(nil), "itube.users.RegInfo")
 	proto.RegisterMapType((map[string]string)(nil), "itube.users.RegInfo.UtmEntry")
@@ -138,37 +200,44 @@ func init() { proto.RegisterFile("user.proto", fileDescriptor_116e343673f7ffaf)
 func init() { golang_proto.RegisterFile("user.proto", fileDescriptor_116e343673f7ffaf) }
 
 var fileDescriptor_116e343673f7ffaf = []byte{
-	// 467 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0xb1, 0x6f, 0xd3, 0x40,
-	0x14, 0xc6, 0x7d, 0x71, 0x9a, 0x84, 0xcb, 0x82, 0x4e, 0x48, 0x58, 0x56, 0x39, 0x5b, 0x5d, 0x88,
-	0x90, 0x62, 0x8b, 0x20, 0x95, 0xaa, 0x63, 0x54, 0x86, 0x0e, 0x2c, 0xa6, 0x91, 0x2a, 0x36, 0xa7,
-	0x7e, 0x36, 0xa7, 0xc4, 0x3e, 0x73, 0xbe, 0x4b, 0xd5, 0xff, 0x80, 0x31, 0x0b, 0x13, 0xff, 0x0c,
-	0x63, 0xc7, 0x8c, 0x4c, 0x84, 0xda, 0xff, 0x08, 0xf2, 0xd9, 0x2e, 0x01, 0xb6, 0xf7, 0xbd, 0xef,
-	0xfb, 0x3d, 0xcb, 0x9f, 0x8d, 0xb1, 0x2a, 0x40, 0x78, 0xb9, 0xe0, 0x92, 0x93, 0x31, 0x93, 0x6a,
-	0x09, 0x5e, 0xbd, 0x29, 0xec, 0xe3, 0x84, 0xf3, 0x64, 0x0d, 0xbe, 0xb6, 0x96, 0x2a, 0xf6, 0x0b,
-	0x29, 0xd4, 0x8d, 0x6c, 0xa2, 0xb6, 0xf3, 0xaf, 0x2b, 0x59, 0x0a, 0x85, 0x0c, 0xd3, 0xbc, 0x0d,
-	0x4c, 0x13, 0x26, 0x3f, 0xa9, 0xa5, 0x77, 0xc3, 0x53, 0x3f, 0xe1, 0x09, 0xff, 0x93, 0xac, 0x95,
-	0x16, 0x7a, 0x6a, 0xe3, 0xa7, 0x07, 0xf1, 0xf4, 0x96, 0xc9, 0x15, 0xbf, 0xf5, 0x13, 0x3e, 0xd5,
-	0xe6, 0x74, 0x13, 0xae, 0x59, 0x14, 0x4a, 0x2e, 0x0a, 0xff, 0x71, 0x6c, 0xb8, 0x93, 0x6f, 0x3d,
-	0xdc, 0x5f, 0x14, 0x20, 0x08, 0xc5, 0x83, 0x88, 0xa7, 0x21, 0xcb, 0x2c, 0xe4, 0xa2, 0xc9, 0x93,
-	0xf9, 0xa0, 0xdc, 0x3b, 0xbd, 0x6b, 0x14, 0xb4, 0x5b, 0x72, 0x81, 0x4d, 0xc5, 0x22, 0xab, 0xa7,
-	0xcd, 0x59, 0xf9, 0xd3, 0x31, 0x17, 0x97, 0x17, 0xe5, 0xde, 0x79, 0xf9, 0xca, 0x65, 0x99, 0xbe,
-	0xea, 0xaa, 0x8c, 0x7d, 0x56, 0xe0, 0xb2, 0x08, 0x32, 0xc9, 0x62, 0x06, 0xc2, 0x8d, 0xb9, 0x48,
-	0x43, 0x79, 0x8d, 0xb6, 0xa8, 0x1f, 0xd4, 0x38, 0x39, 0xc6, 0x47, 0x90, 0x86, 0x6c, 0x6d, 0x99,
-	0x7f, 0x3d, 0xa4, 0x59, 0x12, 0x1b, 0x8f, 0x36, 0x20, 0x6a, 0x34, 0xb2, 0xfa, 0x2e, 0x9a, 0x8c,
-	0x82, 0x47, 0x4d, 0xce, 0xf1, 0x50, 0xe5, 0x51, 0x28, 0x21, 0xb2, 0x8e, 0x5c, 0x34, 0x19, 0xcf,
-	0x6c, 0xaf, 0xa9, 0xd0, 0xeb, 0x8a, 0xf1, 0xae, 0xba, 0x0a, 0xe7, 0xfd, 0xed, 0xde, 0x41, 0x41,
-	0x07, 0x90, 0xb7, 0x18, 0xe7, 0x82, 0xe7, 0x20, 0x24, 0x83, 0xc2, 0xc2, 0x1a, 0x7f, 0xfe, 0x1f,
-	0xfe, 0x41, 0x7f, 0x9f, 0xe0, 0x20, 0x7a, 0xf2, 0x15, 0xe1, 0x61, 0x00, 0xc9, 0x65, 0x16, 0x73,
-	0x62, 0xe1, 0xa1, 0x80, 0x18, 0x04, 0x88, 0xa6, 0xa1, 0xa0, 0x93, 0xe4, 0x0c, 0x9b, 0x4a, 0xa6,
-	0x56, 0xcf, 0x35, 0x27, 0xe3, 0xd9, 0x0b, 0xef, 0xe0, 0x27, 0xf0, 0x5a, 0xd8, 0x5b, 0xc8, 0xf4,
-	0x5d, 0x26, 0xc5, 0xdd, 0x7c, 0xa8, 0x9b, 0xbb, 0x7a, 0x1f, 0xd4, 0x88, 0x7d, 0x8a, 0x47, 0x9d,
-	0x43, 0x9e, 0x62, 0x73, 0x05, 0x77, 0xed, 0xed, 0x7a, 0x24, 0xcf, 0xf0, 0xd1, 0x26, 0x5c, 0x2b,
-	0x68, 0x4a, 0x0f, 0x1a, 0x71, 0xde, 0x3b, 0x43, 0xf3, 0xd7, 0xf7, 0x0f, 0xd4, 0xd8, 0x3d, 0x50,
-	0xe3, 0xbe, 0xa4, 0x68, 0x57, 0x52, 0xf4, 0xab, 0xa4, 0xe8, 0x4b, 0x45, 0x8d, 0x6d, 0x45, 0x8d,
-	0xef, 0x15, 0x45, 0xbb, 0x8a, 0x1a, 0x3f, 0x2a, 0x6a, 0x7c, 0x1c, 0xe6, 0xab, 0xc4, 0x0f, 0x73,
-	0xb6, 0x1c, 0xe8, 0xf7, 0x7c, 0xf3, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xe4, 0x61, 0x1e, 0x74, 0xb0,
-	0x02, 0x00, 0x00,
+	// 590 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x3f, 0x4f, 0xdb, 0x40,
+	0x18, 0xc6, 0x73, 0xce, 0x1f, 0xc3, 0x1b, 0xfa, 0x47, 0x27, 0xd4, 0x5a, 0x11, 0x38, 0x2e, 0x1d,
+	0x1a, 0x55, 0xc2, 0x51, 0xa9, 0x44, 0x11, 0x63, 0x04, 0x43, 0x86, 0x2e, 0x26, 0x48, 0xa8, 0x0b,
+	0x72, 0xe2, 0x37, 0xe6, 0x94, 0xd8, 0xe7, 0xde, 0x9d, 0xa1, 0xf9, 0x06, 0xdd, 0xca, 0xd2, 0xef,
+	0xd3, 0x11, 0xa9, 0x0b, 0x63, 0x27, 0x28, 0xc9, 0x17, 0xa9, 0x7c, 0xb6, 0x69, 0x68, 0x17, 0xb6,
+	0x7b, 0x9f, 0xe7, 0xf9, 0xdd, 0x2b, 0x3f, 0x67, 0x80, 0x54, 0xa2, 0x70, 0x13, 0xc1, 0x15, 0xa7,
+	0x4d, 0xa6, 0xd2, 0x21, 0xba, 0x99, 0x22, 0x5b, 0x1b, 0x21, 0xe7, 0xe1, 0x14, 0xbb, 0xda, 0x1a,
+	0xa6, 0xe3, 0xae, 0x54, 0x22, 0x1d, 0xa9, 0x3c, 0xda, 0x6a, 0xff, 0xeb, 0x2a, 0x16, 0xa1, 0x54,
+	0x7e, 0x94, 0x14, 0x81, 0xed, 0x90, 0xa9, 0xb3, 0x74, 0xe8, 0x8e, 0x78, 0xd4, 0x0d, 0x79, 0xc8,
+	0xff, 0x26, 0xb3, 0x49, 0x0f, 0xfa, 0x54, 0xc4, 0x77, 0x97, 0xe2, 0xd1, 0x05, 0x53, 0x13, 0x7e,
+	0xd1, 0x0d, 0xf9, 0xb6, 0x36, 0xb7, 0xcf, 0xfd, 0x29, 0x0b, 0x7c, 0xc5, 0x85, 0xec, 0xde, 0x1f,
+	0x73, 0x6e, 0xeb, 0xa7, 0x01, 0xb5, 0x63, 0x89, 0x82, 0xda, 0xd0, 0x08, 0x78, 0xe4, 0xb3, 0xd8,
+	0x22, 0x0e, 0xe9, 0xac, 0xf6, 0x1a, 0xf3, 0xdb, 0xb6, 0x71, 0x42, 0xbc, 0x42, 0xa5, 0x07, 0x50,
+	0x4d, 0x59, 0x60, 0x19, 0xda, 0xdc, 0x99, 0xdf, 0xb4, 0xab, 0xc7, 0xfd, 0x83, 0xf9, 0x6d, 0xfb,
+	0xcd, 0x09, 0xb9, 0x24, 0xb5, 0xb7, 0x0e, 0x8b, 0xf5, 0xd5, 0x4e, 0x1a, 0xb3, 0xcf, 0x29, 0x3a,
+	0x2c, 0xc0, 0x58, 0xb1, 0x31, 0x43, 0xe1, 0x8c, 0xb9, 0x88, 0x7c, 0xe5, 0x65, 0x38, 0xdd, 0x80,
+	0x3a, 0x46, 0x3e, 0x9b, 0x5a, 0xd5, 0x07, 0x4b, 0x72, 0x91, 0xb6, 0x60, 0xe5, 0x1c, 0x45, 0x46,
+	0x05, 0x56, 0xcd, 0x21, 0x9d, 0x15, 0xef, 0x7e, 0xa6, 0xfb, 0x60, 0xa6, 0x49, 0xe0, 0x2b, 0x0c,
+	0xac, 0xba, 0x43, 0x3a, 0xcd, 0x9d, 0x96, 0x9b, 0x57, 0xe8, 0x96, 0xc5, 0xb8, 0x83, 0xb2, 0xc2,
+	0x5e, 0xed, 0xf2, 0xb6, 0x4d, 0xbc, 0x12, 0xa0, 0x1f, 0x00, 0x12, 0xc1, 0x13, 0x14, 0x8a, 0xa1,
+	0xb4, 0x40, 0xe3, 0x2f, 0xff, 0xc3, 0x8f, 0xf4, 0xfb, 0x78, 0x4b, 0x51, 0xea, 0x82, 0x29, 0x51,
+	0x4a, 0xc6, 0x63, 0xab, 0xa9, 0xa9, 0x75, 0x77, 0xe9, 0x89, 0xdd, 0xa3, 0xdc, 0xf3, 0xca, 0xd0,
+	0xd6, 0x37, 0x03, 0xcc, 0x42, 0xa4, 0x2f, 0xc0, 0x60, 0xc1, 0x7d, 0x99, 0x37, 0x6d, 0xa3, 0x7f,
+	0xe0, 0x19, 0x2c, 0xa0, 0xaf, 0x60, 0xcd, 0x1f, 0x8d, 0x50, 0xca, 0x53, 0xc5, 0x27, 0x18, 0xe7,
+	0x8d, 0x7a, 0xcd, 0x5c, 0x1b, 0x64, 0x12, 0xdd, 0x04, 0xd0, 0xde, 0xa9, 0x9a, 0x25, 0x98, 0x57,
+	0xe5, 0xad, 0x6a, 0x65, 0x30, 0x4b, 0x30, 0xab, 0x02, 0xbf, 0x24, 0x4c, 0xa0, 0xb4, 0x6a, 0x8f,
+	0xad, 0xa2, 0x00, 0xe8, 0x6b, 0x78, 0x22, 0x70, 0x2c, 0x50, 0x9e, 0x15, 0xeb, 0xeb, 0xfa, 0xf6,
+	0xb5, 0x42, 0xcc, 0xf7, 0xf7, 0xe1, 0x59, 0x19, 0x2a, 0x17, 0x35, 0x1e, 0xb9, 0xe8, 0x69, 0x01,
+	0x1e, 0xe6, 0xdc, 0xd6, 0x77, 0x02, 0xa6, 0x87, 0x61, 0x3f, 0x1e, 0x73, 0x6a, 0x81, 0x29, 0x70,
+	0x8c, 0x02, 0x45, 0x5e, 0x8b, 0x57, 0x8e, 0x74, 0x0f, 0xaa, 0xa9, 0x8a, 0x2c, 0xc3, 0xa9, 0x76,
+	0x9a, 0x3b, 0x9b, 0x0f, 0x3a, 0x2e, 0x60, 0xf7, 0x58, 0x45, 0x87, 0xb1, 0x12, 0xb3, 0x9e, 0xa9,
+	0xff, 0xbd, 0xc1, 0x47, 0x2f, 0x43, 0x5a, 0xbb, 0xb0, 0x52, 0x3a, 0xf4, 0x39, 0x54, 0x27, 0x38,
+	0x2b, 0xee, 0xce, 0x8e, 0x74, 0x1d, 0xea, 0xe7, 0xfe, 0x34, 0xc5, 0xa2, 0xe4, 0x7c, 0xd8, 0x37,
+	0xf6, 0x48, 0xef, 0xdd, 0xd5, 0x9d, 0x5d, 0xb9, 0xbe, 0xb3, 0x2b, 0x57, 0x73, 0x9b, 0x5c, 0xcf,
+	0x6d, 0xf2, 0x7b, 0x6e, 0x93, 0xaf, 0x0b, 0xbb, 0x72, 0xb9, 0xb0, 0x2b, 0x3f, 0x16, 0x36, 0xb9,
+	0x5e, 0xd8, 0x95, 0x5f, 0x0b, 0xbb, 0xf2, 0xc9, 0x4c, 0x26, 0x61, 0xd7, 0x4f, 0xd8, 0xb0, 0xa1,
+	0x3f, 0xfa, 0xfd, 0x9f, 0x01, 0x00, 0xb9, 0xf5, 0x29, 0xd1, 0xf2, 0x03, 0x00, 0x00,
 }
 
 func (m *User) Marshal() (dAtA []byte, err error) {
@@ -191,6 +260,18 @@ func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Session != nil {
+		{
+			size, err := m.Session.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.Properties != nil {
 		{
 			size, err := m.Properties.MarshalToSizedBuffer(dAtA[:i])
@@ -204,12 +285,12 @@ func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x52
 	}
 	if m.Updated != nil {
-		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Updated, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Updated):])
-		if err2 != nil {
-			return 0, err2
+		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Updated, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Updated):])
+		if err3 != nil {
+			return 0, err3
 		}
-		i -= n2
-		i = encodeVarintUser(dAtA, i, uint64(n2))
+		i -= n3
+		i = encodeVarintUser(dAtA, i, uint64(n3))
 		i--
 		dAtA[i] = 0x2a
 	}
@@ -247,6 +328,77 @@ func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Session) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Session) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Session) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RefreshExpires != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.RefreshExpires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.RefreshExpires):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintUser(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.RefreshToken) > 0 {
+		i -= len(m.RefreshToken)
+		copy(dAtA[i:], m.RefreshToken)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.RefreshToken)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Expires != nil {
+		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err5 != nil {
+			return 0, err5
+		}
+		i -= n5
+		i = encodeVarintUser(dAtA, i, uint64(n5))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TokenType) > 0 {
+		i -= len(m.TokenType)
+		copy(dAtA[i:], m.TokenType)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.TokenType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AccessToken) > 0 {
+		i -= len(m.AccessToken)
+		copy(dAtA[i:], m.AccessToken)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.AccessToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *RegInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -336,6 +488,43 @@ func (m *User) Size() (n int) {
 		l = m.Properties.Size()
 		n += 1 + l + sovUser(uint64(l))
 	}
+	if m.Session != nil {
+		l = m.Session.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
+	return n
+}
+
+func (m *Session) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.AccessToken)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.TokenType)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.RefreshToken)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.RefreshExpires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.RefreshExpires)
+		n += 1 + l + sovUser(uint64(l))
+	}
 	return n
 }
 
@@ -583,6 +772,295 @@ func (m *User) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Session", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Session == nil {
+				m.Session = &Session{}
+			}
+			if err := m.Session.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Session) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Session: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Session: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshExpires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RefreshExpires == nil {
+				m.RefreshExpires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.RefreshExpires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipUser(dAtA[iNdEx:])
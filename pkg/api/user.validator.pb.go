@@ -48,6 +48,24 @@ func (this *User) Validate() error {
 			return github_com_mwitkow_go_proto_validators.FieldError("Properties", err)
 		}
 	}
+	if this.Session != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Session); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Session", err)
+		}
+	}
+	return nil
+}
+func (this *Session) Validate() error {
+	if this.Expires != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Expires); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Expires", err)
+		}
+	}
+	if this.RefreshExpires != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.RefreshExpires); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("RefreshExpires", err)
+		}
+	}
 	return nil
 }
 func (this *RegInfo) Validate() error {
@@ -8,6 +8,12 @@ import (
 	"gopkg.in/mail.v2"
 )
 
+// SendDialer устанавливает соединение с SMTP-сервером для отправки писем.
+// Реализуется *mail.Dialer; в тестах может быть заменен.
+type SendDialer interface {
+	Dial() (mail.SendCloser, error)
+}
+
 // Dialer инициализируем объект для отправки почты.
 func Dialer(connection string) (*mail.Dialer, error) {
 	// разбираем параметры подключения к серверу SMTP
@@ -6,14 +6,13 @@ import (
 	"path/filepath"
 	"sort"
 
-	"gopkg.in/mail.v2"
 	"gopkg.in/yaml.v2"
 )
 
 // Templates описывает список доменов и поддерживаемые для них шаблоны писем.
 type Templates struct {
-	*mail.Dialer
-	list map[string]Domain
+	Dialer SendDialer // соединение с SMTP-сервером
+	list   map[string]Domain
 }
 
 // Init загружает шаблоны писем из файла в формате yaml.
@@ -0,0 +1,160 @@
+// Package keyring описывает шифрование данных для хранения с поддержкой
+// ротации ключей.
+//
+// Данные шифруются AES-256-GCM. Вместе с зашифрованными данными сохраняется
+// идентификатор ключа, которым они были зашифрованы, поэтому при ротации
+// новый ключ добавляется первым в список, а старые остаются для
+// расшифровки ранее сохраненных данных. Keyring.NeedsRotation сообщает, что
+// данные зашифрованы не основным ключом и их следует зашифровать заново.
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// KeySize задает размер ключа в байтах (AES-256).
+const KeySize = 32
+
+// ошибки шифрования
+var (
+	// ErrNoKeys возвращается, если не задано ни одного ключа.
+	ErrNoKeys = errors.New("no encryption keys")
+	// ErrUnknownKey возвращается, если данные зашифрованы неизвестным ключом.
+	ErrUnknownKey = errors.New("unknown encryption key")
+	// ErrDecrypt возвращается, если данные повреждены или не соответствуют
+	// ключу и дополнительным данным.
+	ErrDecrypt = errors.New("decryption failed")
+)
+
+// Key описывает ключ шифрования.
+type Key struct {
+	ID     string `yaml:"id"`  // идентификатор ключа (не длиннее 255 байт)
+	Secret string `yaml:"key"` // ключ в формате base64
+}
+
+// Keyring шифрует и расшифровывает данные набором ключей. Новые данные
+// шифруются первым (основным) ключом.
+type Keyring struct {
+	primary string                 // идентификатор основного ключа
+	aeads   map[string]cipher.AEAD // ключи по идентификаторам
+}
+
+// New возвращает набор ключей шифрования. Первый ключ используется как
+// основной.
+func New(keys ...Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	var k = &Keyring{
+		primary: keys[0].ID,
+		aeads:   make(map[string]cipher.AEAD, len(keys)),
+	}
+	for _, key := range keys {
+		if key.ID == "" || len(key.ID) > 255 {
+			return nil, fmt.Errorf("invalid key id: %q", key.ID)
+		}
+		if _, ok := k.aeads[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id: %s", key.ID)
+		}
+		secret, err := base64.StdEncoding.DecodeString(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+		if len(secret) != KeySize {
+			return nil, fmt.Errorf("key %s: must be %d bytes", key.ID, KeySize)
+		}
+		block, err := aes.NewCipher(secret)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.ID, err)
+		}
+		k.aeads[key.ID] = aead
+	}
+	return k, nil
+}
+
+// Load загружает набор ключей из файла в формате yaml. Перед разбором в
+// содержимом файла подставляются значения переменных окружения в виде $NAME
+// или ${NAME}.
+func Load(filename string) (*Keyring, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	var keys []Key
+	err = yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &keys)
+	if err != nil {
+		return nil, err
+	}
+	return New(keys...)
+}
+
+// Encrypt шифрует данные основным ключом. additional задает дополнительные
+// данные, которые не шифруются, но должны совпадать при расшифровке:
+// например, идентификатор владельца, чтобы зашифрованные данные нельзя было
+// перенести к другому владельцу.
+//
+// Результат содержит длину и идентификатор ключа, nonce и зашифрованные
+// данные.
+func (k *Keyring) Encrypt(plain, additional []byte) ([]byte, error) {
+	var aead = k.aeads[k.primary]
+	var data = make([]byte, 0,
+		1+len(k.primary)+aead.NonceSize()+len(plain)+aead.Overhead())
+	data = append(data, byte(len(k.primary)))
+	data = append(data, k.primary...)
+	var nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	data = append(data, nonce...)
+	return aead.Seal(data, nonce, plain, additional), nil
+}
+
+// Decrypt расшифровывает данные ключом, которым они были зашифрованы.
+func (k *Keyring) Decrypt(data, additional []byte) ([]byte, error) {
+	id, rest, err := split(data)
+	if err != nil {
+		return nil, err
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	plain, err := aead.Open(nil, rest[:aead.NonceSize()],
+		rest[aead.NonceSize():], additional)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plain, nil
+}
+
+// NeedsRotation возвращает true, если данные зашифрованы не основным ключом.
+func (k *Keyring) NeedsRotation(data []byte) bool {
+	id, _, err := split(data)
+	return err == nil && id != k.primary
+}
+
+// split разделяет зашифрованные данные на идентификатор ключа и остальное.
+func split(data []byte) (string, []byte, error) {
+	if len(data) == 0 || len(data) < 1+int(data[0]) {
+		return "", nil, ErrDecrypt
+	}
+	var n = 1 + int(data[0])
+	return string(data[1:n]), data[n:], nil
+}
@@ -0,0 +1,174 @@
+package keyring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// testKey возвращает ключ с указанным идентификатором, все байты которого
+// равны b.
+func testKey(id string, b byte) Key {
+	return Key{
+		ID:     id,
+		Secret: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, KeySize)),
+	}
+}
+
+// newKeyring возвращает набор ключей или прерывает тест при ошибке.
+func newKeyring(t *testing.T, keys ...Key) *Keyring {
+	t.Helper()
+	k, err := New(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	var (
+		k          = newKeyring(t, testKey("k1", 1))
+		plain      = []byte("totp secret")
+		additional = []byte("uid")
+	)
+	data, err := k.Encrypt(plain, additional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, plain) {
+		t.Error("plain data in encrypted data")
+	}
+	decrypted, err := k.Decrypt(data, additional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plain) {
+		t.Errorf("decrypted %q, expected %q", decrypted, plain)
+	}
+	// nonce случайный, поэтому результаты шифрования различаются
+	other, err := k.Encrypt(plain, additional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(other, data) {
+		t.Error("same encrypted data for different nonces")
+	}
+	if k.NeedsRotation(data) {
+		t.Error("rotation for primary key")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	var (
+		plain      = []byte("totp secret")
+		additional = []byte("uid")
+		old        = newKeyring(t, testKey("k1", 1))
+	)
+	data, err := old.Encrypt(plain, additional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// новый ключ добавлен первым, старый остался для расшифровки
+	var k = newKeyring(t, testKey("k2", 2), testKey("k1", 1))
+	decrypted, err := k.Decrypt(data, additional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plain) {
+		t.Errorf("decrypted %q, expected %q", decrypted, plain)
+	}
+	if !k.NeedsRotation(data) {
+		t.Error("no rotation for old key")
+	}
+	rotated, err := k.Encrypt(decrypted, additional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.NeedsRotation(rotated) {
+		t.Error("rotation for primary key")
+	}
+	// старый ключ удален: данные, зашифрованные им, не расшифровываются
+	k = newKeyring(t, testKey("k2", 2))
+	if _, err = k.Decrypt(data, additional); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("unexpected unknown key error: %v", err)
+	}
+	if _, err = k.Decrypt(rotated, additional); err != nil {
+		t.Errorf("rotated data decrypt error: %v", err)
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	var k = newKeyring(t, testKey("k1", 1))
+	data, err := k.Encrypt([]byte("totp secret"), []byte("uid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// другие дополнительные данные
+	if _, err = k.Decrypt(data, []byte("other uid")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("unexpected additional data error: %v", err)
+	}
+	// другой ключ с тем же идентификатором
+	var other = newKeyring(t, testKey("k1", 2))
+	if _, err = other.Decrypt(data, []byte("uid")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("unexpected wrong key error: %v", err)
+	}
+	// поврежденные и усеченные данные
+	var corrupted = append([]byte(nil), data...)
+	corrupted[len(corrupted)-1] ^= 1
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"short id":  {5, 'k'},
+		"no nonce":  data[:4],
+		"corrupted": corrupted,
+	} {
+		if _, err = k.Decrypt(data, []byte("uid")); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(); !errors.Is(err, ErrNoKeys) {
+		t.Errorf("unexpected no keys error: %v", err)
+	}
+	for name, keys := range map[string][]Key{
+		"empty id":     {testKey("", 1)},
+		"duplicate id": {testKey("k1", 1), testKey("k1", 2)},
+		"short key":    {{ID: "k1", Secret: base64.StdEncoding.EncodeToString([]byte("short"))}},
+		"not base64":   {{ID: "k1", Secret: "!!!"}},
+	} {
+		if _, err := New(keys...); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var key = testKey("k1", 1)
+	os.Setenv("ITUBE_USERS_TEST_KEY", key.Secret)
+	defer os.Unsetenv("ITUBE_USERS_TEST_KEY")
+	file, err := ioutil.TempFile("", "keyring*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("- id: k1\n  key: ${ITUBE_USERS_TEST_KEY}\n")
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := newKeyring(t, key).Encrypt([]byte("totp secret"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loaded.Decrypt(data, nil); err != nil {
+		t.Errorf("decrypt error: %v", err)
+	}
+}
@@ -0,0 +1,331 @@
+package openid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Authenticator описывает провайдера внешней авторизации, независимо от
+// того, поддерживает он OpenID Connect или только OAuth2.
+type Authenticator interface {
+	// String возвращает идентификатор провайдера (название).
+	String() string
+	// Title возвращает отображаемое название провайдера.
+	Title() string
+	// LoginURL формирует и возвращает уникальный URL для авторизации
+	// пользователя через провайдера.
+	LoginURL(ctx context.Context, redirectURI string,
+		params map[string]string, data interface{}) (string, error)
+	// UserInfo проверяет ответ провайдера и возвращает информацию о
+	// пользователе и сохраненные при начале авторизации данные.
+	UserInfo(ctx context.Context, state, code string) (*UserInfo, json.RawMessage, error)
+	// Propagate возвращает список данных профиля, которые при каждой
+	// авторизации переносятся в свойства пользователя.
+	Propagate() []string
+	// StoreTokens возвращает true, если токены провайдера разрешено
+	// сохранять для последующих обращений к его API.
+	StoreTokens() bool
+	// Refresh получает у провайдера новый токен доступа по токену
+	// обновления.
+	Refresh(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error)
+	// FirstLogin дополняет информацию о пользователе данными, которые
+	// провайдер передает только при первой авторизации, и возвращает список
+	// названий заполненных данных профиля.
+	FirstLogin(userInfo *UserInfo, data []byte) []string
+}
+
+// проверка, что провайдеры поддерживают интерфейс
+var (
+	_ Authenticator = new(Provider)
+	_ Authenticator = new(OAuth2)
+)
+
+// NewAuthenticator инициализирует и возвращает провайдера авторизации в
+// зависимости от типа, указанного в конфигурации: "oidc" (по умолчанию) для
+// OpenID Connect или "oauth2" для OAuth2 с запросом профиля пользователя.
+func NewAuthenticator(cfg Config) (Authenticator, error) {
+	switch cfg.Type {
+	case "", TypeOIDC:
+		return New(cfg)
+	case TypeOAuth2:
+		return NewOAuth2(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported provider type: %s", cfg.Type)
+	}
+}
+
+// base содержит общую для провайдеров логику формирования адреса авторизации
+// и обмена кода авторизации на токен OAuth2.
+type base struct {
+	name         string            // название
+	title        string            // отображаемое название
+	params       map[string]string // дополнительные параметры авторизации
+	oauth2Config oauth2.Config     // конфигурация OAuth2
+	states       StateStore        // хранение состояний авторизации
+	redirectURLs RedirectURIs      // допустимые адреса для возврата
+	trustEmail   string            // доверие к подтверждению email провайдером
+	propagate    []string          // данные профиля для свойств пользователя
+	storeTokens  bool              // сохранять токены провайдера
+	secret       *clientSecret     // секретный ключ клиента в виде JWT
+	userMapping  Mapping           // правила для данных первой авторизации
+}
+
+// newBase возвращает инициализированную общую часть провайдера. Если
+// хранилище состояний не задано, то состояния хранятся в памяти.
+//
+// Способ возврата ответа, отличный от "query", добавляется к параметрам
+// авторизации как response_mode.
+func newBase(cfg Config, oauth2Config oauth2.Config) (base, error) {
+	switch cfg.TrustEmail {
+	case "", TrustProvider, TrustAlways, TrustNever:
+	default:
+		return base{}, fmt.Errorf("unsupported email trust: %s", cfg.TrustEmail)
+	}
+	if err := checkParams(cfg.Params); err != nil {
+		return base{}, err
+	}
+	var params = cfg.Params
+	switch cfg.ResponseMode {
+	case "", ResponseQuery:
+	case ResponseFormPost:
+		params = make(map[string]string, len(cfg.Params)+1)
+		for k, v := range cfg.Params {
+			params[k] = v
+		}
+		params["response_mode"] = cfg.ResponseMode
+	default:
+		return base{}, fmt.Errorf("unsupported response mode: %s", cfg.ResponseMode)
+	}
+	var secret *clientSecret
+	if cfg.ClientKey != nil {
+		var audience = cfg.URL
+		if audience == "" {
+			audience = cfg.TokenURL
+		}
+		var err error
+		secret, err = newClientSecret(*cfg.ClientKey, cfg.СlientID, audience)
+		if err != nil {
+			return base{}, fmt.Errorf("client key error: %w", err)
+		}
+	}
+	var userMapping = cfg.UserMapping
+	if userMapping == (Mapping{}) {
+		userMapping = DefaultMapping
+	}
+	var states = cfg.States
+	if states == nil {
+		states = NewMemoryStates()
+	}
+	return base{
+		name:         cfg.Name,
+		title:        cfg.Title,
+		params:       params,
+		oauth2Config: oauth2Config,
+		states:       states,
+		redirectURLs: cfg.RedirectURLs,
+		trustEmail:   cfg.TrustEmail,
+		propagate:    cfg.Propagate,
+		storeTokens:  cfg.StoreTokens,
+		secret:       secret,
+		userMapping:  userMapping,
+	}, nil
+}
+
+// reservedParams содержит параметры запроса авторизации, которые
+// формируются самим провайдером и не могут быть переопределены: иначе,
+// например, подмена redirect_uri позволила бы обойти список допустимых
+// адресов для возврата.
+var reservedParams = map[string]bool{
+	"client_id":     true,
+	"redirect_uri":  true,
+	"response_type": true,
+	"scope":         true,
+	"state":         true,
+	"nonce":         true,
+}
+
+// checkParams возвращает ErrReservedParam, если среди дополнительных
+// параметров авторизации есть зарезервированные.
+func checkParams(params map[string]string) error {
+	for name := range params {
+		var key = strings.ToLower(strings.TrimSpace(name))
+		if reservedParams[key] || strings.HasPrefix(key, "code_challenge") {
+			return fmt.Errorf("%w: %s", ErrReservedParam, name)
+		}
+	}
+	return nil
+}
+
+// config возвращает конфигурацию OAuth2 с действующим секретным ключом
+// клиента.
+func (p base) config() (oauth2.Config, error) {
+	var cfg = p.oauth2Config
+	if p.secret != nil {
+		secret, err := p.secret.Token()
+		if err != nil {
+			return cfg, fmt.Errorf("client secret error: %w", err)
+		}
+		cfg.ClientSecret = secret
+	}
+	return cfg, nil
+}
+
+// FirstLogin дополняет информацию о пользователе данными в формате JSON,
+// которые провайдер передает только при первой авторизации (например,
+// параметр user от Apple), по правилам UserMapping. Заполняются только
+// пустые поля имени и аватара, а идентификатор пользователя, email-адрес и
+// флаг его подтверждения не изменяются, т.к. эти данные не подписаны
+// провайдером и передаются через браузер. Возвращает список названий
+// заполненных данных профиля.
+func (p base) FirstLogin(userInfo *UserInfo, data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	var profile interface{}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil
+	}
+	return p.userMapping.complete(userInfo, profile)
+}
+
+// String возвращает идетификатор провайдера (название).
+func (p base) String() string {
+	return p.name
+}
+
+// Title возвращает отображаемое название провайдера.
+func (p base) Title() string {
+	if p.title == "" {
+		return p.name
+	}
+	return p.title
+}
+
+// Propagate возвращает список данных профиля, которые при каждой
+// авторизации переносятся в свойства пользователя.
+func (p base) Propagate() []string {
+	return p.propagate
+}
+
+// StoreTokens возвращает true, если токены провайдера разрешено
+// сохранять для последующих обращений к его API.
+func (p base) StoreTokens() bool {
+	return p.storeTokens
+}
+
+// Refresh получает у провайдера новый токен доступа по токену обновления.
+// Возвращает ErrNoRefreshToken, если токен обновления не задан.
+func (p base) Refresh(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	if token.RefreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+	// сбрасываем токен доступа, чтобы обновление выполнялось всегда
+	var expired = *token
+	expired.AccessToken = ""
+	cfg, err := p.config()
+	if err != nil {
+		return nil, err
+	}
+	refreshed, err := cfg.TokenSource(ctx, &expired).Token()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing access token: %w", err)
+	}
+	return refreshed, nil
+}
+
+// trust сохраняет в информации о пользователе настройку доверия провайдеру
+// и применяет ее к флагу подтверждения email-адреса.
+func (p base) trust(userInfo *UserInfo) {
+	userInfo.trustEmail = p.trustEmail
+	userInfo.applyTrust()
+}
+
+// loginURL сохраняет состояние авторизации и формирует URL для авторизации.
+// Если nonce не пустой, то он добавляется в запрос и сохраняется в состоянии.
+func (p base) loginURL(ctx context.Context, redirectURI string,
+	params map[string]string, data interface{}, nonce string) (string, error) {
+	// проверяем, что адрес для возврата разрешен
+	if !p.redirectURLs.Match(redirectURI) {
+		return "", ErrRedirectURI
+	}
+	// проверяем, что дополнительные параметры не подменяют формируемые
+	if err := checkParams(params); err != nil {
+		return "", err
+	}
+	// формируем code_verifier для этой авторизации
+	var verifier = pkceVerifier()
+	// формируем список дополнительных параметров запроса
+	var opts = make([]oauth2.AuthCodeOption, 0, len(p.params)+len(params)+3)
+	for k, v := range p.params {
+		if _, ok := params[k]; !ok {
+			opts = append(opts, oauth2.SetAuthURLParam(k, v))
+		}
+	}
+	for k, v := range params {
+		opts = append(opts, oauth2.SetAuthURLParam(k, v))
+	}
+	if nonce != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+	opts = append(opts,
+		oauth2.SetAuthURLParam("code_challenge", pkceChallenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"))
+	// сохраняем объект в сессии и получаем сессионный ключ
+	// этот ключ одновременно служит для защиты авторизационной сессии
+	var state = randomToken(StateLength)
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	err = p.states.Save(ctx, state, State{
+		Created:     time.Now(),
+		RedirectURI: redirectURI,
+		Nonce:       nonce,
+		Verifier:    verifier,
+		Data:        rawData,
+	})
+	if err != nil {
+		return "", err
+	}
+	// копируем конфигурацию для авторизации и добавляем адрес для редиректа
+	var cfg = p.oauth2Config
+	cfg.RedirectURL = redirectURI
+	// формируем адрес для авторизации пользователя и перенаправляем на него
+	return cfg.AuthCodeURL(state, opts...), nil
+}
+
+// exchange проверяет и удаляет состояние авторизации, после чего обменивает
+// код авторизации на токен OAuth2.
+func (p base) exchange(ctx context.Context, state, code string) (*State, *oauth2.Token, error) {
+	// проверяем сессионный ключ и получаем данные о сессии
+	stateObj, err := p.states.Take(ctx, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	// повторно проверяем адрес для возврата, т.к. список мог измениться
+	if !p.redirectURLs.Match(stateObj.RedirectURI) {
+		return nil, nil, ErrRedirectURI
+	}
+	// копируем конфигурацию для авторизации и добавляем адрес для редиректа
+	cfg, err := p.config()
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg.RedirectURL = stateObj.RedirectURI
+	// получаем токен авторизации OAuth2, подтверждая его code_verifier
+	var opts []oauth2.AuthCodeOption
+	if stateObj.Verifier != "" {
+		opts = append(opts,
+			oauth2.SetAuthURLParam("code_verifier", stateObj.Verifier))
+	}
+	oauth2Token, err := cfg.Exchange(ctx, code, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error receiving authorization token: %w", err)
+	}
+	return stateObj, oauth2Token, nil
+}
@@ -0,0 +1,54 @@
+package openid
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestLoginURLReservedParams(t *testing.T) {
+	var cfg = Config{
+		Type:         TypeOAuth2,
+		Name:         "test",
+		СlientID:     "client",
+		Secret:       "secret",
+		AuthURL:      "https://auth.example.com/authorize",
+		TokenURL:     "https://auth.example.com/token",
+		UserInfoURL:  "https://auth.example.com/user",
+		RedirectURLs: RedirectURIs{"https://app.example.com/callback"},
+	}
+	provider, err := NewOAuth2(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const redirectURI = "https://app.example.com/callback"
+	for _, name := range []string{"redirect_uri", "state", "client_id",
+		"response_type", "scope", "nonce", "code_challenge",
+		"code_challenge_method", "Redirect_URI"} {
+		_, err := provider.LoginURL(context.Background(), redirectURI,
+			map[string]string{name: "https://evil.example.com/"}, nil)
+		if !errors.Is(err, ErrReservedParam) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+	// остальные параметры передаются провайдеру
+	loginURL, err := provider.LoginURL(context.Background(), redirectURI,
+		map[string]string{"prompt": "login"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var query = parsed.Query()
+	if query.Get("prompt") != "login" || query.Get("redirect_uri") != redirectURI {
+		t.Errorf("unexpected login url: %s", loginURL)
+	}
+	// зарезервированные параметры нельзя задать и в конфигурации
+	cfg.Params = map[string]string{"redirect_uri": "https://evil.example.com/"}
+	if _, err = NewOAuth2(cfg); !errors.Is(err, ErrReservedParam) {
+		t.Errorf("config: unexpected error: %v", err)
+	}
+}
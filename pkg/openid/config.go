@@ -2,19 +2,43 @@ package openid
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Поддерживаемые типы провайдеров авторизации.
+const (
+	TypeOIDC   = "oidc"   // OpenID Connect
+	TypeOAuth2 = "oauth2" // OAuth2 с запросом профиля пользователя
+)
+
+// Варианты доверия к подтверждению email-адреса провайдером.
+const (
+	TrustProvider = "provider" // используется флаг, полученный от провайдера
+	TrustAlways   = "always"   // адрес всегда считается подтвержденным
+	TrustNever    = "never"    // адрес всегда считается неподтвержденным
+)
+
+// Поддерживаемые способы возврата ответа провайдера авторизации.
+const (
+	ResponseQuery    = "query"     // параметры адреса для возврата
+	ResponseFormPost = "form_post" // POST-запрос на адрес для возврата
 )
 
 // Настройки, используемые для формирования и проверки случайной строки сессии
 // авторизации и nonce.
 var (
 	StateLength = 48               // длина сессионой строки (рекомендуется не меньше 30)
+	NonceLength = 32               // длина nonce, создаваемого для каждой авторизации
 	StateTTL    = time.Minute * 30 // время жизни сессии авторизации
-	// строка с идентификацией сервера (сервиса), используемая при создании
-	// токена авторизации внешним сервером авторизации
-	ServerNonce = randomToken(8)
 )
 
 // randomToken generates a random @length length token.
@@ -24,7 +48,19 @@ func randomToken(length int) string {
 	if err != nil {
 		panic(fmt.Errorf("random string generation error: %w", err))
 	}
-	return base64.StdEncoding.EncodeToString(bytes)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// pkceVerifier возвращает случайный code_verifier для PKCE (RFC 7636).
+// Длина 43 символа соответствует минимально допустимой и 32 случайным байтам.
+func pkceVerifier() string {
+	return randomToken(43)
+}
+
+// pkceChallenge возвращает code_challenge для метода S256 (RFC 7636).
+func pkceChallenge(verifier string) string {
+	var sum = sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
 
 // Config описывает информацию для конфигурации OpenID Connect авторизации.
@@ -41,10 +77,104 @@ func randomToken(length int) string {
 //
 // RedirectURLs задает список зарегистрированных URL для возврата
 // авторизационной информации. Если задан, то перед генерацией url для
-// авторизации проверяется, что он находится в списке.
+// авторизации проверяется, что он находится в списке. Формат описан в
+// RedirectURIs.
+//
+// Title задает отображаемое название провайдера, например, для кнопки входа.
+// Если не задано, то используется Name.
+//
+// Scopes задает запрашиваемые области доступа. Если для OpenID Connect не
+// задано, то запрашиваются "openid", "profile" и "email".
+//
+// Params задает дополнительные параметры, добавляемые к каждому запросу
+// авторизации. Параметры из запроса имеют приоритет над ними.
+//
+// Type задает протокол провайдера: "oidc" (по умолчанию) или "oauth2". Для
+// провайдеров OAuth2, не выдающих токен идентификации, вместо URL задаются
+// адреса AuthURL, TokenURL и UserInfoURL, а информация о пользователе
+// заполняется из профиля по правилам Mapping. TokenParam задает название
+// параметра для передачи токена в адресе запроса профиля, если провайдер не
+// принимает его в заголовке.
+//
+// TrustEmail задает, насколько доверять подтверждению email-адреса
+// провайдером: "provider" (по умолчанию) использует флаг email_verified,
+// полученный от провайдера, "always" считает любой адрес подтвержденным, а
+// "never" — неподтвержденным. Пользователь с неподтвержденным адресом не
+// привязывается автоматически к уже зарегистрированному пользователю с тем
+// же email.
+//
+// Propagate задает список данных профиля (например, "name", "picture",
+// "locale"), которые при каждой авторизации переносятся в расширенные
+// свойства пользователя. Значение "*" переносит все данные профиля. Если
+// не задано, то свойства пользователя заполняются только при регистрации.
+//
+// StoreTokens разрешает сохранять токены доступа и обновления провайдера
+// для последующих обращений к его API от имени пользователя.
+//
+// ResponseMode задает способ возврата ответа провайдера: "query" (по
+// умолчанию) или "form_post", когда провайдер отправляет state и code
+// POST-запросом на адрес для возврата (например, Sign in with Apple).
+//
+// ClientKey задает закрытый ключ для формирования секретного ключа клиента
+// в виде подписанного JWT вместо статического Secret.
+//
+// UserMapping задает правила заполнения информации о пользователе из
+// данных, которые провайдер передает только при первой авторизации
+// (например, параметр user от Apple). Если не заданы, то используются
+// стандартные поля профиля OpenID Connect. Эти данные не подписаны
+// провайдером, поэтому из них берутся только имя и аватар, а правила для
+// идентификатора, email-адреса и флага его подтверждения игнорируются.
+//
+// States задает хранилище состояний авторизации. Если не задано, то
+// используется хранилище в памяти процесса.
 type Config struct {
-	Name     string // Название провайдера
-	URL      string // URL сервиса авторизации
-	СlientID string // иденитфикатор клиента
-	Secret   string // секретный код для подтверждения клиента
+	Type         string            `yaml:"type"`          // Протокол провайдера
+	Name         string            `yaml:"name"`          // Название провайдера
+	Title        string            `yaml:"title"`         // Отображаемое название
+	URL          string            `yaml:"url"`           // URL сервиса авторизации
+	СlientID     string            `yaml:"client_id"`     // иденитфикатор клиента
+	Secret       string            `yaml:"secret"`        // секретный код для подтверждения клиента
+	Scopes       []string          `yaml:"scopes"`        // запрашиваемые области доступа
+	Params       map[string]string `yaml:"params"`        // дополнительные параметры авторизации
+	RedirectURLs RedirectURIs      `yaml:"redirect_uris"` // допустимые адреса для возврата после авторизации
+	AuthURL      string            `yaml:"auth_url"`      // адрес для авторизации OAuth2
+	TokenURL     string            `yaml:"token_url"`     // адрес для получения токена OAuth2
+	UserInfoURL  string            `yaml:"userinfo_url"`  // адрес для запроса профиля пользователя OAuth2
+	TokenParam   string            `yaml:"token_param"`   // параметр для передачи токена в запросе профиля
+	Mapping      Mapping           `yaml:"mapping"`       // правила заполнения информации о пользователе
+	TrustEmail   string            `yaml:"trust_email"`   // доверие к подтверждению email провайдером
+	Propagate    []string          `yaml:"propagate"`     // данные профиля для свойств пользователя
+	StoreTokens  bool              `yaml:"store_tokens"`  // сохранять токены провайдера
+	ResponseMode string            `yaml:"response_mode"` // способ возврата ответа провайдера
+	ClientKey    *ClientKey        `yaml:"client_key"`    // ключ для секретного ключа клиента в виде JWT
+	UserMapping  Mapping           `yaml:"user_mapping"`  // правила для данных первой авторизации
+	States       StateStore        `yaml:"-"`             // хранилище состояний авторизации
+}
+
+// LoadConfig загружает из файла в формате yaml список конфигураций
+// провайдеров авторизации. Перед разбором в содержимом файла подставляются
+// значения переменных окружения в виде $NAME или ${NAME}, что позволяет не
+// хранить секретные ключи в самом файле.
+func LoadConfig(filename string) ([]Config, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	var list []Config
+	err = yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &list)
+	if err != nil {
+		return nil, err
+	}
+	// проверяем, что названия провайдеров заданы и не повторяются
+	var names = make(map[string]bool, len(list))
+	for _, cfg := range list {
+		if cfg.Name == "" {
+			return nil, errors.New("provider name is empty")
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("duplicate provider name: %s", cfg.Name)
+		}
+		names[cfg.Name] = true
+	}
+	return list, nil
 }
@@ -6,12 +6,18 @@ var GoogleProviderName = "google"
 
 // NewGoogle возвращает инициализированный Auth для работы с Google OpenID
 // Connect. Вызывает панику, если сервер https://accounts.google.com не доступен.
-func NewGoogle(clientID, secret string) (*Provider, error) {
+// Если states не задан, то состояния авторизации хранятся в памяти.
+// redirectURLs задает список допустимых адресов для возврата после
+// авторизации; пустой список допускает любой адрес.
+func NewGoogle(clientID, secret string, redirectURLs RedirectURIs,
+	states StateStore) (*Provider, error) {
 	provider, err := New(Config{
-		Name:     GoogleProviderName,
-		URL:      "https://accounts.google.com",
-		СlientID: clientID,
-		Secret:   secret,
+		Name:         GoogleProviderName,
+		URL:          "https://accounts.google.com",
+		СlientID:     clientID,
+		Secret:       secret,
+		RedirectURLs: redirectURLs,
+		States:       states,
 	})
 	if err != nil {
 		return nil, err
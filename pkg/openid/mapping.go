@@ -0,0 +1,176 @@
+package openid
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Mapping задает правила заполнения информации о пользователе из профиля,
+// полученного от провайдера OAuth2.
+//
+// Каждое правило — путь к значению в JSON профиля, где имена полей и индексы
+// массивов разделяются точкой, например "response.0.id". Путь, начинающийся
+// с "token.", указывает на дополнительное поле ответа с токеном авторизации:
+// так, например, VK возвращает email пользователя. Пустое правило оставляет
+// поле незаполненным.
+//
+// Значение для Verified считается истинным, если это true, "true" или
+// ненулевое число. Правило для Verified можно так же задать константой
+// "=true", но только если провайдер гарантированно возвращает
+// подтвержденные адреса. Подтвержденный адрес автоматически привязывает
+// учетную запись провайдера к уже зарегистрированному пользователю с тем же
+// email: если провайдер позволяет указать в профиле чужой адрес, то его
+// владелец получит доступ к учетной записи пользователя. Без правила адрес
+// считается неподтвержденным, и привязку подтверждает владелец учетной
+// записи.
+//
+// Если полное имя не задано, то оно составляется из имени и фамилии.
+type Mapping struct {
+	Subject    string `yaml:"subject"`     // уникальный идентификатор пользователя
+	Email      string `yaml:"email"`       // email-адрес
+	Verified   string `yaml:"verified"`    // флаг, что email-адрес подтвержден
+	Name       string `yaml:"name"`        // полное отображаемое имя
+	GivenName  string `yaml:"given_name"`  // имя
+	FamilyName string `yaml:"family_name"` // фамилия
+	Picture    string `yaml:"picture"`     // ссылка на аватар
+}
+
+// DefaultMapping используется, если правила не заданы, и соответствует
+// стандартным полям профиля OpenID Connect.
+var DefaultMapping = Mapping{
+	Subject:    "sub",
+	Email:      "email",
+	Verified:   "email_verified",
+	Name:       "name",
+	GivenName:  "given_name",
+	FamilyName: "family_name",
+	Picture:    "picture",
+}
+
+// mappedValue возвращает значение по правилу из профиля или токена авторизации.
+func mappedValue(path string, profile interface{}, token *oauth2.Token) interface{} {
+	if path == "" {
+		return nil
+	}
+	if strings.HasPrefix(path, "=") {
+		return path[1:] // константа
+	}
+	if strings.HasPrefix(path, "token.") {
+		if token == nil {
+			return nil
+		}
+		return lookup(token.Extra(strings.TrimPrefix(path, "token.")), "")
+	}
+	return lookup(profile, path)
+}
+
+// apply заполняет информацию о пользователе по правилам из профиля и токена
+// авторизации.
+func (m Mapping) apply(userInfo *UserInfo, profile interface{}, token *oauth2.Token) error {
+	userInfo.Subject = toString(mappedValue(m.Subject, profile, token))
+	if userInfo.Subject == "" {
+		return ErrMissingSubject
+	}
+	userInfo.Email = toString(mappedValue(m.Email, profile, token))
+	userInfo.Verified = toBool(mappedValue(m.Verified, profile, token))
+	userInfo.Name = toString(mappedValue(m.Name, profile, token))
+	userInfo.GivenName = toString(mappedValue(m.GivenName, profile, token))
+	userInfo.FamilyName = toString(mappedValue(m.FamilyName, profile, token))
+	userInfo.Picture = toString(mappedValue(m.Picture, profile, token))
+	userInfo.fullName()
+	return nil
+}
+
+// complete заполняет только пустые поля имени и аватара в информации о
+// пользователе и возвращает список названий заполненных данных профиля.
+// Идентификатор, email-адрес и флаг его подтверждения не заполняются
+// никогда: неподписанные данные не должны влиять на регистрацию и привязку
+// пользователя.
+func (m Mapping) complete(userInfo *UserInfo, profile interface{}) []string {
+	var names []string
+	for _, field := range []struct {
+		name, rule string
+		value      *string
+	}{
+		{"name", m.Name, &userInfo.Name},
+		{"given_name", m.GivenName, &userInfo.GivenName},
+		{"family_name", m.FamilyName, &userInfo.FamilyName},
+		{"picture", m.Picture, &userInfo.Picture},
+	} {
+		if *field.value != "" {
+			continue
+		}
+		if *field.value = toString(mappedValue(field.rule, profile, nil)); *field.value != "" {
+			names = append(names, field.name)
+		}
+	}
+	if userInfo.Name == "" && userInfo.fullName() {
+		names = append(names, "name")
+	}
+	return names
+}
+
+// lookup возвращает значение по пути, разделенному точками. Если значение не
+// найдено, то возвращается nil.
+func lookup(data interface{}, path string) interface{} {
+	if path == "" {
+		return data
+	}
+	var parts = strings.Split(path, ".")
+	for _, name := range parts {
+		switch obj := data.(type) {
+		case map[string]interface{}:
+			data = obj[name]
+		case []interface{}:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(obj) {
+				return nil
+			}
+			data = obj[i]
+		default:
+			return nil
+		}
+	}
+	return data
+}
+
+// toString возвращает строковое представление значения. Числа
+// преобразуются в строку без экспоненты.
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toBool возвращает true для true, "true", "1" или ненулевого числа.
+func toBool(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	case json.Number:
+		f, _ := v.Float64()
+		return f != 0
+	case float64:
+		return v != 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,39 @@
+package openid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFirstLoginIgnoresEmail(t *testing.T) {
+	provider, err := NewOAuth2(Config{
+		Type:        TypeOAuth2,
+		Name:        "test",
+		СlientID:    "client",
+		Secret:      "secret",
+		AuthURL:     "https://auth.example.com/authorize",
+		TokenURL:    "https://auth.example.com/token",
+		UserInfoURL: "https://auth.example.com/user",
+		UserMapping: Mapping{
+			Email:      "email",
+			Verified:   "=true",
+			GivenName:  "name.firstName",
+			FamilyName: "name.lastName",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var userInfo = &UserInfo{Subject: "1"}
+	names := provider.FirstLogin(userInfo, []byte(`{"email":"victim@example.com",
+		"name":{"firstName":"John","lastName":"Doe"}}`))
+	if userInfo.Email != "" || userInfo.Verified {
+		t.Errorf("unsigned email used: %q, %v", userInfo.Email, userInfo.Verified)
+	}
+	if userInfo.Name != "John Doe" {
+		t.Errorf("unexpected name: %q", userInfo.Name)
+	}
+	if want := []string{"given_name", "family_name", "name"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
@@ -0,0 +1,144 @@
+package openid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// OAuth2 отвечает за обработку запросов авторизации пользователя через
+// внешние системы, которые поддерживают только OAuth2 и не выдают токен
+// идентификации, например GitHub, VK или Facebook.
+//
+// После получения токена авторизации запрашивается профиль пользователя по
+// адресу UserInfoURL, а поля профиля переносятся в информацию о пользователе
+// в соответствии с правилами Mapping.
+type OAuth2 struct {
+	base
+	userInfoURL string  // адрес для запроса профиля пользователя
+	tokenParam  string  // параметр для передачи токена в адресе запроса
+	mapping     Mapping // правила заполнения информации о пользователе
+}
+
+// NewOAuth2 инициализирует и возвращает обработчик авторизации по протоколу
+// OAuth2. В конфигурации должны быть заданы адреса AuthURL, TokenURL и
+// UserInfoURL.
+func NewOAuth2(cfg Config) (*OAuth2, error) {
+	// проверяем, что заданы ключи для инициализации провайдера
+	if cfg.СlientID == "" || (cfg.Secret == "" && cfg.ClientKey == nil) {
+		return nil, ErrMissingProviderKeys
+	}
+	if cfg.AuthURL == "" || cfg.TokenURL == "" || cfg.UserInfoURL == "" {
+		return nil, ErrMissingEndpoints
+	}
+	var mapping = cfg.Mapping
+	if mapping == (Mapping{}) {
+		mapping = DefaultMapping
+	}
+	var oauth2Config = oauth2.Config{
+		ClientID:     cfg.СlientID,
+		ClientSecret: cfg.Secret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  cfg.AuthURL,
+			TokenURL: cfg.TokenURL,
+		},
+		Scopes: cfg.Scopes,
+	}
+	common, err := newBase(cfg, oauth2Config)
+	if err != nil {
+		return nil, err
+	}
+	var auth = &OAuth2{
+		base:        common,
+		userInfoURL: cfg.UserInfoURL,
+		tokenParam:  cfg.TokenParam,
+		mapping:     mapping,
+	}
+	return auth, nil
+}
+
+// LoginURL формирует и возвращает уникальный URL для авторизации пользователя
+// через провайдера авторизации. Параметры аналогичны Provider.LoginURL, но
+// nonce не используется.
+func (p *OAuth2) LoginURL(ctx context.Context, redirectURI string,
+	params map[string]string, data interface{}) (string, error) {
+	return p.loginURL(ctx, redirectURI, params, data, "")
+}
+
+// UserInfo получает токен авторизации, запрашивает профиль пользователя и
+// формирует на его основании информацию о пользователе.
+//
+// Так же вторым значением возвращается сохраненное в сессии значение
+// вспомогательных данных в формате JSON.
+func (p *OAuth2) UserInfo(ctx context.Context, state, code string) (*UserInfo, json.RawMessage, error) {
+	// проверяем состояние авторизации и получаем токен авторизации OAuth2
+	stateObj, oauth2Token, err := p.exchange(ctx, state, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	// запрашиваем профиль пользователя
+	profile, err := p.profile(ctx, oauth2Token)
+	if err != nil {
+		return nil, nil, err
+	}
+	// формируем информацию о пользователе
+	var userInfo = &UserInfo{
+		Issuer:      p.name,
+		RedirectURI: stateObj.RedirectURI,
+		oauth2Token: oauth2Token,
+		data:        stateObj.Data,
+	}
+	if err = p.mapping.apply(userInfo, profile, oauth2Token); err != nil {
+		return nil, nil, err
+	}
+	p.trust(userInfo)
+	return userInfo, stateObj.Data, nil
+}
+
+// profile запрашивает профиль пользователя и возвращает его в разобранном
+// виде.
+func (p *OAuth2) profile(ctx context.Context, token *oauth2.Token) (interface{}, error) {
+	var client = p.oauth2Config.Client(ctx, token)
+	var profileURL = p.userInfoURL
+	// некоторые провайдеры принимают токен только в параметрах запроса
+	if p.tokenParam != "" {
+		u, err := url.Parse(profileURL)
+		if err != nil {
+			return nil, fmt.Errorf("user profile url error: %w", err)
+		}
+		var query = u.Query()
+		query.Set(p.tokenParam, token.AccessToken)
+		u.RawQuery = query.Encode()
+		profileURL = u.String()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, profileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user profile: %w", err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error getting user profile: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting user profile: %s", resp.Status)
+	}
+	var profile interface{}
+	var dec = json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber() // сохраняем большие числовые идентификаторы без потерь
+	if err = dec.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("error parsing user profile: %w", err)
+	}
+	return profile, nil
+}
@@ -0,0 +1,431 @@
+// Package openidtest описывает сервер авторизации OpenID Connect для
+// локальной разработки и тестирования.
+//
+// Сервер публикует описание сервисов (/.well-known/openid-configuration),
+// открытые ключи (JWKS) и поддерживает авторизацию по коду с PKCE, выдачу и
+// обновление токенов и запрос профиля пользователя. Авторизация выполняется
+// без участия пользователя: сервер сразу возвращает код авторизации для
+// пользователя, указанного в параметре login_hint (email или идентификатор),
+// или для пользователя по умолчанию. Для неизвестного email в login_hint
+// пользователь создается автоматически с неподтвержденным адресом. При response_mode=form_post ответ
+// отправляется POST-запросом на адрес для возврата.
+package openidtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"itube/users/pkg/openid"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// Настройки сервера, используемые по умолчанию.
+var (
+	ClientID = "test-client"   // идентификатор клиента
+	Secret   = "test-secret"   // секретный ключ клиента
+	TokenTTL = time.Hour       // время жизни токенов доступа
+	CodeTTL  = time.Minute * 5 // время жизни кода авторизации
+	KeyBits  = 2048            // размер ключа RSA для подписи токенов
+	Default  = Identity{       // пользователь по умолчанию
+		Subject:  "1",
+		Email:    "user@example.com",
+		Verified: true,
+		Name:     "Test User",
+	}
+)
+
+// Identity описывает пользователя, от имени которого сервер выдает токены.
+type Identity struct {
+	Subject  string                 // уникальный идентификатор
+	Email    string                 // email-адрес
+	Verified bool                   // флаг, что email-адрес подтвержден
+	Name     string                 // полное отображаемое имя
+	Picture  string                 // ссылка на аватар
+	Claims   map[string]interface{} // дополнительные данные профиля
+}
+
+// claims возвращает данные профиля пользователя.
+func (i Identity) claims() map[string]interface{} {
+	var claims = make(map[string]interface{}, len(i.Claims)+5)
+	for k, v := range i.Claims {
+		claims[k] = v
+	}
+	claims["sub"] = i.Subject
+	if i.Email != "" {
+		claims["email"] = i.Email
+		claims["email_verified"] = i.Verified
+	}
+	if i.Name != "" {
+		claims["name"] = i.Name
+	}
+	if i.Picture != "" {
+		claims["picture"] = i.Picture
+	}
+	return claims
+}
+
+// grant описывает выданный код авторизации.
+type grant struct {
+	identity    Identity  // пользователь
+	redirectURI string    // адрес для возврата
+	nonce       string    // nonce из запроса авторизации
+	challenge   string    // code_challenge из запроса авторизации
+	expires     time.Time // время окончания действия кода
+}
+
+// Server описывает запущенный сервер авторизации OpenID Connect.
+type Server struct {
+	URL      string // адрес сервера и идентификатор (issuer)
+	ClientID string // идентификатор клиента
+	Secret   string // секретный ключ клиента
+
+	server     *httptest.Server
+	signer     jose.Signer
+	keys       jose.JSONWebKeySet
+	mu         sync.Mutex
+	identities []Identity          // зарегистрированные пользователи
+	codes      map[string]grant    // выданные коды авторизации
+	tokens     map[string]Identity // выданные токены доступа и обновления
+}
+
+// NewServer запускает сервер авторизации на локальном адресе. Первый из
+// переданных пользователей используется по умолчанию; если пользователи не
+// заданы, то используется Default. Сервер необходимо остановить через Close.
+func NewServer(identities ...Identity) *Server {
+	key, err := rsa.GenerateKey(rand.Reader, KeyBits)
+	if err != nil {
+		panic("openidtest: key generation error: " + err.Error())
+	}
+	var public = jose.JSONWebKey{
+		Key:       key.Public(),
+		KeyID:     "test",
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.RS256,
+			Key:       jose.JSONWebKey{Key: key, KeyID: public.KeyID},
+		},
+		new(jose.SignerOptions).WithType("JWT"))
+	if err != nil {
+		panic("openidtest: signer initialization error: " + err.Error())
+	}
+	if len(identities) == 0 {
+		identities = []Identity{Default}
+	}
+	var s = &Server{
+		ClientID:   ClientID,
+		Secret:     Secret,
+		signer:     signer,
+		keys:       jose.JSONWebKeySet{Keys: []jose.JSONWebKey{public}},
+		identities: identities,
+		codes:      make(map[string]grant),
+		tokens:     make(map[string]Identity),
+	}
+	var mux = http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/userinfo", s.userinfo)
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close останавливает сервер.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddIdentity регистрирует пользователя. Пользователь с тем же
+// идентификатором заменяется.
+func (s *Server) AddIdentity(identity Identity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, item := range s.identities {
+		if item.Subject == identity.Subject {
+			s.identities[i] = identity
+			return
+		}
+	}
+	s.identities = append(s.identities, identity)
+}
+
+// Config возвращает конфигурацию провайдера авторизации для этого сервера с
+// указанным названием.
+func (s *Server) Config(name string) openid.Config {
+	return openid.Config{
+		Name:     name,
+		URL:      s.URL,
+		СlientID: s.ClientID,
+		Secret:   s.Secret,
+	}
+}
+
+// identity возвращает пользователя по email или идентификатору. Если hint
+// не задан, то возвращается пользователь по умолчанию. Для неизвестного
+// email пользователь создается, но адрес не считается подтвержденным: его
+// владение ничем не проверено.
+func (s *Server) identity(hint string) (Identity, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hint == "" {
+		return s.identities[0], true
+	}
+	for _, identity := range s.identities {
+		if identity.Subject == hint || strings.EqualFold(identity.Email, hint) {
+			return identity, true
+		}
+	}
+	if !strings.Contains(hint, "@") {
+		return Identity{}, false
+	}
+	var sum = sha256.Sum256([]byte(strings.ToLower(hint)))
+	var identity = Identity{
+		Subject: hex.EncodeToString(sum[:8]),
+		Email:   hint,
+		Name:    hint[:strings.IndexByte(hint, '@')],
+	}
+	s.identities = append(s.identities, identity)
+	return identity, true
+}
+
+// discovery возвращает описание сервисов сервера.
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"userinfo_endpoint":                     s.URL + "/userinfo",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{string(jose.RS256)},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "profile", "email"},
+	})
+}
+
+// jwks возвращает открытые ключи для проверки подписи токенов.
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.keys)
+}
+
+// authorize сразу перенаправляет на адрес для возврата с кодом авторизации.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	var query = r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if query.Get("client_id") != s.ClientID {
+		http.Error(w, "invalid client_id", http.StatusBadRequest)
+		return
+	}
+	var params = redirectURI.Query()
+	params.Set("state", query.Get("state"))
+	identity, ok := s.identity(query.Get("login_hint"))
+	switch {
+	case query.Get("response_type") != "code":
+		params.Set("error", "unsupported_response_type")
+	case !ok:
+		params.Set("error", "access_denied")
+	default:
+		var code = random()
+		s.mu.Lock()
+		s.codes[code] = grant{
+			identity:    identity,
+			redirectURI: query.Get("redirect_uri"),
+			nonce:       query.Get("nonce"),
+			challenge:   query.Get("code_challenge"),
+			expires:     time.Now().Add(CodeTTL),
+		}
+		s.mu.Unlock()
+		params.Set("code", code)
+	}
+	if query.Get("response_mode") == "form_post" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = formPost.Execute(w, struct {
+			URL    string
+			Params url.Values
+		}{redirectURI.String(), params})
+		return
+	}
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// formPost задает страницу, отправляющую ответ POST-запросом на адрес для
+// возврата (response_mode=form_post).
+var formPost = template.Must(template.New("form_post").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.URL}}">
+{{range $name, $values := .Params}}{{range $values}}<input type="hidden" name="{{$name}}" value="{{.}}">
+{{end}}{{end}}<noscript><button type="submit">Continue</button></noscript>
+</form>
+</body>
+</html>
+`))
+
+// token выдает токены по коду авторизации или токену обновления.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+	// проверяем клиента: в заголовке или в параметрах запроса
+	clientID, secret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.ClientID || secret != s.Secret {
+		w.Header().Set("WWW-Authenticate", "Basic")
+		writeJSON(w, http.StatusUnauthorized,
+			map[string]string{"error": "invalid_client"})
+		return
+	}
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		var code = r.PostForm.Get("code")
+		s.mu.Lock()
+		g, ok := s.codes[code]
+		delete(s.codes, code)
+		s.mu.Unlock()
+		if !ok || time.Now().After(g.expires) ||
+			g.redirectURI != r.PostForm.Get("redirect_uri") ||
+			!verifyPKCE(g.challenge, r.PostForm.Get("code_verifier")) {
+			tokenError(w, "invalid_grant")
+			return
+		}
+		idToken, err := s.idToken(g.identity, g.nonce)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.issue(w, g.identity, idToken)
+	case "refresh_token":
+		var refresh = r.PostForm.Get("refresh_token")
+		s.mu.Lock()
+		identity, ok := s.tokens["refresh:"+refresh]
+		delete(s.tokens, "refresh:"+refresh)
+		s.mu.Unlock()
+		if !ok {
+			tokenError(w, "invalid_grant")
+			return
+		}
+		s.issue(w, identity, "")
+	default:
+		tokenError(w, "unsupported_grant_type")
+	}
+}
+
+// issue выдает новые токены доступа и обновления.
+func (s *Server) issue(w http.ResponseWriter, identity Identity, idToken string) {
+	var access, refresh = random(), random()
+	s.mu.Lock()
+	s.tokens["access:"+access] = identity
+	s.tokens["refresh:"+refresh] = identity
+	s.mu.Unlock()
+	var response = map[string]interface{}{
+		"access_token":  access,
+		"token_type":    "Bearer",
+		"expires_in":    int(TokenTTL / time.Second),
+		"refresh_token": refresh,
+	}
+	if idToken != "" {
+		response["id_token"] = idToken
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, response)
+}
+
+// idToken формирует подписанный токен идентификации.
+func (s *Server) idToken(identity Identity, nonce string) (string, error) {
+	var now = time.Now()
+	var claims = identity.claims()
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	return jwt.Signed(s.signer).
+		Claims(jwt.Claims{
+			Issuer:   s.URL,
+			Subject:  identity.Subject,
+			Audience: jwt.Audience{s.ClientID},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(TokenTTL)),
+		}).
+		Claims(claims).
+		CompactSerialize()
+}
+
+// userinfo возвращает профиль пользователя по токену доступа.
+func (s *Server) userinfo(w http.ResponseWriter, r *http.Request) {
+	var auth = r.Header.Get("Authorization")
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "bearer ") {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "missing access token", http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	identity, ok := s.tokens["access:"+auth[7:]]
+	s.mu.Unlock()
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, "invalid access token", http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, identity.claims())
+}
+
+// verifyPKCE проверяет code_verifier для метода S256. Если code_challenge
+// не задан, то проверка не выполняется.
+func verifyPKCE(challenge, verifier string) bool {
+	if challenge == "" {
+		return true
+	}
+	var sum = sha256.Sum256([]byte(verifier))
+	var expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// random возвращает случайную строку для кодов и токенов.
+func random() string {
+	var b = make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic("openidtest: random error: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// tokenError возвращает ошибку запроса токена.
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+// writeJSON отправляет ответ в формате JSON.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -2,9 +2,10 @@ package openid
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/coreos/go-oidc"
 	"golang.org/x/oauth2"
@@ -13,15 +14,8 @@ import (
 // Provider отвечает за обработку запросов авторизации пользователя через
 // внешние системы по протоколу OpenID Connect.
 type Provider struct {
-	name         string         // название
-	provider     *oidc.Provider // провайдер авторизации
-	oauth2Config oauth2.Config  // конфигурация OAuth2
-	states       *states        // хранение списка nonce для авторизации
-}
-
-// String возвращает идетификатор провайдера (название).
-func (p Provider) String() string {
-	return p.name
+	base
+	provider *oidc.Provider // провайдер авторизации
 }
 
 // New инициализирует и возвращает обработчик авторизации по протоколу
@@ -29,7 +23,7 @@ func (p Provider) String() string {
 // поддерживаемых сервисов провайдера.
 func New(cfg Config) (*Provider, error) {
 	// проверяем, что заданы ключи для инициализации провайдера
-	if cfg.СlientID == "" || cfg.Secret == "" {
+	if cfg.СlientID == "" || (cfg.Secret == "" && cfg.ClientKey == nil) {
 		return nil, ErrMissingProviderKeys
 	}
 	// инициализируем провайдера авторизации
@@ -43,35 +37,19 @@ func New(cfg Config) (*Provider, error) {
 		ClientID:     cfg.СlientID,
 		ClientSecret: cfg.Secret,
 		Endpoint:     provider.Endpoint(), // вычисляется после запроса сервисов провайдера
-		Scopes:       []string{oidc.ScopeOpenID, "profile", "email"},
+		Scopes:       cfg.Scopes,
+	}
+	if len(oauth2Config.Scopes) == 0 {
+		oauth2Config.Scopes = []string{oidc.ScopeOpenID, "profile", "email"}
 	}
-	// инициализируем хранилище случайных последовательностей, используемых
-	// при авторизации
-	var states = new(states)
-	// запускаем процесс автоматического удаления устаревших сессионных
-	// ключей для проверки авторизации
-	go func() {
-		for {
-			var before = time.Now().Add(-StateTTL) // время для проверки устаревания
-			states.Range(func(key string, value stateObj) bool {
-				// проверяем, что не "устарело"
-				if value.Created.Before(before) {
-					states.Delete(key) // удаляем устаревшую сессию
-				}
-				return true // переходим к проверке следующей сессии
-			})
-			// в принципе, нам не нужно слишком часто очищать этот список
-			// но пусть будет в качестве интервала очистки тот же интервал,
-			// что используется для задания времени жизни
-			time.Sleep(StateTTL) // на время прекращаем обработку
-		}
-	}()
 	// инициализируем наш обработчик авторизации
+	common, err := newBase(cfg, oauth2Config)
+	if err != nil {
+		return nil, err
+	}
 	var auth = &Provider{
-		name:         cfg.Name,
-		provider:     provider,
-		oauth2Config: oauth2Config,
-		states:       states,
+		base:     common,
+		provider: provider,
 	}
 	return auth, nil
 }
@@ -84,32 +62,26 @@ func New(cfg Config) (*Provider, error) {
 // с помощью параметра redirect_uri.
 //
 // params позволяют задать дополнительные именованны параметры, используем
-// при авторизации. Например: login_hint, hd, display
+// при авторизации. Например: login_hint, hd, display. Они дополняют и
+// заменяют параметры, заданные в конфигурации провайдера.
 // Подробнее о дополнительный параметрах можно прочитать:
 // https://developers.google.com/identity/protocols/oauth2/openid-connect#authenticationuriparameters
-func (p *Provider) LoginURL(redirectURI string, params map[string]string, data interface{}) string {
-	// формируем список дополнительных параметров запроса
-	var opts = make([]oauth2.AuthCodeOption, 0, len(params)+1)
-	for k, v := range params {
-		opts = append(opts, oauth2.SetAuthURLParam(k, v))
-	}
-	// добавляем идентификатор сервера
-	if ServerNonce != "" {
-		opts = append(opts, oidc.Nonce(ServerNonce))
-	}
-	// сохраняем объект в сессии и получаем сессионный ключ
-	// этот ключ одновременно служит для защиты авторизационной сессии
-	var state = randomToken(48)
-	p.states.Store(state, stateObj{
-		Created:     time.Now(),
-		RedirectURI: redirectURI,
-		Data:        data,
-	})
-	// копируем конфигурацию для авторизации и добавляем адрес для редиректа
-	var cfg = p.oauth2Config
-	cfg.RedirectURL = redirectURI
-	// формируем адрес для авторизации пользователя и перенаправляем на него
-	return cfg.AuthCodeURL(state, opts...)
+//
+// data сохраняется в состоянии авторизации в формате JSON и возвращается
+// после авторизации пользователя.
+//
+// Для каждой авторизации создается свой nonce, который затем проверяется в
+// токене идентификации, и code_verifier для PKCE (RFC 7636) с методом S256.
+// Оба значения сохраняются вместе с состоянием авторизации.
+//
+// Если для провайдера задан список допустимых адресов для возврата, то
+// для адреса не из списка возвращается ошибка ErrRedirectURI.
+// Дополнительные параметры не могут переопределять параметры, формируемые
+// самим провайдером (redirect_uri, state, nonce и т.д.): для них
+// возвращается ошибка ErrReservedParam.
+func (p *Provider) LoginURL(ctx context.Context, redirectURI string,
+	params map[string]string, data interface{}) (string, error) {
+	return p.loginURL(ctx, redirectURI, params, data, randomToken(NonceLength))
 }
 
 var (
@@ -123,29 +95,42 @@ var (
 	// ErrMissingIDToken возвращается, когда в полученном авторизационном ответе
 	// нет токена идентификации
 	ErrMissingIDToken = errors.New("missing identification token")
+	// ErrRedirectURI возвращается, если адрес для возврата после авторизации
+	// не входит в список допустимых
+	ErrRedirectURI = errors.New("redirect uri is not allowed")
+	// ErrReservedParam возвращается, если в дополнительных параметрах
+	// авторизации указан параметр, который формируется самим провайдером
+	ErrReservedParam = errors.New("reserved authorization parameter")
 	// ErrNonce возвращается, когда в идентификационном токене nonce не
-	// совпадает с заданным на сервере
+	// совпадает с созданным при начале авторизации
 	ErrNonce = errors.New("invalid identification token nonce")
+	// ErrMissingEndpoints возвращается, если для провайдера OAuth2 не заданы
+	// адреса для авторизации, получения токена или профиля пользователя
+	ErrMissingEndpoints = errors.New("missing provider auth, token or userinfo url")
+	// ErrMissingSubject возвращается, если в профиле пользователя, полученном
+	// от провайдера OAuth2, нет идентификатора пользователя
+	ErrMissingSubject = errors.New("missing user identifier in profile")
+	// ErrSubjectMismatch возвращается, если идентификатор пользователя в
+	// профиле не совпадает с идентификатором из токена идентификации
+	ErrSubjectMismatch = errors.New("user profile subject mismatch")
+	// ErrNoRefreshToken возвращается, если токен доступа нельзя обновить,
+	// т.к. провайдер не выдал токен обновления
+	ErrNoRefreshToken = errors.New("missing refresh token")
 )
 
 // UserInfo запрашивает идентификационный токен, проверяет его валидности и,
 // на основании полученных в нем данных, формирует информацию о пользователе.
 //
 // Так же вторым значением возвращается сохраненное в сессии значение
-// вспомогательных данных.
-func (p *Provider) UserInfo(ctx context.Context, state, code string) (*UserInfo, interface{}, error) {
-	// проверяем сессионный ключ и получаем данные о сессии
-	stateObj, ok := p.states.Load(state)
-	if !ok || stateObj.Created.Before(time.Now().Add(-StateTTL)) {
-		return nil, nil, ErrBadState
-	}
-	// копируем конфигурацию для авторизации и добавляем адрес для редиректа
-	var cfg = p.oauth2Config
-	cfg.RedirectURL = stateObj.RedirectURI
-	// получаем токен авторизации OAuth2
-	oauth2Token, err := cfg.Exchange(ctx, code)
+// вспомогательных данных в формате JSON.
+//
+// Состояние авторизации удаляется при первом же обращении, поэтому
+// повторная проверка с тем же state возвращает ErrBadState.
+func (p *Provider) UserInfo(ctx context.Context, state, code string) (*UserInfo, json.RawMessage, error) {
+	// проверяем состояние авторизации и получаем токен авторизации OAuth2
+	stateObj, oauth2Token, err := p.exchange(ctx, state, code)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error receiving authorization token: %w", err)
+		return nil, nil, err
 	}
 	// получаем идентификационный токен из OAuth2 токена
 	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
@@ -154,20 +139,22 @@ func (p *Provider) UserInfo(ctx context.Context, state, code string) (*UserInfo,
 	}
 	// проверяем полученный идентификационный токен
 	var verifier = p.provider.Verifier(&oidc.Config{
-		ClientID: cfg.ClientID,
+		ClientID: p.oauth2Config.ClientID,
 	})
 	idToken, err := verifier.Verify(ctx, rawIDToken)
 	if err != nil {
 		return nil, nil, fmt.Errorf("identification token verification error: %w", err)
 	}
-	// проверяем nonce, если он задан на сервере
-	if ServerNonce != "" && idToken.Nonce != ServerNonce {
+	// проверяем nonce, созданный при начале авторизации
+	if stateObj.Nonce == "" || subtle.ConstantTimeCompare(
+		[]byte(idToken.Nonce), []byte(stateObj.Nonce)) != 1 {
 		return nil, nil, ErrNonce
 	}
 	// формируем информацию о пользователе
 	var userInfo = &UserInfo{
 		Issuer:      p.name,
 		Subject:     idToken.Subject,
+		RedirectURI: stateObj.RedirectURI,
 		provider:    p.provider,
 		oauth2Token: oauth2Token,
 		data:        stateObj.Data,
@@ -176,5 +163,6 @@ func (p *Provider) UserInfo(ctx context.Context, state, code string) (*UserInfo,
 	if err = idToken.Claims(userInfo); err != nil {
 		return nil, nil, fmt.Errorf("identification token parsing error: %w", err)
 	}
+	p.trust(userInfo)
 	return userInfo, stateObj.Data, nil
 }
@@ -0,0 +1,124 @@
+package openid
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RedirectURIs описывает список допустимых адресов для возврата после
+// авторизации.
+//
+// Адрес в списке может быть задан точно или в виде шаблона, в котором
+// символ "*" заменяет одну метку имени хоста. Например,
+// "https://*.example.com/callback" допускает любой поддомен первого уровня
+// example.com, но не другую схему, порт, путь или домен. В других частях
+// адреса "*" не допускается. Адреса с "\", "@" или данными пользователя
+// не допускаются, т.к. браузеры могут разобрать их иначе.
+type RedirectURIs []string
+
+// Match возвращает true, если адрес соответствует одному из адресов в
+// списке. Пустой список допускает любой адрес.
+func (r RedirectURIs) Match(uri string) bool {
+	if len(r) == 0 {
+		return true
+	}
+	for _, pattern := range r {
+		if matchURI(pattern, uri) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchURI проверяет соответствие адреса шаблону.
+func matchURI(pattern, uri string) bool {
+	// не допускаем символов, которые браузеры и url.Parse разбирают по-разному
+	if strings.ContainsAny(uri, "\\@") {
+		return false
+	}
+	if !strings.Contains(pattern, "*") {
+		return pattern == uri // точное совпадение
+	}
+	p, err := url.Parse(pattern)
+	if err != nil || p.Opaque != "" || p.User != nil {
+		return false
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Opaque != "" || u.User != nil {
+		return false
+	}
+	// "*" допускается только в имени хоста
+	var host = p.Hostname()
+	if strings.Contains(strings.Replace(pattern, host, "", 1), "*") {
+		return false
+	}
+	// все, кроме имени хоста, должно совпадать точно
+	if !strings.EqualFold(p.Scheme, u.Scheme) || p.Port() != u.Port() ||
+		p.EscapedPath() != u.EscapedPath() || p.RawQuery != u.RawQuery ||
+		p.ForceQuery != u.ForceQuery || p.Fragment != u.Fragment {
+		return false
+	}
+	return matchHost(host, u.Hostname())
+}
+
+// matchHost проверяет соответствие имени хоста шаблону, в котором метка
+// "*" соответствует одной любой метке имени.
+func matchHost(pattern, host string) bool {
+	var patterns, labels = strings.Split(pattern, "."), strings.Split(host, ".")
+	if len(patterns) != len(labels) {
+		return false
+	}
+	for i, label := range labels {
+		if patterns[i] == "*" {
+			if !validLabel(label) {
+				return false
+			}
+			continue
+		}
+		if strings.Contains(patterns[i], "*") ||
+			!strings.EqualFold(patterns[i], label) {
+			return false
+		}
+	}
+	return true
+}
+
+// validLabel возвращает true, если строка является допустимой меткой имени
+// хоста: латинские буквы, цифры и дефис.
+func validLabel(label string) bool {
+	if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+			c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadRedirectURIs загружает из файла в формате yaml списки допустимых
+// адресов для возврата после авторизации, заданные для доменов.
+func LoadRedirectURIs(filename string) (map[string]RedirectURIs, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	var list = make(map[string]RedirectURIs)
+	err = yaml.NewDecoder(file).Decode(&list)
+	_ = file.Close()
+	if err != nil {
+		return nil, err
+	}
+	// имена доменов сравниваются без учета регистра
+	var result = make(map[string]RedirectURIs, len(list))
+	for domain, uris := range list {
+		result[strings.ToLower(domain)] = uris
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package openid
+
+import "testing"
+
+func TestRedirectURIsMatch(t *testing.T) {
+	var list = RedirectURIs{
+		"https://app.example.com/callback",
+		"https://*.example.com/cb",
+		"http://localhost:8080/cb?mode=dev",
+	}
+	for _, test := range []struct {
+		uri   string
+		match bool
+	}{
+		{"https://app.example.com/callback", true},
+		{"https://app.example.com/callback/", false},
+		{"https://sub.example.com/cb", true},
+		{"https://SUB.Example.com/cb", true},
+		{"https://my-app.example.com/cb", true},
+		{"https://example.com/cb", false},
+		{"https://a.b.example.com/cb", false},
+		{"https://evil.com\\.example.com/cb", false},
+		{"https://evil.com/.example.com/cb", false},
+		{"https://evil.com?.example.com/cb", false},
+		{"https://evil.com#.example.com/cb", false},
+		{"https://user@sub.example.com/cb", false},
+		{"https://evil.com@sub.example.com/cb", false},
+		{"https://sub.example.com@evil.com/cb", false},
+		{"https://sub.example.com:8443/cb", false},
+		{"http://sub.example.com/cb", false},
+		{"https://sub.example.com/cb/other", false},
+		{"https://sub.example.com/cb?next=x", false},
+		{"https://sub.example.com/cb#x", false},
+		{"https://-sub.example.com/cb", false},
+		{"https://sub.example.com.evil.com/cb", false},
+		{"http://localhost:8080/cb?mode=dev", true},
+		{"http://localhost:8080/cb", false},
+	} {
+		if match := list.Match(test.uri); match != test.match {
+			t.Errorf("%s: match %t, expected %t", test.uri, match, test.match)
+		}
+	}
+	// пустой список допускает любой адрес
+	if !RedirectURIs(nil).Match("https://any.example.org/") {
+		t.Error("empty list does not match")
+	}
+}
+
+func TestRedirectURIsPattern(t *testing.T) {
+	for _, test := range []struct {
+		pattern, uri string
+		match        bool
+	}{
+		// "*" допускается только в имени хоста и заменяет метку целиком
+		{"https://*.example.com/*", "https://sub.example.com/cb", false},
+		{"https://*.example.com/cb?x=*", "https://sub.example.com/cb?x=1", false},
+		{"https://app-*.example.com/cb", "https://app-1.example.com/cb", false},
+		{"https://*.*.example.com/cb", "https://a.b.example.com/cb", true},
+		{"https://*/cb", "https://evil.com/cb", false},
+		{"https://*.example.com:*/cb", "https://sub.example.com:1/cb", false},
+	} {
+		if match := matchURI(test.pattern, test.uri); match != test.match {
+			t.Errorf("%s ~ %s: match %t, expected %t",
+				test.pattern, test.uri, match, test.match)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package openid
+
+import (
+	"sync"
+	"time"
+
+	"itube/users/pkg/session"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// DefaultClientKeyTTL задает время жизни секретного ключа клиента в виде
+// JWT, если оно не указано в конфигурации.
+var DefaultClientKeyTTL = time.Hour
+
+// ClientKey задает закрытый ключ, которым подписывается секретный ключ
+// клиента в виде JWT вместо статического Secret. Так, например, работает
+// Sign in with Apple: в качестве Issuer указывается Team ID, а KeyID —
+// идентификатор ключа из консоли разработчика.
+type ClientKey struct {
+	File     string        `yaml:"file"`     // файл с закрытым ключом в формате PEM
+	KeyID    string        `yaml:"key_id"`   // идентификатор ключа (kid)
+	Issuer   string        `yaml:"issuer"`   // издатель (iss); по умолчанию client_id
+	Audience string        `yaml:"audience"` // получатель (aud); по умолчанию URL провайдера
+	TTL      time.Duration `yaml:"ttl"`      // время жизни; по умолчанию DefaultClientKeyTTL
+}
+
+// clientSecret формирует и кеширует секретный ключ клиента в виде JWT.
+type clientSecret struct {
+	signer   jose.Signer   // подпись токенов
+	issuer   string        // издатель (iss)
+	subject  string        // идентификатор клиента (sub)
+	audience string        // получатель (aud)
+	ttl      time.Duration // время жизни
+	mu       sync.Mutex
+	token    string    // последний сформированный токен
+	expires  time.Time // время окончания действия токена
+}
+
+// newClientSecret загружает закрытый ключ и возвращает инициализированный
+// генератор секретного ключа клиента.
+func newClientSecret(key ClientKey, clientID, audience string) (*clientSecret, error) {
+	signingKey, err := session.LoadKey(key.File)
+	if err != nil {
+		return nil, err
+	}
+	alg, err := session.KeyAlgorithm(signingKey)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: alg,
+			Key:       jose.JSONWebKey{Key: signingKey, KeyID: key.KeyID},
+		},
+		nil)
+	if err != nil {
+		return nil, err
+	}
+	var secret = &clientSecret{
+		signer:   signer,
+		issuer:   key.Issuer,
+		subject:  clientID,
+		audience: key.Audience,
+		ttl:      key.TTL,
+	}
+	if secret.issuer == "" {
+		secret.issuer = clientID
+	}
+	if secret.audience == "" {
+		secret.audience = audience
+	}
+	if secret.ttl <= 0 {
+		secret.ttl = DefaultClientKeyTTL
+	}
+	return secret, nil
+}
+
+// Token возвращает действующий секретный ключ клиента. Новый ключ
+// формируется, когда у предыдущего остается меньше четверти времени жизни.
+func (c *clientSecret) Token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var now = time.Now()
+	if c.token != "" && c.expires.Sub(now) > c.ttl/4 {
+		return c.token, nil
+	}
+	var expires = now.Add(c.ttl)
+	// aud передается строкой, т.к. не все провайдеры принимают массив
+	token, err := jwt.Signed(c.signer).
+		Claims(map[string]interface{}{
+			"iss": c.issuer,
+			"sub": c.subject,
+			"aud": c.audience,
+			"iat": now.Unix(),
+			"exp": expires.Unix(),
+		}).
+		CompactSerialize()
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expires = token, expires
+	return token, nil
+}
@@ -1,19 +1,92 @@
-//go:generate go-syncmap -type "states<string,stateObj>"
-
 package openid
 
 import (
+	"context"
+	"encoding/json"
 	"sync"
 	"time"
 )
 
-// states хранит сессии авторизации.
-type states sync.Map
+// State описывает состояние авторизации, сохраняемое между началом
+// авторизации и получением ответа от сервера авторизации.
+type State struct {
+	Created     time.Time       // время создания
+	RedirectURI string          // адрес для редиректа после авторизации
+	Nonce       string          // nonce для проверки токена идентификации
+	Verifier    string          // code_verifier для PKCE
+	Data        json.RawMessage // дополнительные данные в формате JSON
+}
+
+// StateStore описывает хранилище состояний авторизации.
+//
+// Состояние может быть получено только один раз: Take удаляет его из
+// хранилища, что исключает повторное использование. Для работы нескольких
+// экземпляров сервиса хранилище должно быть общим для всех экземпляров.
+type StateStore interface {
+	// Save сохраняет состояние авторизации с указанным ключом.
+	Save(ctx context.Context, key string, state State) error
+	// Take возвращает и удаляет из хранилища состояние авторизации с
+	// указанным ключом. Возвращает ErrBadState, если состояние не найдено
+	// или его время жизни StateTTL истекло.
+	Take(ctx context.Context, key string) (*State, error)
+}
+
+// проверка, что хранилище поддерживает интерфейс
+var _ StateStore = new(MemoryStates)
+
+// MemoryStates хранит состояния авторизации в памяти процесса. Подходит
+// только для сервиса, запущенного в единственном экземпляре.
+//
+// Устаревшие состояния удаляются при сохранении новых, но не чаще одного
+// раза за время жизни состояния StateTTL, поэтому хранилище не запускает
+// фоновых процессов.
+type MemoryStates struct {
+	mu      sync.Mutex
+	states  map[string]State
+	cleaned time.Time // время последнего удаления устаревших состояний
+}
+
+// NewMemoryStates возвращает инициализированное хранилище состояний
+// авторизации в памяти.
+func NewMemoryStates() *MemoryStates {
+	return &MemoryStates{
+		states:  make(map[string]State),
+		cleaned: time.Now(),
+	}
+}
+
+// Save сохраняет состояние авторизации с указанным ключом.
+func (m *MemoryStates) Save(_ context.Context, key string, state State) error {
+	var now = time.Now()
+	m.mu.Lock()
+	if now.Sub(m.cleaned) >= StateTTL {
+		m.cleanup(now)
+	}
+	m.states[key] = state
+	m.mu.Unlock()
+	return nil
+}
+
+// Take возвращает и удаляет состояние авторизации с указанным ключом.
+func (m *MemoryStates) Take(_ context.Context, key string) (*State, error) {
+	m.mu.Lock()
+	state, ok := m.states[key]
+	delete(m.states, key)
+	m.mu.Unlock()
+	if !ok || state.Created.Before(time.Now().Add(-StateTTL)) {
+		return nil, ErrBadState
+	}
+	return &state, nil
+}
 
-// stateObj используется для хранения состояний между началом авторизации и
-// получением ответа от сервера авторизации.
-type stateObj struct {
-	Created     time.Time   // время создания
-	RedirectURI string      // адрес для редиректа после авторизации
-	Data        interface{} // дополнительные данные
+// cleanup удаляет устаревшие состояния авторизации. Вызывается с
+// заблокированным mu.
+func (m *MemoryStates) cleanup(now time.Time) {
+	var before = now.Add(-StateTTL) // время для проверки устаревания
+	for key, state := range m.states {
+		if state.Created.Before(before) {
+			delete(m.states, key) // удаляем устаревшее состояние
+		}
+	}
+	m.cleaned = now
 }
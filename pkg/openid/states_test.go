@@ -0,0 +1,60 @@
+package openid
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStates(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		states = NewMemoryStates()
+	)
+	if err := states.Save(ctx, "key", State{Created: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := states.Take(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	// состояние можно получить только один раз
+	if _, err := states.Take(ctx, "key"); !errors.Is(err, ErrBadState) {
+		t.Errorf("unexpected second take error: %v", err)
+	}
+	// устаревшее состояние не возвращается
+	var expired = State{Created: time.Now().Add(-StateTTL - time.Second)}
+	if err := states.Save(ctx, "expired", expired); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := states.Take(ctx, "expired"); !errors.Is(err, ErrBadState) {
+		t.Errorf("unexpected expired take error: %v", err)
+	}
+}
+
+func TestMemoryStatesCleanup(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		states  = NewMemoryStates()
+		expired = State{Created: time.Now().Add(-StateTTL - time.Second)}
+	)
+	for _, key := range []string{"expired1", "expired2"} {
+		if err := states.Save(ctx, key, expired); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// до истечения интервала очистки устаревшие состояния не удаляются
+	if len(states.states) != 2 {
+		t.Fatalf("states: %d, expected 2", len(states.states))
+	}
+	states.cleaned = time.Now().Add(-StateTTL)
+	if err := states.Save(ctx, "key", State{Created: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if len(states.states) != 1 {
+		t.Errorf("states after cleanup: %d, expected 1", len(states.states))
+	}
+	if _, err := states.Take(ctx, "key"); err != nil {
+		t.Errorf("take error: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc"
@@ -36,17 +37,27 @@ type UserInfo struct {
 	Phone         string    `json:"phone_number,omitempty"`          // телефон: +1 (604) 555-1234;ext=5678
 	PhoneVerified bool      `json:"phone_number_verified,omitempty"` // флаг, что телефонный номер подтвержден
 	Updated       int64     `json:"updated_at,omitempty"`            // дата обновления
+	PrivateEmail  bool      `json:"is_private_email,omitempty"`      // флаг, что email-адрес — адрес для пересылки провайдера
 	Expiry        time.Time `json:"-"`                               // до какого времени эта информация считается актуальной
+	RedirectURI   string    `json:"-"`                               // адрес для возврата, использованный при авторизации
 
-	provider    *oidc.Provider // провайдер авторизации
-	oauth2Token *oauth2.Token  // токен авторизации OAuth2
-	data        interface{}    // дополнительная информация
+	provider    *oidc.Provider  // провайдер авторизации
+	oauth2Token *oauth2.Token   // токен авторизации OAuth2
+	data        json.RawMessage // дополнительная информация
+	trustEmail  string          // доверие к подтверждению email провайдером
 }
 
 // Update обращается к серверу провайдера авторизации и запрашивает полный
 // профиль пользователя. На основании полученных данных обновляет информацию
 // о пользователе.
 //
+// Идентификатор пользователя в профиле должен совпадать с идентификатором из
+// токена идентификации (OpenID Connect Core 5.3.2), иначе возвращается
+// ошибка ErrSubjectMismatch и информация не изменяется. Email-адрес и флаг
+// его подтверждения из подписанного токена идентификации не заменяются:
+// они определяют регистрацию и привязку пользователя. Если в токене адреса
+// нет, то берется адрес из профиля, но он не считается подтвержденным.
+//
 // Если информация заполнена не из токена идентификации, то обновление не будет
 // производиться и ошибка не возвращается.
 func (u *UserInfo) Update(ctx context.Context) error {
@@ -63,18 +74,76 @@ func (u *UserInfo) Update(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error getting user profile update: %w", err)
 	}
-	// обновлям поля с полученной информацией
-	u.Subject = userInfo.Subject
-	u.Profile = userInfo.Profile
-	u.Email = userInfo.Email
-	u.Verified = userInfo.EmailVerified
-	// заполняем остальные поля
-	if err = userInfo.Claims(u); err != nil {
+	// профиль должен относиться к тому же пользователю
+	if userInfo.Subject != u.Subject {
+		return ErrSubjectMismatch
+	}
+	// заполняем поля полученной информацией, сохраняя данные из токена
+	// идентификации
+	var updated = *u
+	updated.Profile = userInfo.Profile
+	if err = userInfo.Claims(&updated); err != nil {
 		return fmt.Errorf("error parsing updated user profile: %w", err)
 	}
+	if u.Email != "" {
+		updated.Email, updated.Verified = u.Email, u.Verified
+	} else {
+		updated.Verified = false
+	}
+	*u = updated
+	u.applyTrust()
 	return nil
 }
 
+// applyTrust изменяет флаг подтверждения email-адреса в соответствии с
+// настройками доверия провайдеру.
+func (u *UserInfo) applyTrust() {
+	switch u.trustEmail {
+	case TrustAlways:
+		u.Verified = u.Email != ""
+	case TrustNever:
+		u.Verified = false
+	}
+}
+
+// UnmarshalJSON разбирает данные профиля пользователя. Флаги
+// email_verified, phone_number_verified и is_private_email принимаются как в
+// виде логических значений, так и в виде строк "true" и "false", как их
+// передает, например, Apple.
+func (u *UserInfo) UnmarshalJSON(data []byte) error {
+	type plain UserInfo // тип без метода UnmarshalJSON
+	var flags = struct {
+		*plain
+		Verified      interface{} `json:"email_verified"`
+		PhoneVerified interface{} `json:"phone_number_verified"`
+		PrivateEmail  interface{} `json:"is_private_email"`
+	}{plain: (*plain)(u)}
+	if err := json.Unmarshal(data, &flags); err != nil {
+		return err
+	}
+	// изменяем только флаги, присутствующие в данных
+	if flags.Verified != nil {
+		u.Verified = toBool(flags.Verified)
+	}
+	if flags.PhoneVerified != nil {
+		u.PhoneVerified = toBool(flags.PhoneVerified)
+	}
+	if flags.PrivateEmail != nil {
+		u.PrivateEmail = toBool(flags.PrivateEmail)
+	}
+	return nil
+}
+
+// fullName составляет полное имя из имени и фамилии, если оно не задано.
+// Возвращает true, если имя было составлено.
+func (u *UserInfo) fullName() bool {
+	if u.Name != "" {
+		return false
+	}
+	u.Name = strings.TrimSpace(u.GivenName + " " + u.FamilyName)
+	return u.Name != ""
+}
+
 // IsExpired возвращает true, если информация считается устаревшей.
 // Для вычисления используется дата времени жизни идентификационного токена, из
 // которого взята информация о пользователе. В противном случае информация
@@ -95,8 +164,52 @@ func (u UserInfo) JSON() []byte {
 	return data
 }
 
-// Data возвращает дополнительные данные, прикрепленные к изначальному запросу
-// получения информации о пользовтаеле.
-func (u UserInfo) Data() interface{} {
+// Select возвращает в формате JSON только перечисленные данные профиля
+// пользователя. Значение "*" выбирает все данные. Пустые значения не
+// выбираются, чтобы не затирать ими свойства пользователя. Если ни одного из
+// данных нет, то возвращается nil.
+func (u UserInfo) Select(names []string) []byte {
+	if len(names) == 0 {
+		return nil
+	}
+	var claims map[string]json.RawMessage
+	if err := json.Unmarshal(u.JSON(), &claims); err != nil {
+		return nil
+	}
+	var all bool
+	for _, name := range names {
+		all = all || name == "*"
+	}
+	var selected = make(map[string]json.RawMessage, len(names))
+	for name, value := range claims {
+		if string(value) == `""` || string(value) == "null" {
+			continue
+		}
+		if all {
+			selected[name] = value
+			continue
+		}
+		for _, n := range names {
+			if n == name {
+				selected[name] = value
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(selected)
+	return data
+}
+
+// Token возвращает токен авторизации OAuth2, полученный от провайдера.
+func (u UserInfo) Token() *oauth2.Token {
+	return u.oauth2Token
+}
+
+// Data возвращает дополнительные данные в формате JSON, прикрепленные к
+// изначальному запросу получения информации о пользовтаеле.
+func (u UserInfo) Data() json.RawMessage {
 	return u.data
 }
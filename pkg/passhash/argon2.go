@@ -0,0 +1,104 @@
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2idPrefix задает префикс хеша Argon2id в формате PHC.
+const argon2idPrefix = "$argon2id$"
+
+// DefaultArgon2id задает параметры Argon2id по умолчанию (рекомендованные
+// RFC 9106 для систем с ограниченной памятью).
+var DefaultArgon2id = Argon2id{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+// Argon2id хеширует пароли с помощью Argon2id.
+type Argon2id struct {
+	Time    uint32 // количество проходов
+	Memory  uint32 // объем памяти в KiB
+	Threads uint8  // степень параллелизма
+	SaltLen uint32 // длина соли в байтах
+	KeyLen  uint32 // длина хеша в байтах
+}
+
+// phcCoder используется для кодирования соли и хеша в формате PHC.
+var phcCoder = base64.RawStdEncoding
+
+// Hash возвращает хеш пароля в формате PHC.
+func (a Argon2id) Hash(password string) (string, error) {
+	var salt = make([]byte, a.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	var key = argon2.IDKey([]byte(password), salt,
+		a.Time, a.Memory, a.Threads, a.KeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix,
+		argon2.Version, a.Memory, a.Time, a.Threads,
+		phcCoder.EncodeToString(salt), phcCoder.EncodeToString(key)), nil
+}
+
+// NeedsRehash возвращает true, если хеш создан не Argon2id или с более
+// слабыми параметрами.
+func (a Argon2id) NeedsRehash(hash string) bool {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.Memory < a.Memory || params.Time < a.Time ||
+		params.Threads < a.Threads ||
+		uint32(len(salt)) < a.SaltLen || uint32(len(key)) < a.KeyLen
+}
+
+// parseArgon2id разбирает хеш Argon2id в формате PHC.
+func parseArgon2id(hash string) (params Argon2id, salt, key []byte, err error) {
+	var parts = strings.Split(hash, "$")
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnknownHash
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&params.Memory, &params.Time, &params.Threads)
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if salt, err = phcCoder.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if key, err = phcCoder.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	params.SaltLen, params.KeyLen = uint32(len(salt)), uint32(len(key))
+	return params, salt, key, nil
+}
+
+// compareArgon2id проверяет пароль с хешем Argon2id.
+func compareArgon2id(hash, password string) error {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return err
+	}
+	var other = argon2.IDKey([]byte(password), salt,
+		params.Time, params.Memory, params.Threads, params.KeyLen)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package passhash
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost задает "стоимость" хеширования bcrypt по умолчанию.
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// Bcrypt хеширует пароли с помощью bcrypt.
+type Bcrypt struct {
+	Cost int // "стоимость" хеширования
+}
+
+// Hash возвращает хеш пароля.
+func (b Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// NeedsRehash возвращает true, если хеш создан не bcrypt или с меньшей
+// стоимостью.
+func (b Bcrypt) NeedsRehash(hash string) bool {
+	if !isBcrypt(hash) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	var want = b.Cost
+	if want < bcrypt.MinCost {
+		want = bcrypt.DefaultCost // так же поступает GenerateFromPassword
+	}
+	return cost < want
+}
+
+// isBcrypt возвращает true, если хеш в формате bcrypt.
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
+
+// compareBcrypt проверяет пароль с хешем bcrypt.
+func compareBcrypt(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatch
+	}
+	return err
+}
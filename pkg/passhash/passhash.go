@@ -0,0 +1,65 @@
+// Package passhash описывает хеширование паролей пользователей.
+//
+// Хеши сохраняются в виде строк в формате PHC
+// (https://github.com/P-H-C/phc-string-format): для Argon2id это
+// $argon2id$v=19$m=...,t=...,p=...$salt$hash, а для bcrypt используется его
+// стандартный формат $2a$cost$..., который является частным случаем
+// модульного формата crypt.
+//
+// Проверка пароля через Compare поддерживает все известные алгоритмы вне
+// зависимости от того, какой из них используется для создания новых хешей.
+// Это позволяет менять алгоритм или его параметры без принудительного сброса
+// паролей: Hasher.NeedsRehash сообщает, что хеш следует пересчитать при
+// следующей успешной авторизации.
+package passhash
+
+import (
+	"errors"
+	"strings"
+)
+
+// Hasher описывает алгоритм хеширования паролей.
+type Hasher interface {
+	// Hash возвращает хеш пароля в формате PHC.
+	Hash(password string) (string, error)
+	// NeedsRehash возвращает true, если хеш создан другим алгоритмом или с
+	// более слабыми параметрами и его необходимо пересчитать.
+	NeedsRehash(hash string) bool
+}
+
+// ошибки проверки пароля
+var (
+	// ErrMismatch возвращается, если пароль не соответствует хешу.
+	ErrMismatch = errors.New("password mismatch")
+	// ErrUnknownHash возвращается, если формат хеша не поддерживается.
+	ErrUnknownHash = errors.New("unknown password hash format")
+)
+
+// Default используется по умолчанию для хеширования паролей.
+var Default Hasher = Bcrypt{Cost: DefaultBcryptCost}
+
+// Compare проверяет, что пароль соответствует хешу. Алгоритм определяется
+// по формату хеша. Возвращает ErrMismatch, если пароль не совпадает.
+func Compare(hash, password string) error {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		return compareArgon2id(hash, password)
+	case isBcrypt(hash):
+		return compareBcrypt(hash, password)
+	default:
+		return ErrUnknownHash
+	}
+}
+
+// New возвращает алгоритм хеширования по его имени: "bcrypt" или "argon2id"
+// с параметрами по умолчанию.
+func New(name string) (Hasher, error) {
+	switch strings.ToLower(name) {
+	case "bcrypt":
+		return Bcrypt{Cost: DefaultBcryptCost}, nil
+	case "argon2id", "argon2":
+		return DefaultArgon2id, nil
+	default:
+		return nil, errors.New("unsupported password hash algorithm")
+	}
+}
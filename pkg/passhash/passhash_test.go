@@ -0,0 +1,136 @@
+package passhash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2id задает облегченные параметры Argon2id для тестов.
+var testArgon2id = Argon2id{
+	Time:    1,
+	Memory:  1024,
+	Threads: 1,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	hash, err := testArgon2id.Hash("secret password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected hash format: %s", hash)
+	}
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params != testArgon2id || len(salt) != 16 || len(key) != 32 {
+		t.Errorf("unexpected params: %+v", params)
+	}
+	if err = Compare(hash, "secret password"); err != nil {
+		t.Errorf("compare error: %v", err)
+	}
+	if err = Compare(hash, "other password"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("unexpected mismatch error: %v", err)
+	}
+	// соль случайная, поэтому хеши одного пароля различаются
+	other, err := testArgon2id.Hash("secret password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == hash {
+		t.Error("same hash for different salts")
+	}
+}
+
+func TestArgon2idParse(t *testing.T) {
+	for _, hash := range []string{
+		"",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$!!!",
+	} {
+		if _, _, _, err := parseArgon2id(hash); err == nil {
+			t.Errorf("%q: parsed", hash)
+		}
+	}
+	if err := Compare("$1$salt$hash", "password"); !errors.Is(err, ErrUnknownHash) {
+		t.Errorf("unexpected unknown hash error: %v", err)
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	hash, err := testArgon2id.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testArgon2id.NeedsRehash(hash) {
+		t.Error("rehash with the same params")
+	}
+	var weaker = testArgon2id
+	weaker.Memory /= 2
+	if weaker.NeedsRehash(hash) {
+		t.Error("rehash with weaker params")
+	}
+	for name, change := range map[string]func(*Argon2id){
+		"time":    func(a *Argon2id) { a.Time++ },
+		"memory":  func(a *Argon2id) { a.Memory *= 2 },
+		"threads": func(a *Argon2id) { a.Threads++ },
+		"salt":    func(a *Argon2id) { a.SaltLen *= 2 },
+		"key":     func(a *Argon2id) { a.KeyLen *= 2 },
+	} {
+		var stronger = testArgon2id
+		change(&stronger)
+		if !stronger.NeedsRehash(hash) {
+			t.Errorf("%s: no rehash with stronger params", name)
+		}
+	}
+	// хеш другого алгоритма всегда пересчитывается
+	bcryptHash, err := Bcrypt{Cost: bcrypt.MinCost}.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !testArgon2id.NeedsRehash(bcryptHash) {
+		t.Error("no rehash for bcrypt hash")
+	}
+}
+
+func TestBcrypt(t *testing.T) {
+	var hasher = Bcrypt{Cost: bcrypt.MinCost}
+	hash, err := hasher.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Compare(hash, "password"); err != nil {
+		t.Errorf("compare error: %v", err)
+	}
+	if err = Compare(hash, "other"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("unexpected mismatch error: %v", err)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("rehash with the same cost")
+	}
+	if !(Bcrypt{Cost: bcrypt.MinCost + 1}).NeedsRehash(hash) {
+		t.Error("no rehash with higher cost")
+	}
+	// нулевая стоимость соответствует стоимости по умолчанию
+	if !(Bcrypt{}).NeedsRehash(hash) {
+		t.Error("no rehash with default cost")
+	}
+	// хеш другого алгоритма всегда пересчитывается
+	argonHash, err := testArgon2id.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasher.NeedsRehash(argonHash) {
+		t.Error("no rehash for argon2id hash")
+	}
+}
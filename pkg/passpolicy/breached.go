@@ -0,0 +1,120 @@
+package passpolicy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" // #nosec используется в формате списка HIBP
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Breached осуществляет поиск пароля в локальном списке скомпрометированных
+// паролей.
+//
+// Список задается файлом в формате Have I Been Pwned, отсортированном по
+// хешу: каждая строка содержит SHA-1 от пароля в шестнадцатеричном виде и,
+// через двоеточие, количество утечек (HASH:COUNT). Файл не загружается в
+// память: поиск ведется двоичным поиском непосредственно по файлу.
+type Breached struct {
+	file *os.File
+	size int64
+}
+
+// OpenBreached открывает файл со списком скомпрометированных паролей.
+func OpenBreached(filename string) (*Breached, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &Breached{file: file, size: info.Size()}, nil
+}
+
+// Close закрывает файл со списком скомпрометированных паролей.
+func (b *Breached) Close() error {
+	return b.file.Close()
+}
+
+// Count возвращает, сколько раз пароль встречался в утечках, или 0, если
+// пароль в списке не найден.
+func (b *Breached) Count(password string) (int, error) {
+	var sum = sha1.Sum([]byte(password)) // #nosec
+	var hash = make([]byte, hex.EncodedLen(len(sum)))
+	hex.Encode(hash, sum[:])
+	hash = bytes.ToUpper(hash)
+	// ищем первую строку, которая начинается в диапазоне [low, high)
+	var low, high = int64(0), b.size
+	for low < high {
+		var mid = low + (high-low)/2
+		line, next, err := b.lineAfter(mid)
+		if err != nil {
+			return 0, err
+		}
+		if line == nil || bytes.Compare(key(line), hash) >= 0 {
+			high = mid
+		} else {
+			low = next
+		}
+	}
+	line, _, err := b.lineAfter(low)
+	if err != nil || line == nil {
+		return 0, err
+	}
+	if !bytes.Equal(key(line), hash) {
+		return 0, nil
+	}
+	// количество утечек может отсутствовать
+	var count = 1
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		n, err := strconv.Atoi(string(bytes.TrimSpace(line[i+1:])))
+		if err == nil && n > 0 {
+			count = n
+		}
+	}
+	return count, nil
+}
+
+// lineAfter возвращает первую полную строку, начинающуюся не раньше offset,
+// и смещение следующей за ней строки. Если offset не равен 0, то строка,
+// в которую он попадает, пропускается. Возвращает nil, если строк больше нет.
+func (b *Breached) lineAfter(offset int64) ([]byte, int64, error) {
+	var start = offset
+	if offset > 0 {
+		start-- // проверяем, не начинается ли строка ровно с offset
+	}
+	var r = bufio.NewReader(io.NewSectionReader(b.file, start, b.size-start))
+	if offset > 0 {
+		skipped, err := r.ReadSlice('\n')
+		if errors.Is(err, io.EOF) {
+			return nil, b.size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		start += int64(len(skipped))
+	}
+	line, err := r.ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+	if len(line) == 0 {
+		return nil, b.size, nil
+	}
+	return bytes.TrimRight(line, "\r\n"), start + int64(len(line)), nil
+}
+
+// key возвращает хеш из строки списка в верхнем регистре.
+func key(line []byte) []byte {
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		line = line[:i]
+	}
+	return bytes.ToUpper(bytes.TrimSpace(line))
+}
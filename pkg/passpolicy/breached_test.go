@@ -0,0 +1,128 @@
+package passpolicy
+
+import (
+	"crypto/sha1" // #nosec используется в формате списка HIBP
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// hashHex возвращает хеш пароля в формате списка HIBP.
+func hashHex(password string) string {
+	return fmt.Sprintf("%X", sha1.Sum([]byte(password))) // #nosec
+}
+
+// openBreached записывает список скомпрометированных паролей во временный
+// файл и открывает его.
+func openBreached(t *testing.T, data string) *Breached {
+	t.Helper()
+	file, err := ioutil.TempFile("", "breached*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(file.Name()) })
+	_, err = file.WriteString(data)
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	breached, err := OpenBreached(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { breached.Close() })
+	return breached
+}
+
+func TestBreachedCount(t *testing.T) {
+	var (
+		counts = make(map[string]int, 100)
+		lines  = make([]string, 0, 100)
+	)
+	for i := 0; i < 100; i++ {
+		var password = fmt.Sprintf("password%d", i)
+		counts[password] = i + 1
+		lines = append(lines, fmt.Sprintf("%s:%d", hashHex(password), i+1))
+	}
+	sort.Strings(lines)
+	// первая и последняя строки файла
+	var first, last string
+	for password := range counts {
+		switch hashHex(password) {
+		case lines[0][:40]:
+			first = password
+		case lines[len(lines)-1][:40]:
+			last = password
+		}
+	}
+	for _, format := range []struct {
+		name string
+		data string
+	}{
+		{"lf", strings.Join(lines, "\n") + "\n"},
+		{"crlf", strings.Join(lines, "\r\n") + "\r\n"},
+		{"no trailing newline", strings.Join(lines, "\n")},
+	} {
+		var breached = openBreached(t, format.data)
+		for _, password := range []string{first, last} {
+			count, err := breached.Count(password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != counts[password] {
+				t.Errorf("%s: %s: count %d, expected %d",
+					format.name, password, count, counts[password])
+			}
+		}
+		for password, expected := range counts {
+			count, err := breached.Count(password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != expected {
+				t.Errorf("%s: %s: count %d, expected %d",
+					format.name, password, count, expected)
+			}
+		}
+		// отсутствующие в списке пароли
+		for _, password := range []string{"", "missing", "password100"} {
+			count, err := breached.Count(password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 0 {
+				t.Errorf("%s: %q: found %d", format.name, password, count)
+			}
+		}
+	}
+}
+
+func TestBreachedFormat(t *testing.T) {
+	// хеш в нижнем регистре и строки без количества утечек
+	var lines = []string{
+		strings.ToLower(hashHex("first")),
+		hashHex("second") + ":0",
+		hashHex("third") + ":",
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return strings.ToUpper(lines[i]) < strings.ToUpper(lines[j])
+	})
+	var breached = openBreached(t, strings.Join(lines, "\n"))
+	for _, password := range []string{"first", "second", "third"} {
+		count, err := breached.Count(password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Errorf("%s: count %d, expected 1", password, count)
+		}
+	}
+	// пустой файл
+	count, err := openBreached(t, "").Count("password")
+	if err != nil || count != 0 {
+		t.Errorf("empty file: %d, %v", count, err)
+	}
+}
@@ -0,0 +1,159 @@
+// Package passpolicy описывает проверку паролей пользователей на соответствие
+// политике: длина, используемые классы символов, совпадение с email и наличие
+// в списке скомпрометированных паролей.
+package passpolicy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Policy описывает требования к паролю.
+type Policy struct {
+	// минимальная длина пароля в символах
+	MinLength int `yaml:"min_length"`
+	// максимальная длина пароля в байтах (bcrypt учитывает только первые
+	// 72 байта); 0 - без ограничений
+	MaxLength int `yaml:"max_length"`
+	// минимальное количество используемых классов символов: строчные и
+	// заглавные буквы, цифры и остальные символы
+	Classes int `yaml:"classes"`
+	// разрешает не проверять пароль по списку скомпрометированных паролей
+	AllowBreached bool `yaml:"allow_breached"`
+	// количество последних паролей пользователя, которые нельзя использовать
+	// повторно; 0 - без ограничений
+	History int `yaml:"history"`
+}
+
+// Default задает политику паролей по умолчанию.
+var Default = Policy{
+	MinLength: 8,
+	MaxLength: 72,
+}
+
+// правила проверки пароля
+const (
+	RuleMinLength = "min_length" // пароль слишком короткий
+	RuleMaxLength = "max_length" // пароль слишком длинный
+	RuleClasses   = "classes"    // недостаточно классов символов
+	RuleEmail     = "email"      // пароль совпадает с email
+	RuleBreached  = "breached"   // пароль скомпрометирован
+	RuleHistory   = "history"    // пароль уже использовался ранее
+)
+
+// Violation описывает нарушение политики паролей.
+type Violation struct {
+	Rule        string // правило
+	Description string // описание нарушения
+}
+
+// Error возвращается, если пароль не соответствует политике.
+type Error struct {
+	Violations []Violation
+}
+
+// Error возвращает описание ошибки.
+func (e *Error) Error() string {
+	var descriptions = make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return "password policy violation: " + strings.Join(descriptions, "; ")
+}
+
+// Check проверяет пароль на соответствие политике. Если пароль не
+// соответствует политике, то возвращается ошибка *Error со списком нарушений.
+// Если breached не задан, то проверка по списку скомпрометированных паролей
+// не производится.
+func (p Policy) Check(password, email string, breached *Breached) error {
+	var violations []Violation
+	var add = func(rule, format string, args ...interface{}) {
+		violations = append(violations, Violation{
+			Rule:        rule,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		add(RuleMinLength, "password must be at least %d characters long",
+			p.MinLength)
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		add(RuleMaxLength, "password must be at most %d bytes long",
+			p.MaxLength)
+	}
+	if classes(password) < p.Classes {
+		add(RuleClasses, "password must contain at least %d of: lowercase "+
+			"letters, uppercase letters, digits and other characters", p.Classes)
+	}
+	if email != "" && strings.EqualFold(password, email) {
+		add(RuleEmail, "password must not match the email")
+	}
+	if breached != nil && !p.AllowBreached {
+		count, err := breached.Count(password)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			add(RuleBreached, "password has appeared in a data breach")
+		}
+	}
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// classes возвращает количество используемых в пароле классов символов.
+func classes(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+// Policies описывает политики паролей для доменов. Политика с именем "*"
+// используется для доменов, для которых политика не задана.
+type Policies map[string]Policy
+
+// Load загружает политики паролей для доменов из файла в формате yaml.
+func Load(filename string) (Policies, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	var policies = make(Policies)
+	err = yaml.NewDecoder(file).Decode(&policies)
+	_ = file.Close()
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// Get возвращает политику паролей для домена. Если политика для домена не
+// задана, то возвращается политика "*", а если нет и ее, то Default.
+func (p Policies) Get(domain string) Policy {
+	if policy, ok := p[strings.ToLower(domain)]; ok {
+		return policy
+	}
+	if policy, ok := p["*"]; ok {
+		return policy
+	}
+	return Default
+}
@@ -0,0 +1,183 @@
+package session
+
+import (
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// Настройки, используемые по умолчанию при выдаче токенов доступа.
+var (
+	DefaultIssuer = "itube-users"    // идентификатор сервиса, выдавшего токен
+	DefaultTTL    = time.Minute * 15 // время жизни токена доступа
+)
+
+// Claims описывает информацию, передаваемую в токене доступа.
+//
+// В качестве Subject используется уникальный идентификатор пользователя, а в
+// Audience — домен, для которого была произведена авторизация.
+type Claims struct {
+	jwt.Claims
+	SessionID string `json:"sid"`             // идентификатор сессии
+	Email     string `json:"email,omitempty"` // email-адрес пользователя
+	Scope     string `json:"scope,omitempty"` // области доступа клиента OpenID Connect
+}
+
+// Issuer отвечает за выдачу и проверку подписанных токенов доступа в формате
+// JWT.
+type Issuer struct {
+	name   string             // идентификатор сервиса (iss)
+	ttl    time.Duration      // время жизни токена
+	signer jose.Signer        // подпись токенов
+	key    jose.JSONWebKey    // открытый ключ для проверки подписи
+	keys   jose.JSONWebKeySet // список публикуемых открытых ключей
+}
+
+// NewIssuer возвращает инициализированный обработчик для выдачи токенов
+// доступа, подписанных указанным ключом. Если name или ttl не заданы, то
+// используются значения DefaultIssuer и DefaultTTL соответственно.
+//
+// В качестве идентификатора ключа (kid) используется его отпечаток по
+// RFC 7638.
+func NewIssuer(name string, ttl time.Duration, key crypto.Signer) (*Issuer, error) {
+	if name == "" {
+		name = DefaultIssuer
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	alg, err := KeyAlgorithm(key)
+	if err != nil {
+		return nil, err
+	}
+	// вычисляем идентификатор ключа по его отпечатку
+	var public = jose.JSONWebKey{
+		Key:       key.Public(),
+		Algorithm: string(alg),
+		Use:       "sig",
+	}
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("key thumbprint error: %w", err)
+	}
+	public.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+	// инициализируем подпись токенов
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: alg,
+			Key:       jose.JSONWebKey{Key: key, KeyID: public.KeyID},
+		},
+		new(jose.SignerOptions).WithType("JWT"))
+	if err != nil {
+		return nil, err
+	}
+	return &Issuer{
+		name:   name,
+		ttl:    ttl,
+		signer: signer,
+		key:    public,
+		keys:   jose.JSONWebKeySet{Keys: []jose.JSONWebKey{public}},
+	}, nil
+}
+
+// String возвращает идентификатор сервиса, выдающего токены.
+func (i *Issuer) String() string {
+	return i.name
+}
+
+// Token формирует и подписывает токен доступа для пользователя. scope задает
+// области доступа клиента OpenID Connect и добавляется, если не пустой.
+// Возвращает сам токен и время окончания его действия.
+func (i *Issuer) Token(sid, uid, email, domain, scope string) (string, time.Time, error) {
+	var (
+		now     = time.Now()
+		expires = now.Add(i.ttl)
+	)
+	var claims = Claims{
+		Claims: jwt.Claims{
+			Issuer:    i.name,
+			Subject:   uid,
+			Audience:  jwt.Audience{domain},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(expires),
+		},
+		SessionID: sid,
+		Email:     email,
+		Scope:     scope,
+	}
+	token, err := jwt.Signed(i.signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expires, nil
+}
+
+// IDToken формирует и подписывает токен идентификации OpenID Connect для
+// клиента audience. nonce и время авторизации пользователя добавляются, если
+// заданы. claims дополняют токен данными профиля пользователя.
+func (i *Issuer) IDToken(uid, audience, nonce string, authTime time.Time,
+	claims map[string]interface{}) (string, error) {
+	var now = time.Now()
+	var extra = make(map[string]interface{}, len(claims)+2)
+	for name, value := range claims {
+		extra[name] = value
+	}
+	if nonce != "" {
+		extra["nonce"] = nonce
+	}
+	if !authTime.IsZero() {
+		extra["auth_time"] = authTime.Unix()
+	}
+	return jwt.Signed(i.signer).
+		Claims(extra).
+		Claims(jwt.Claims{
+			Issuer:   i.name,
+			Subject:  uid,
+			Audience: jwt.Audience{audience},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(i.ttl)),
+		}).
+		CompactSerialize()
+}
+
+// Algorithm возвращает название алгоритма подписи токенов.
+func (i *Issuer) Algorithm() string {
+	return i.key.Algorithm
+}
+
+// ErrBadToken возвращается, если токен доступа не прошел проверку.
+var ErrBadToken = errors.New("invalid access token")
+
+// Verify проверяет подпись и время действия токена доступа и возвращает
+// информацию из него. Если задан domain, то проверяется, что токен был выдан
+// для этого домена.
+func (i *Issuer) Verify(token, domain string) (*Claims, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, ErrBadToken
+	}
+	var claims = new(Claims)
+	if err = parsed.Claims(i.key.Key, claims); err != nil {
+		return nil, ErrBadToken
+	}
+	var expected = jwt.Expected{Issuer: i.name, Time: time.Now()}
+	if domain != "" {
+		expected.Audience = jwt.Audience{domain}
+	}
+	if err = claims.Validate(expected); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBadToken, err)
+	}
+	return claims, nil
+}
+
+// PublicKeys возвращает список открытых ключей для проверки подписи токенов
+// в формате JWKS.
+func (i *Issuer) PublicKeys() jose.JSONWebKeySet {
+	return i.keys
+}
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+// ErrUnsupportedKey возвращается, если тип ключа не поддерживается для подписи
+// токенов.
+var ErrUnsupportedKey = errors.New("unsupported signing key type")
+
+// GenerateKey генерирует новый ключ ECDSA P-256 для подписи токенов.
+//
+// Токены, подписанные таким ключом, перестанут проверяться после перезапуска
+// сервиса, поэтому его лучше использовать только для разработки.
+func GenerateKey() (crypto.Signer, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// LoadKey загружает закрытый ключ для подписи токенов из файла в формате PEM.
+// Поддерживаются ключи RSA и ECDSA в форматах PKCS #1, SEC 1 и PKCS #8.
+func LoadKey(filename string) (crypto.Signer, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %q", filename)
+	}
+	var key interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+	return signer, nil
+}
+
+// KeyAlgorithm возвращает алгоритм подписи для указанного ключа.
+func KeyAlgorithm(key crypto.Signer) (jose.SignatureAlgorithm, error) {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+	}
+	return "", ErrUnsupportedKey
+}
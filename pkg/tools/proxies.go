@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"net"
+	"strings"
+)
+
+// Proxies описывает список сетей доверенных прокси-серверов, от которых
+// принимаются заголовки с адресом клиента (X-Forwarded-For, X-Real-IP).
+type Proxies []*net.IPNet
+
+// ParseProxies разбирает список IP-адресов или сетей в формате CIDR,
+// разделенных запятыми.
+func ParseProxies(list string) (Proxies, error) {
+	var proxies Proxies
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			// отдельный адрес приводим к сети из одного адреса
+			if ip := net.ParseIP(item); ip != nil && ip.To4() != nil {
+				item += "/32"
+			} else {
+				item += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+// Trusted возвращает true, если адрес принадлежит доверенному прокси-серверу.
+func (p Proxies) Trusted(addr string) bool {
+	var ip = net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range p {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP возвращает IP-адрес клиента по адресу подключения remote и
+// значениям заголовков X-Forwarded-For и X-Real-IP.
+//
+// Заголовки учитываются, только если подключение установлено с доверенного
+// прокси-сервера, иначе их может подменить сам клиент. Адреса в
+// X-Forwarded-For перебираются с конца, пока они принадлежат доверенным
+// прокси-серверам: первый недоверенный адрес и есть адрес клиента.
+func (p Proxies) ClientIP(remote string, forwardedFor []string, realIP string) string {
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	if !p.Trusted(remote) {
+		return remote
+	}
+	var hops []string
+	for _, value := range forwardedFor {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	if len(hops) == 0 {
+		if realIP = strings.TrimSpace(realIP); net.ParseIP(realIP) != nil {
+			return realIP
+		}
+		return remote
+	}
+	var ip = remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		var hop = strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break // дальше адресам доверять нельзя
+		}
+		ip = hop
+		if !p.Trusted(hop) {
+			break
+		}
+	}
+	return ip
+}
@@ -0,0 +1,37 @@
+package tools
+
+import "testing"
+
+func TestProxiesClientIP(t *testing.T) {
+	proxies, err := ParseProxies("10.0.0.0/8, 192.168.1.1,::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name      string
+		remote    string
+		forwarded []string
+		realIP    string
+		want      string
+	}{
+		{"direct", "203.0.113.5:1234", nil, "", "203.0.113.5"},
+		{"spoofed header", "203.0.113.5:1234", []string{"1.2.3.4"}, "1.2.3.4", "203.0.113.5"},
+		{"trusted proxy", "10.1.2.3:1234", []string{"198.51.100.7"}, "", "198.51.100.7"},
+		{"proxy chain", "10.1.2.3:1234", []string{"1.2.3.4, 198.51.100.7, 10.0.0.2"}, "", "198.51.100.7"},
+		{"several headers", "192.168.1.1:1234", []string{"1.2.3.4", "198.51.100.7"}, "", "198.51.100.7"},
+		{"real ip", "[::1]:1234", nil, "198.51.100.7", "198.51.100.7"},
+		{"invalid hop", "10.1.2.3:1234", []string{"1.2.3.4, junk"}, "", "10.1.2.3"},
+		{"only proxies", "10.1.2.3:1234", []string{"10.0.0.2"}, "", "10.0.0.2"},
+		{"no proxies", "10.1.2.3", nil, "", "10.1.2.3"},
+	} {
+		if got := proxies.ClientIP(test.remote, test.forwarded, test.realIP); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+	if got := Proxies(nil).ClientIP("10.1.2.3:1", []string{"1.2.3.4"}, ""); got != "10.1.2.3" {
+		t.Errorf("empty list: got %q", got)
+	}
+	if _, err := ParseProxies("10.0.0.0/33"); err == nil {
+		t.Error("invalid network parsed")
+	}
+}
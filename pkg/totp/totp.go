@@ -0,0 +1,108 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- используется алгоритм по умолчанию из RFC 6238
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Настройки генерации одноразовых паролей. Большинство приложений
+// поддерживают только значения по умолчанию, поэтому менять их не стоит.
+var (
+	Digits     = 6                // количество цифр в пароле
+	Period     = time.Second * 30 // время действия одного пароля
+	Skew       = 1                // допустимое расхождение часов в периодах
+	SecretSize = 20               // длина секретного ключа в байтах
+)
+
+// encoding используется для представления секретного ключа в виде строки.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret генерирует новый случайный секретный ключ и возвращает его
+// в виде строки base32.
+func GenerateSecret() (string, error) {
+	var secret = make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI возвращает ссылку otpauth:// для добавления ключа в приложение
+// (обычно отображается в виде QR-кода).
+func URI(issuer, account, secret string) string {
+	var params = url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(Digits)},
+		"period":    {fmt.Sprint(int(Period / time.Second))},
+	}
+	var uri = url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: params.Encode(),
+	}
+	return uri.String()
+}
+
+// Counter возвращает номер периода для указанного времени.
+func Counter(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code возвращает одноразовый пароль для указанного номера периода.
+func Code(secret string, counter int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("bad totp secret: %w", err)
+	}
+	var msg = make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	var mac = hmac.New(sha1.New, key)
+	_, _ = mac.Write(msg)
+	var sum = mac.Sum(nil)
+	// динамическое усечение (RFC 4226, раздел 5.3)
+	var offset = sum[len(sum)-1] & 0x0f
+	var value = binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	var mod uint32 = 1
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate проверяет одноразовый пароль на указанное время с учетом
+// допустимого расхождения часов. Возвращает номер периода, для которого
+// пароль совпал, или 0, если пароль неверен.
+//
+// Пароли для периодов, номер которых не больше after, не принимаются: это
+// позволяет запретить повторное использование уже введенного пароля.
+func Validate(secret, code string, t time.Time, after int64) (int64, error) {
+	if len(code) != Digits {
+		return 0, nil
+	}
+	var current = Counter(t)
+	for i := -Skew; i <= Skew; i++ {
+		var counter = current + int64(i)
+		if counter <= after {
+			continue
+		}
+		expected, err := Code(secret, counter)
+		if err != nil {
+			return 0, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,80 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret задает секретный ключ для SHA-1 из тестовых векторов RFC 6238.
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+// тестовые векторы RFC 6238 (приложение B) для SHA-1 и 8 цифр
+var rfcVectors = []struct {
+	time int64
+	code string
+}{
+	{59, "94287082"},
+	{1111111109, "07081804"},
+	{1111111111, "14050471"},
+	{1234567890, "89005924"},
+	{2000000000, "69279037"},
+	{20000000000, "65353130"},
+}
+
+// withDigits временно изменяет количество цифр в пароле.
+func withDigits(t *testing.T, digits int) {
+	var saved = Digits
+	Digits = digits
+	t.Cleanup(func() { Digits = saved })
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	withDigits(t, 8)
+	for _, vector := range rfcVectors {
+		code, err := Code(rfcSecret, Counter(time.Unix(vector.time, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != vector.code {
+			t.Errorf("%d: code %s, expected %s", vector.time, code, vector.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	withDigits(t, 8)
+	for _, vector := range rfcVectors {
+		var at = time.Unix(vector.time, 0)
+		counter, err := Validate(rfcSecret, vector.code, at, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if counter != Counter(at) {
+			t.Errorf("%d: counter %d, expected %d", vector.time, counter, Counter(at))
+		}
+		// пароль принимается с учетом допустимого расхождения часов
+		counter, err = Validate(rfcSecret, vector.code, at.Add(Period), 0)
+		if err != nil || counter != Counter(at) {
+			t.Errorf("%d: skew not accepted: %d, %v", vector.time, counter, err)
+		}
+		// уже использованный пароль повторно не принимается
+		counter, err = Validate(rfcSecret, vector.code, at, Counter(at))
+		if err != nil || counter != 0 {
+			t.Errorf("%d: replayed code accepted: %d, %v", vector.time, counter, err)
+		}
+	}
+	// неверный пароль и пароль неверной длины
+	var at = time.Unix(rfcVectors[0].time, 0)
+	for _, code := range []string{"00000000", "9428708", "942870820"} {
+		counter, err := Validate(rfcSecret, code, at, 0)
+		if err != nil || counter != 0 {
+			t.Errorf("%s: invalid code accepted: %d, %v", code, counter, err)
+		}
+	}
+	// пароль вне допустимого расхождения часов не принимается
+	counter, err := Validate(rfcSecret, rfcVectors[0].code,
+		at.Add(Period*time.Duration(Skew+2)), 0)
+	if err != nil || counter != 0 {
+		t.Errorf("expired code accepted: %d, %v", counter, err)
+	}
+}